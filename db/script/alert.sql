@@ -0,0 +1,60 @@
+-- 알림 규칙/무음 (docker_service 알림 엔진, ALERT_STORE=mysql)
+-- id 는 AUTO_INCREMENT 이므로 CREATE TABLE 에서 기본키를 지정한다.
+
+-- 알림 규칙
+CREATE TABLE `alert_rule` (
+	`id`         BIGINT       NOT NULL AUTO_INCREMENT, -- 규칙 ID
+	`agent_id`   INT          NOT NULL,                -- 에이전트 ID
+	`name`       VARCHAR(100) NOT NULL,                -- 규칙 명
+	`enabled`    TINYINT(1)   NOT NULL DEFAULT 1,      -- 사용 여부
+	`spec`       JSON         NOT NULL,                -- 규칙 조건 (kind, scope, 임계값, 채널)
+	`created_at` DATETIME     NULL,                    -- 생성 일시
+	`updated_at` DATETIME     NULL,                    -- 갱신 일시
+	PRIMARY KEY (`id`)
+);
+
+-- 알림 규칙
+ALTER TABLE `alert_rule`
+	ADD INDEX `IX_alert_rule_agent` ( -- 에이전트별 조회
+	    `agent_id` -- 에이전트 ID
+	);
+
+-- 알림 무음
+CREATE TABLE `alert_silence` (
+	`id`         BIGINT       NOT NULL AUTO_INCREMENT, -- 무음 ID
+	`agent_id`   INT          NOT NULL,                -- 에이전트 ID
+	`matchers`   JSON         NOT NULL,                -- 대상 조건 (rule_id, host, container)
+	`starts_at`  DATETIME     NOT NULL,                -- 시작 일시
+	`ends_at`    DATETIME     NOT NULL,                -- 종료 일시
+	`comment`    VARCHAR(256) NULL,                    -- 설명
+	`created_by` VARCHAR(100) NULL,                    -- 등록자
+	`created_at` DATETIME     NULL,                    -- 생성 일시
+	PRIMARY KEY (`id`)
+);
+
+-- 알림 무음
+ALTER TABLE `alert_silence`
+	ADD INDEX `IX_alert_silence_agent` ( -- 에이전트별 조회 (만료 제외)
+	    `agent_id`, -- 에이전트 ID
+	    `ends_at`   -- 종료 일시
+	);
+
+-- 에이전트 -> 알림 규칙
+ALTER TABLE `alert_rule`
+	ADD CONSTRAINT `FK_agent_TO_alert_rule` -- 에이전트 -> 알림 규칙
+	FOREIGN KEY (
+	    `agent_id` -- 에이전트 ID
+	)
+	REFERENCES `agent` ( -- 에이전트
+	    `id` -- ID
+	);
+
+-- 에이전트 -> 알림 무음
+ALTER TABLE `alert_silence`
+	ADD CONSTRAINT `FK_agent_TO_alert_silence` -- 에이전트 -> 알림 무음
+	FOREIGN KEY (
+	    `agent_id` -- 에이전트 ID
+	)
+	REFERENCES `agent` ( -- 에이전트
+	    `id` -- ID
+	);
@@ -0,0 +1,13 @@
+-- loadtest(docker_service/loadtest) 용 agent 등록
+-- agent ID 2 ~ 1001, agent key 'loadtest-%04d' (loadtest 기본 키)
+-- key 는 saas_service 와 같이 해시 값으로 저장한다. ("sha256:" + hex, 운영 agent 는 Enroll 로 등록)
+
+INSERT INTO agent (id, agent_name, agent_key, agent_address, updated_at)
+SELECT s.n, CONCAT('loadtest-', LPAD(s.n, 4, '0')), CONCAT('sha256:', SHA2(CONCAT('loadtest-', LPAD(s.n, 4, '0')), 256)), NULL, now()
+FROM (
+	SELECT a.d + b.d * 10 + c.d * 100 + 2 AS n
+	FROM       (SELECT 0 d UNION ALL SELECT 1 UNION ALL SELECT 2 UNION ALL SELECT 3 UNION ALL SELECT 4 UNION ALL SELECT 5 UNION ALL SELECT 6 UNION ALL SELECT 7 UNION ALL SELECT 8 UNION ALL SELECT 9) a
+	CROSS JOIN (SELECT 0 d UNION ALL SELECT 1 UNION ALL SELECT 2 UNION ALL SELECT 3 UNION ALL SELECT 4 UNION ALL SELECT 5 UNION ALL SELECT 6 UNION ALL SELECT 7 UNION ALL SELECT 8 UNION ALL SELECT 9) b
+	CROSS JOIN (SELECT 0 d UNION ALL SELECT 1 UNION ALL SELECT 2 UNION ALL SELECT 3 UNION ALL SELECT 4 UNION ALL SELECT 5 UNION ALL SELECT 6 UNION ALL SELECT 7 UNION ALL SELECT 8 UNION ALL SELECT 9) c
+) s
+ON DUPLICATE KEY UPDATE agent_key = VALUES(agent_key);
@@ -128,6 +128,7 @@ func clearEnv() {
 
 func main() {
 	process_mode := flag.String("mode", "debug", "프로세스 실행 모드를 선택")
+	rotate_key := flag.Bool("rotate-key", false, "agent key 교체 후 종료 (AGENT_STATE_FILE)")
 	flag.Parse()
 	logger.Log.Print(2, "process mode : %s", *process_mode)
 	logger.Log.Print(2, "남은 인자들:[%v]", flag.Args())
@@ -141,6 +142,16 @@ func main() {
 		logger.Log.Error("initEnv Error...")
 	}
 
+	if ok && *rotate_key {
+		if err := app.RotateAgentKey(ct); err != nil {
+			logger.Log.Error("rotate agent key fail.. %v", err)
+			fmt.Fprintf(os.Stderr, "rotate agent key fail: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println("agent key rotated")
+		return
+	}
+
 	var ch_terminate chan bool = make(chan bool)
 	// NewApplication()
 	if ok {
@@ -1,17 +1,26 @@
 package app
 
 import (
+	"context"
+	"fmt"
+	"strings"
 	"sync"
+	"time"
 
 	"docker_service/internal/config"
 	"docker_service/internal/container"
 	evt "docker_service/internal/event2"
 	"docker_service/internal/logger"
 	"docker_service/internal/pipeline"
+	"docker_service/internal/pipeline/collector"
+	"docker_service/internal/server/alert"
 	"docker_service/internal/server/api"
+	"docker_service/internal/server/command"
 	"docker_service/internal/server/event"
+	"docker_service/internal/server/heal"
 	"docker_service/internal/server/pipe"
 	gapi "docker_service/internal/server/rpc_client"
+	"docker_service/internal/server/timeline"
 )
 
 type Application struct {
@@ -19,6 +28,10 @@ type Application struct {
 	ApiServer  *api.Server
 	PipeServer *pipe.Server
 	Gclient    *gapi.GrpcClient
+	Executor   *command.Executor
+	Alert      *alert.Engine
+	Healer     *heal.Healer
+	Timeline   *timeline.Correlator
 	config     *config.Config
 
 	eventServer *event.Server
@@ -32,6 +45,7 @@ func NewApplication(ct *container.Container, ch_terminate chan bool) *Applicatio
 
 	// event 수집 인스턴스 (evtMgr : container.Container 멤버로 관리 고려)
 	evtMgr := evt.NewEventManager(ct.DockerMng)
+	evtMgr.SetHistory(newEventHistory(ct)) // 이벤트 이력 (GET /events/history)
 	// event 수집 메니저 초기화
 	evtsvr, err := event.NewServer(wg, ct, evtMgr) // evtMgr : watch host, and 이벤트 수집
 	if err != nil {
@@ -46,30 +60,91 @@ func NewApplication(ct *container.Container, ch_terminate chan bool) *Applicatio
 		return nil
 	}
 
+	// 알림 규칙 엔진 (저장소 : ALERT_STORE, 채널 : ALERT_CHANNELS)
+	alertEng, err := alert.NewEngine(wg, ct, evtMgr, newAlertStore(ct))
+	if err != nil {
+		logger.Log.Error("Alert engine initialization fail.. %v", err)
+		return nil
+	}
+	apisvr.SetAlertEngine(alertEng)
+
+	// 자가 복구 (정책 : HEAL_POLICIES, dry-run : HEAL_DRY_RUN)
+	healer, err := heal.NewHealer(wg, ct, evtMgr)
+	if err != nil {
+		logger.Log.Error("Healer initialization fail.. %v", err)
+		return nil
+	}
+	apisvr.SetHealer(healer)
+
+	// 컨테이너 lifecycle timeline, crash loop 감지 (CRASHLOOP_THRESHOLD, CRASHLOOP_WINDOW, FLAP_THRESHOLD)
+	correlator := timeline.NewCorrelator(wg, ct, evtMgr)
+	apisvr.SetTimeline(correlator)
+
 	if ct.Config.OprMode == "aws" {
-		// Pipeline Server 초기화
-		pipeCfg := pipe.Config{
-			IntervalSec: 30, // 30초 주기
-			BufferSize:  50,
+		// Pipeline Server 초기화 (수집기 설정 : PIPELINE_COLLECTORS)
+		pipeCfg := pipe.DefaultConfig()
+		collectorCfg, err := collector.ParsePipelineConfig(ct.Config.PipelineCollectors)
+		if err != nil {
+			logger.Log.Error("Pipeline collector config error, using defaults.. %v", err)
+		}
+		pipeCfg.Collectors = collectorCfg
+		switch {
+		case ct.Config.PipelineStatsSample > 0:
+			pipeCfg.Queue.StatsSampleInterval = ct.Config.PipelineStatsSample
+		case ct.Config.PipelineStatsSample < 0:
+			pipeCfg.Queue.StatsSampleInterval = 0
 		}
+
 		pipesvr, err := pipe.NewServer(wg, ct.DockerMng, pipeCfg, ct.Config, pipeCh, evtMgr)
 		if err != nil {
 			logger.Log.Error("Pipe server initialization fail.. %v", err)
 			return nil
 		}
 
-		// init grpc client
-		// gclient, err := gapi.NewClient(wg, ct, pipeCh, "localhost:9190", "agentkey...")
-		gclient, err := gapi.NewClient(wg, ct, pipeCh, ct.Config.AwsRpcServerAddress, "agentkey...")
+		// init grpc client (자격 증명 : AGENT_STATE_FILE, 없으면 ENROLL_TOKEN 으로 등록)
+		gopts := grpcClientOptions(ct.Config)
+		if ct.Config.GrpcBatchSize > 1 {
+			gopts = append(gopts, gapi.WithBatch(ct.Config.GrpcBatchSize, ct.Config.GrpcBatchFlush))
+		}
+		if ct.Config.GrpcCompression != "" {
+			gopts = append(gopts, gapi.WithCompression(ct.Config.GrpcCompression))
+		}
+		gclient, err := gapi.NewClient(wg, ct, pipeCh, ct.Config.AwsRpcServerAddress, ct.Config.AgentKey, gopts...)
 		if err != nil {
 			logger.Log.Error("gRPC client initialization fail.. %v", err)
 			return nil
 		}
+
+		// 원격 명령 실행기 (허용 명령 : COMMAND_ALLOWLIST)
+		executor, err := command.NewExecutor(wg, ct, gclient, pipesvr)
+		if err != nil {
+			logger.Log.Error("Command executor initialization fail.. %v", err)
+			return nil
+		}
+
+		// Register/Heartbeat 정보 (지원 타입, 허용 명령, 큐 적체량)
+		gclient.SetPipeServer(pipesvr)
+		gclient.SetCommands(executor.Allowed())
+
+		// 수집 서버 연결 up/down -> 이벤트 버스 (SSE/WS 구독자)
+		gclient.SetUpstreamListener(upstreamEventPublisher(evtMgr))
+
+		// stats 샘플 -> 알림 엔진 (cpu/memory 규칙)
+		pipesvr.SetObserver(alertEng.Observe)
+
+		// pipeline 관리 API 연결
+		apisvr.SetPipeServer(pipesvr)
+		apisvr.SetGrpcClient(gclient)
+
 		return &Application{
 			wg:          wg,
 			ApiServer:   apisvr,
 			PipeServer:  pipesvr,
 			Gclient:     gclient,
+			Executor:    executor,
+			Alert:       alertEng,
+			Healer:      healer,
+			Timeline:    correlator,
 			pipeCh:      pipeCh,
 			eventServer: evtsvr,
 			config:      ct.Config,
@@ -78,6 +153,9 @@ func NewApplication(ct *container.Container, ch_terminate chan bool) *Applicatio
 	return &Application{
 		wg:          wg,
 		ApiServer:   apisvr,
+		Alert:       alertEng,
+		Healer:      healer,
+		Timeline:    correlator,
 		pipeCh:      pipeCh,
 		eventServer: evtsvr,
 		config:      ct.Config,
@@ -101,16 +179,38 @@ func (app *Application) Start() {
 		logger.Log.Print(3, "Start gRPC client..")
 		go app.Gclient.Start()
 
+		// 원격 명령 실행기 시작
+		app.wg.Add(1)
+		logger.Log.Print(3, "Start command executor..")
+		go app.Executor.Start()
 	}
 
 	// event server 시작
 	app.wg.Add(1)
 	logger.Log.Print(3, "Start event server..")
 	go app.eventServer.Start()
+
+	// 알림 엔진 시작
+	app.wg.Add(1)
+	logger.Log.Print(3, "Start alert engine..")
+	go app.Alert.Start()
+
+	// 자가 복구 시작
+	app.wg.Add(1)
+	logger.Log.Print(3, "Start healer..")
+	go app.Healer.Start()
+
+	// lifecycle 상관 분석 시작
+	app.wg.Add(1)
+	logger.Log.Print(3, "Start timeline correlator..")
+	go app.Timeline.Start()
 }
 
 func (app *Application) Shutdown() {
 	if app.config.OprMode == "aws" {
+		logger.Log.Print(3, "Shutdown command executor..")
+		app.Executor.Shutdown()
+
 		logger.Log.Print(3, "Shutdown Pipe server..")
 		app.PipeServer.Shutdown()
 		close(app.pipeCh)
@@ -120,6 +220,15 @@ func (app *Application) Shutdown() {
 	logger.Log.Print(3, "Shutdown API server..")
 	app.ApiServer.Shutdown()
 
+	logger.Log.Print(3, "Shutdown alert engine..")
+	app.Alert.Shutdown()
+
+	logger.Log.Print(3, "Shutdown healer..")
+	app.Healer.Shutdown()
+
+	logger.Log.Print(3, "Shutdown timeline correlator..")
+	app.Timeline.Shutdown()
+
 	if app.config.OprMode == "aws" {
 		logger.Log.Print(3, "Shutdown grpc client..")
 		go app.Gclient.Shutdown()
@@ -132,3 +241,112 @@ func (app *Application) Shutdown() {
 
 	logger.Log.Print(3, "Shutdown complete")
 }
+
+// grpcClientOptions 연결 보안/자격 증명 옵션 (TLS, 상태 파일)
+func grpcClientOptions(cfg *config.Config) []gapi.ClientOption {
+	var gopts []gapi.ClientOption
+	if cfg.GrpcTLS {
+		tlsCfg := gapi.TLSConfig{
+			CAFile:     cfg.GrpcCAFile,
+			CertFile:   cfg.GrpcCertFile,
+			KeyFile:    cfg.GrpcKeyFile,
+			ServerName: cfg.GrpcServerName,
+		}
+		if cfg.GrpcPins != "" {
+			tlsCfg.Pins = strings.Split(cfg.GrpcPins, ",")
+		}
+		gopts = append(gopts, gapi.WithTLS(tlsCfg))
+	}
+	if cfg.AgentStateFile != "" {
+		gopts = append(gopts, gapi.WithCredential(cfg.AgentStateFile, cfg.EnrollToken))
+	}
+	if cfg.GrpcKeepalive > 0 {
+		gopts = append(gopts, gapi.WithKeepalive(cfg.GrpcKeepalive, 0))
+	}
+	return gopts
+}
+
+// newEventHistory 이벤트 이력 저장소 (EVENT_HISTORY), mysql 초기화 실패 시 memory 로 대체
+func newEventHistory(ct *container.Container) evt.HistoryStore {
+	cfg := ct.Config
+	if strings.EqualFold(cfg.EventHistory, "mysql") {
+		hosts, err := cfg.GetDockerHosts()
+		if err != nil {
+			logger.Log.Error("parse docker hosts config error..(%v)", err)
+		}
+		hostIds := make(map[string]int, len(hosts))
+		for _, h := range hosts {
+			hostIds[h.Name] = h.Id
+		}
+
+		h, err := evt.NewDbHistory(ct.DbHnd, cfg.AgentId, hostIds)
+		if err == nil {
+			logger.Log.Print(2, "event history : mysql (agent=%d)", cfg.AgentId)
+			return h
+		}
+		logger.Log.Error("event history mysql init error, using memory.. %v", err)
+	}
+	return evt.NewMemHistory(cfg.EventHistorySize)
+}
+
+// newAlertStore 알림 규칙/무음 저장소 (ALERT_STORE), mysql 조회 실패 시 memory 로 대체
+func newAlertStore(ct *container.Container) alert.Store {
+	cfg := ct.Config
+	if !strings.EqualFold(cfg.AlertStore, "memory") {
+		s := alert.NewDbStore(ct.DbHnd, cfg.AgentId)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_, err := s.Rules(ctx)
+		if err == nil {
+			logger.Log.Print(2, "alert store : mysql (agent=%d)", cfg.AgentId)
+			return s
+		}
+		logger.Log.Error("alert store mysql init error, using memory.. %v", err)
+	}
+	return alert.NewMemStore()
+}
+
+// upstreamEventPublisher 수집 서버 연결 상태 전환을 EventManager 이벤트로 발행
+func upstreamEventPublisher(evtMgr *evt.EventManager) gapi.UpstreamListener {
+	return func(up bool, addr, reason string) {
+		action := evt.ActionDown
+		if up {
+			action = evt.ActionUp
+		}
+		attrs := map[string]string{"addr": addr}
+		if reason != "" {
+			attrs["reason"] = reason
+		}
+		evtMgr.Publish(evt.ContainerEvent{
+			Type:      evt.TypeUpstream,
+			Action:    action,
+			ActorID:   "upstream",
+			ActorName: addr,
+			Timestamp: time.Now().Unix(),
+			Attrs:     attrs,
+		})
+	}
+}
+
+// RotateAgentKey agent key 교체 (-rotate-key)
+// 새 key 는 AGENT_STATE_FILE 에 저장되며, 실행 중인 agent 는 파일 변경을 감지해 새 key 로 전환한다.
+func RotateAgentKey(ct *container.Container) error {
+	if ct.Config.AgentStateFile == "" {
+		return fmt.Errorf("AGENT_STATE_FILE is not configured")
+	}
+
+	wg := &sync.WaitGroup{}
+	gclient, err := gapi.NewClient(wg, ct, nil, ct.Config.AwsRpcServerAddress, ct.Config.AgentKey, grpcClientOptions(ct.Config)...)
+	if err != nil {
+		return err
+	}
+	defer gclient.Shutdown()
+
+	cred, err := gclient.RotateAgentKey()
+	if err != nil {
+		return err
+	}
+	logger.Log.Print(2, "agent=%d key rotated, saved to %s", cred.AgentId, ct.Config.AgentStateFile)
+	return nil
+}
@@ -0,0 +1,409 @@
+package chaos
+
+// TCP 장애 주입 프록시 (agent <-> 수집 서버 사이에 두고 GrpcClient 복구 검증)
+// - latency / jitter : chunk 마다 지연 (순서 유지)
+// - bandwidth       : 연결/방향별 초당 바이트 제한
+// - drop            : 확률적으로 chunk 를 늦게 전달 (TCP 재전송 지연 모사, 스트림은 깨지지 않음)
+// - half-open       : 연결은 유지하고 데이터만 버림 (FIN/RST 없음, 해당 연결은 해제 후에도 복구되지 않음)
+// - reset           : 주기적으로 모든 연결을 RST 로 끊음
+// 설정은 실행 중에 SetConfig 로 바꿀 수 있다.
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	chunkSize          = 16 * 1024
+	defaultDropPenalty = 200 * time.Millisecond // 최소 TCP RTO
+)
+
+// Config 장애 설정 (zero value : 그대로 전달)
+type Config struct {
+	Latency     time.Duration // 방향별 추가 지연
+	Jitter      time.Duration // 0 ~ Jitter 추가 지연
+	Bandwidth   int64         // bytes/sec (0 : 제한 없음)
+	DropRate    float64       // 0 ~ 1, chunk 별 재전송 지연 확률
+	DropPenalty time.Duration // drop 된 chunk 의 지연 (기본 200ms)
+	HalfOpen    bool          // true : 데이터를 버림 (연결 유지, 이미 half-open 된 연결은 계속 버림)
+	ResetEvery  time.Duration // 주기적 연결 끊기 (0 : 없음)
+}
+
+// ParseConfig "latency=50ms,jitter=10ms,bw=512K,drop=0.01,halfopen,reset=30s" 형식 파싱
+func ParseConfig(spec string) (Config, error) {
+	var cfg Config
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		key, val, _ := strings.Cut(part, "=")
+		var err error
+		switch strings.ToLower(key) {
+		case "latency":
+			cfg.Latency, err = time.ParseDuration(val)
+		case "jitter":
+			cfg.Jitter, err = time.ParseDuration(val)
+		case "bw", "bandwidth":
+			cfg.Bandwidth, err = parseBytes(val)
+		case "drop":
+			cfg.DropRate, err = strconv.ParseFloat(val, 64)
+			if err == nil && (cfg.DropRate < 0 || cfg.DropRate > 1) {
+				err = errors.New("must be between 0 and 1")
+			}
+		case "drop-penalty":
+			cfg.DropPenalty, err = time.ParseDuration(val)
+		case "halfopen", "half-open":
+			cfg.HalfOpen = val == "" || val == "true" || val == "1"
+		case "reset":
+			cfg.ResetEvery, err = time.ParseDuration(val)
+		default:
+			err = errors.New("unknown option")
+		}
+		if err != nil {
+			return Config{}, fmt.Errorf("chaos: %q: %w", part, err)
+		}
+	}
+	return cfg, nil
+}
+
+// parseBytes "512", "64K", "2M" (1024 단위)
+func parseBytes(s string) (int64, error) {
+	mul := int64(1)
+	switch {
+	case strings.HasSuffix(strings.ToUpper(s), "K"):
+		mul, s = 1024, s[:len(s)-1]
+	case strings.HasSuffix(strings.ToUpper(s), "M"):
+		mul, s = 1024*1024, s[:len(s)-1]
+	}
+	n, err := strconv.ParseInt(s, 10, 64)
+	return n * mul, err
+}
+
+func (c Config) String() string {
+	var parts []string
+	if c.Latency > 0 {
+		parts = append(parts, "latency="+c.Latency.String())
+	}
+	if c.Jitter > 0 {
+		parts = append(parts, "jitter="+c.Jitter.String())
+	}
+	if c.Bandwidth > 0 {
+		parts = append(parts, fmt.Sprintf("bw=%d", c.Bandwidth))
+	}
+	if c.DropRate > 0 {
+		parts = append(parts, fmt.Sprintf("drop=%g", c.DropRate))
+	}
+	if c.HalfOpen {
+		parts = append(parts, "halfopen")
+	}
+	if c.ResetEvery > 0 {
+		parts = append(parts, "reset="+c.ResetEvery.String())
+	}
+	if len(parts) == 0 {
+		return "none"
+	}
+	return strings.Join(parts, ",")
+}
+
+// Stats 프록시 통계
+type Stats struct {
+	Accepted  uint64 `json:"accepted"`
+	Active    int    `json:"active"`
+	Resets    uint64 `json:"resets"`     // 강제로 끊은 연결 수
+	BytesUp   uint64 `json:"bytes_up"`   // client -> target
+	BytesDown uint64 `json:"bytes_down"` // target -> client
+	Dropped   uint64 `json:"dropped"`    // 재전송 지연된 chunk 수
+	Discarded uint64 `json:"discarded"`  // half-open 으로 버린 바이트
+}
+
+// Proxy TCP 장애 주입 프록시
+type Proxy struct {
+	lis    net.Listener
+	target string
+	cfg    atomic.Pointer[Config]
+
+	mu    sync.Mutex
+	conns map[*link]struct{}
+	wg    sync.WaitGroup
+
+	closed   chan struct{}
+	resetCh  chan struct{} // ResetEvery 변경 알림
+	accepted atomic.Uint64
+	resets   atomic.Uint64
+	up, down atomic.Uint64
+	dropped  atomic.Uint64
+	discard  atomic.Uint64
+}
+
+// link client <-> target 연결 쌍
+type link struct {
+	client, server net.Conn
+	once           sync.Once
+	pipes          atomic.Int32 // 동작 중인 방향 수
+	dead           atomic.Bool  // half-open 이 된 적 있음 (상대가 사라진 연결과 같이 계속 버림)
+}
+
+// NewProxy listenAddr ("127.0.0.1:0" 가능) 에서 받아 target 으로 전달
+func NewProxy(listenAddr, target string, cfg Config) (*Proxy, error) {
+	lis, err := net.Listen("tcp", listenAddr)
+	if err != nil {
+		return nil, err
+	}
+	p := &Proxy{
+		lis:     lis,
+		target:  target,
+		conns:   make(map[*link]struct{}),
+		closed:  make(chan struct{}),
+		resetCh: make(chan struct{}, 1),
+	}
+	p.cfg.Store(&cfg)
+
+	p.wg.Add(2)
+	go p.acceptLoop()
+	go p.resetLoop()
+	return p, nil
+}
+
+// Addr 프록시 주소 (GrpcClient 가 접속할 주소)
+func (p *Proxy) Addr() string {
+	return p.lis.Addr().String()
+}
+
+func (p *Proxy) Config() Config {
+	return *p.cfg.Load()
+}
+
+// SetConfig 장애 설정 변경 (기존 연결에도 즉시 적용)
+func (p *Proxy) SetConfig(cfg Config) {
+	p.cfg.Store(&cfg)
+	if cfg.HalfOpen {
+		p.mu.Lock()
+		for l := range p.conns {
+			l.dead.Store(true)
+		}
+		p.mu.Unlock()
+	}
+	select {
+	case p.resetCh <- struct{}{}:
+	default:
+	}
+}
+
+// ResetAll 모든 연결을 RST 로 끊음
+func (p *Proxy) ResetAll() int {
+	p.mu.Lock()
+	links := make([]*link, 0, len(p.conns))
+	for l := range p.conns {
+		links = append(links, l)
+	}
+	p.mu.Unlock()
+
+	for _, l := range links {
+		l.reset()
+	}
+	p.resets.Add(uint64(len(links)))
+	return len(links)
+}
+
+func (p *Proxy) Stats() Stats {
+	p.mu.Lock()
+	active := len(p.conns)
+	p.mu.Unlock()
+	return Stats{
+		Accepted:  p.accepted.Load(),
+		Active:    active,
+		Resets:    p.resets.Load(),
+		BytesUp:   p.up.Load(),
+		BytesDown: p.down.Load(),
+		Dropped:   p.dropped.Load(),
+		Discarded: p.discard.Load(),
+	}
+}
+
+// Close 리스너와 모든 연결 종료 (모든 goroutine 종료까지 대기)
+func (p *Proxy) Close() error {
+	select {
+	case <-p.closed:
+		return nil
+	default:
+	}
+	close(p.closed)
+	err := p.lis.Close()
+	p.ResetAll()
+	p.wg.Wait()
+	return err
+}
+
+func (p *Proxy) acceptLoop() {
+	defer p.wg.Done()
+	for {
+		conn, err := p.lis.Accept()
+		if err != nil {
+			return
+		}
+		p.accepted.Add(1)
+
+		server, err := net.DialTimeout("tcp", p.target, 5*time.Second)
+		if err != nil {
+			conn.Close()
+			continue
+		}
+
+		l := &link{client: conn, server: server}
+		l.pipes.Store(2)
+		p.mu.Lock()
+		p.conns[l] = struct{}{}
+		p.mu.Unlock()
+
+		p.wg.Add(2)
+		go p.pipe(l, conn, server, &p.up)
+		go p.pipe(l, server, conn, &p.down)
+	}
+}
+
+func (p *Proxy) resetLoop() {
+	defer p.wg.Done()
+	for {
+		var tick <-chan time.Time
+		var timer *time.Timer
+		if every := p.Config().ResetEvery; every > 0 {
+			timer = time.NewTimer(every)
+			tick = timer.C
+		}
+		select {
+		case <-p.closed:
+			if timer != nil {
+				timer.Stop()
+			}
+			return
+		case <-p.resetCh:
+			if timer != nil {
+				timer.Stop()
+			}
+		case <-tick:
+			p.ResetAll()
+		}
+	}
+}
+
+type chunk struct {
+	data []byte
+	due  time.Time
+}
+
+// pipe src -> dst 전달 (읽기와 쓰기를 분리해 지연 중에도 계속 읽는다)
+func (p *Proxy) pipe(l *link, src, dst net.Conn, counter *atomic.Uint64) {
+	defer p.wg.Done()
+	defer func() {
+		// 양방향 모두 끝나면 연결 정리
+		if l.pipes.Add(-1) == 0 {
+			l.close()
+			p.remove(l)
+		}
+	}()
+
+	queue := make(chan chunk, 256)
+	done := make(chan struct{})
+
+	// writer : due 까지 기다렸다가 대역폭 제한을 적용해 전달
+	go func() {
+		defer close(done)
+		for c := range queue {
+			if d := time.Until(c.due); d > 0 {
+				time.Sleep(d)
+			}
+			cfg := p.Config()
+			if cfg.HalfOpen {
+				l.dead.Store(true)
+			}
+			if l.dead.Load() {
+				p.discard.Add(uint64(len(c.data)))
+				continue
+			}
+			if cfg.Bandwidth > 0 {
+				time.Sleep(time.Duration(float64(len(c.data)) / float64(cfg.Bandwidth) * float64(time.Second)))
+			}
+			if _, err := dst.Write(c.data); err != nil {
+				l.reset()
+				for range queue { // reader 가 멈추지 않도록 비움
+				}
+				return
+			}
+			counter.Add(uint64(len(c.data)))
+		}
+	}()
+
+	var last time.Time
+	buf := make([]byte, chunkSize)
+	for {
+		n, err := src.Read(buf)
+		if n > 0 {
+			cfg := p.Config()
+			due := time.Now().Add(cfg.Latency)
+			if cfg.Jitter > 0 {
+				due = due.Add(rand.N(cfg.Jitter))
+			}
+			if cfg.DropRate > 0 && rand.Float64() < cfg.DropRate {
+				penalty := cfg.DropPenalty
+				if penalty <= 0 {
+					penalty = defaultDropPenalty
+				}
+				due = due.Add(penalty)
+				p.dropped.Add(1)
+			}
+			if due.Before(last) { // 순서 유지
+				due = last
+			}
+			last = due
+			queue <- chunk{data: append([]byte(nil), buf[:n]...), due: due}
+		}
+		if err != nil {
+			close(queue)
+			<-done
+			if errors.Is(err, io.EOF) {
+				// 정상 종료 : 반대쪽에 FIN 전달
+				if tc, ok := dst.(*net.TCPConn); ok {
+					tc.CloseWrite()
+					return
+				}
+			}
+			l.reset()
+			return
+		}
+	}
+}
+
+func (p *Proxy) remove(l *link) {
+	p.mu.Lock()
+	delete(p.conns, l)
+	p.mu.Unlock()
+}
+
+// reset 양쪽 연결을 RST 로 끊음
+func (l *link) reset() {
+	l.once.Do(func() {
+		for _, c := range []net.Conn{l.client, l.server} {
+			if tc, ok := c.(*net.TCPConn); ok {
+				tc.SetLinger(0)
+			}
+			c.Close()
+		}
+	})
+}
+
+// close 양쪽 연결 정상 종료
+func (l *link) close() {
+	l.once.Do(func() {
+		l.client.Close()
+		l.server.Close()
+	})
+}
@@ -0,0 +1,250 @@
+package chaos
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestParseConfig(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    Config
+		wantErr bool
+	}{
+		{spec: "", want: Config{}},
+		{spec: "latency=50ms,jitter=10ms", want: Config{Latency: 50 * time.Millisecond, Jitter: 10 * time.Millisecond}},
+		{spec: "bw=512K", want: Config{Bandwidth: 512 * 1024}},
+		{spec: "bandwidth=2M", want: Config{Bandwidth: 2 * 1024 * 1024}},
+		{spec: "bw=100", want: Config{Bandwidth: 100}},
+		{spec: "drop=0.01,drop-penalty=1s", want: Config{DropRate: 0.01, DropPenalty: time.Second}},
+		{spec: "halfopen", want: Config{HalfOpen: true}},
+		{spec: "half-open=false", want: Config{}},
+		{spec: " reset=30s , ", want: Config{ResetEvery: 30 * time.Second}},
+		{spec: "LATENCY=1s", want: Config{Latency: time.Second}},
+		{spec: "drop=1.5", wantErr: true},
+		{spec: "drop=-0.1", wantErr: true},
+		{spec: "latency=fast", wantErr: true},
+		{spec: "bw=1G", wantErr: true},
+		{spec: "loss=0.1", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			got, err := ParseConfig(tt.spec)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseConfig(%q) = %+v, want error", tt.spec, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseConfig(%q) error: %v", tt.spec, err)
+			}
+			if got != tt.want {
+				t.Fatalf("ParseConfig(%q) = %+v, want %+v", tt.spec, got, tt.want)
+			}
+		})
+	}
+}
+
+// echoServer 받은 데이터를 그대로 돌려주는 TCP 서버
+func echoServer(t *testing.T) string {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { lis.Close() })
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				io.Copy(conn, conn)
+			}()
+		}
+	}()
+	return lis.Addr().String()
+}
+
+// roundTrip payload 전송 후 같은 길이를 읽을 때까지 걸린 시간
+func roundTrip(conn net.Conn, payload []byte, timeout time.Duration) (time.Duration, error) {
+	start := time.Now()
+	conn.SetDeadline(start.Add(timeout))
+	if _, err := conn.Write(payload); err != nil {
+		return 0, err
+	}
+	buf := make([]byte, len(payload))
+	if _, err := io.ReadFull(conn, buf); err != nil {
+		return 0, err
+	}
+	if !bytes.Equal(buf, payload) {
+		return 0, errors.New("payload mismatch")
+	}
+	return time.Since(start), nil
+}
+
+func TestProxyFaults(t *testing.T) {
+	small := []byte("ping")
+	large := bytes.Repeat([]byte("x"), 32*1024)
+
+	tests := []struct {
+		name  string
+		cfg   Config
+		check func(t *testing.T, p *Proxy, conn net.Conn)
+	}{
+		{
+			name: "passthrough",
+			check: func(t *testing.T, p *Proxy, conn net.Conn) {
+				if _, err := roundTrip(conn, small, time.Second); err != nil {
+					t.Fatal(err)
+				}
+				st := p.Stats()
+				if st.BytesUp != uint64(len(small)) || st.BytesDown != uint64(len(small)) {
+					t.Fatalf("bytes up/down = %d/%d, want %d", st.BytesUp, st.BytesDown, len(small))
+				}
+			},
+		},
+		{
+			name: "latency",
+			cfg:  Config{Latency: 100 * time.Millisecond},
+			check: func(t *testing.T, p *Proxy, conn net.Conn) {
+				d, err := roundTrip(conn, small, 2*time.Second)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if d < 200*time.Millisecond { // 방향별 지연
+					t.Fatalf("round trip %v, want >= 200ms", d)
+				}
+			},
+		},
+		{
+			name: "bandwidth",
+			cfg:  Config{Bandwidth: 64 * 1024},
+			check: func(t *testing.T, p *Proxy, conn net.Conn) {
+				d, err := roundTrip(conn, large, 5*time.Second)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if d < 450*time.Millisecond { // 32K 를 64K/s 로 전송 (양방향은 겹쳐서 진행)
+					t.Fatalf("round trip %v, want >= 450ms", d)
+				}
+			},
+		},
+		{
+			name: "drop",
+			cfg:  Config{DropRate: 1, DropPenalty: 150 * time.Millisecond},
+			check: func(t *testing.T, p *Proxy, conn net.Conn) {
+				d, err := roundTrip(conn, small, 2*time.Second)
+				if err != nil {
+					t.Fatalf("drop must delay, not lose data: %v", err)
+				}
+				if d < 300*time.Millisecond {
+					t.Fatalf("round trip %v, want >= 300ms", d)
+				}
+				if p.Stats().Dropped < 2 {
+					t.Fatalf("dropped = %d, want >= 2", p.Stats().Dropped)
+				}
+			},
+		},
+		{
+			name: "half-open",
+			cfg:  Config{HalfOpen: true},
+			check: func(t *testing.T, p *Proxy, conn net.Conn) {
+				_, err := roundTrip(conn, small, 300*time.Millisecond)
+				if !errors.Is(err, os.ErrDeadlineExceeded) {
+					t.Fatalf("round trip error = %v, want timeout (no FIN/RST)", err)
+				}
+				if p.Stats().Discarded != uint64(len(small)) {
+					t.Fatalf("discarded = %d, want %d", p.Stats().Discarded, len(small))
+				}
+
+				// 해제 후에도 이미 half-open 된 연결은 복구되지 않는다
+				p.SetConfig(Config{})
+				if _, err := roundTrip(conn, small, 300*time.Millisecond); err == nil {
+					t.Fatal("half-open connection recovered after SetConfig")
+				}
+			},
+		},
+		{
+			name: "periodic reset",
+			cfg:  Config{ResetEvery: 100 * time.Millisecond},
+			check: func(t *testing.T, p *Proxy, conn net.Conn) {
+				conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+				_, err := conn.Read(make([]byte, 1))
+				if err == nil || errors.Is(err, os.ErrDeadlineExceeded) {
+					t.Fatalf("read error = %v, want connection reset", err)
+				}
+				if p.Stats().Resets == 0 {
+					t.Fatal("resets = 0")
+				}
+			},
+		},
+		{
+			name: "reset all",
+			check: func(t *testing.T, p *Proxy, conn net.Conn) {
+				if _, err := roundTrip(conn, small, time.Second); err != nil {
+					t.Fatal(err)
+				}
+				if n := p.ResetAll(); n != 1 {
+					t.Fatalf("ResetAll = %d, want 1", n)
+				}
+				conn.SetReadDeadline(time.Now().Add(time.Second))
+				if _, err := conn.Read(make([]byte, 1)); err == nil || errors.Is(err, os.ErrDeadlineExceeded) {
+					t.Fatalf("read error = %v, want connection reset", err)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := NewProxy("127.0.0.1:0", echoServer(t), tt.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer p.Close()
+
+			conn, err := net.Dial("tcp", p.Addr())
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer conn.Close()
+
+			tt.check(t, p, conn)
+		})
+	}
+}
+
+func TestProxyCloseReleasesConnections(t *testing.T) {
+	p, err := NewProxy("127.0.0.1:0", echoServer(t), Config{Latency: time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn, err := net.Dial("tcp", p.Addr())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	conn.Write([]byte("pending"))
+
+	done := make(chan struct{})
+	go func() {
+		p.Close()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(3 * time.Second):
+		t.Fatal("Close did not return")
+	}
+	if st := p.Stats(); st.Active != 0 {
+		t.Fatalf("active = %d after Close", st.Active)
+	}
+}
@@ -38,6 +38,46 @@ type Config struct {
 	AwsRpcServerAddress string `mapstructure:"AWS_RPC_SERVER"`
 	OprMode             string `mapstructure:"OPR_MODE"`
 	AgentId             int    `mapstructure:"AGENT_ID"`
+	AgentKey            string `mapstructure:"AGENT_KEY"`        // 정적 agent key (AGENT_STATE_FILE 자격 증명이 없을 때)
+	AgentStateFile      string `mapstructure:"AGENT_STATE_FILE"` // Enroll 로 발급받은 agent ID/key 저장 파일 (0600)
+	EnrollToken         string `mapstructure:"ENROLL_TOKEN"`     // 최초 등록용 bootstrap token (단기, 1회용)
+
+	GrpcBatchSize   int           `mapstructure:"GRPC_BATCH_SIZE"`  // 0,1 : 배치 미사용
+	GrpcBatchFlush  time.Duration `mapstructure:"GRPC_BATCH_FLUSH"` // 배치 flush 주기 (ex: 500ms)
+	GrpcCompression string        `mapstructure:"GRPC_COMPRESSION"` // "" or "gzip"
+	GrpcKeepalive   time.Duration `mapstructure:"GRPC_KEEPALIVE"`   // keepalive ping 주기 (0 : 미사용, half-open 연결 감지)
+
+	GrpcTLS        bool   `mapstructure:"GRPC_TLS"`         // true : TLS 연결 (GRPC_CERT_FILE/KEY_FILE 설정 시 mTLS)
+	GrpcCAFile     string `mapstructure:"GRPC_CA_FILE"`     // 서버 인증서 CA ("" : 시스템 CA)
+	GrpcCertFile   string `mapstructure:"GRPC_CERT_FILE"`   // 클라이언트 인증서
+	GrpcKeyFile    string `mapstructure:"GRPC_KEY_FILE"`    // 클라이언트 개인키
+	GrpcServerName string `mapstructure:"GRPC_SERVER_NAME"` // 인증서 검증 서버 이름 override
+	GrpcPins       string `mapstructure:"GRPC_PINS"`        // SPKI sha256 pin (comma 구분, base64 또는 hex)
+
+	PipelineCollectors  string        `mapstructure:"PIPELINE_COLLECTORS"`   // JSON format: {"defaults":{"stat":{"interval_sec":10}},"hosts":{...}}
+	PipelineStatsSample time.Duration `mapstructure:"PIPELINE_STATS_SAMPLE"` // 호스트별 stats 전송 최소 간격 (0 : 5s, 음수 : 샘플링 안함)
+	ContainerFilters    string        `mapstructure:"CONTAINER_FILTERS"`     // JSON format: {"global":{"exclude_names":["^ci-"]},"hosts":{...}}
+	EventPolicy         string        `mapstructure:"EVENT_POLICY"`          // JSON format: {"global":{"exclude_actions":["exec_*"]},"hosts":{...}}
+
+	RedactKeyPatterns string  `mapstructure:"REDACT_KEY_PATTERNS"` // 기본 패턴에 추가 (comma 구분, ex: *DSN*,*CREDENTIAL*)
+	RedactEntropy     float64 `mapstructure:"REDACT_ENTROPY"`      // 엔트로피 임계값 (0: 기본값 4.0, 음수: 사용 안함)
+	RedactAdminUsers  string  `mapstructure:"REDACT_ADMIN_USERS"`  // 원본 조회 허용 사용자 (comma 구분)
+
+	CommandAllowlist string `mapstructure:"COMMAND_ALLOWLIST"` // 허용 원격 명령 (comma 구분, ex: start_container,collect_now / "*" : 전체)
+
+	EventHistory     string `mapstructure:"EVENT_HISTORY"`      // 이벤트 이력 저장소 ("" or memory / mysql : container_event_log)
+	EventHistorySize int    `mapstructure:"EVENT_HISTORY_SIZE"` // memory 모드 호스트별 보관 건수 (0 : 1000)
+
+	AlertStore        string        `mapstructure:"ALERT_STORE"`         // 알림 규칙/무음 저장소 ("" or mysql : alert_rule, alert_silence / memory)
+	AlertChannels     string        `mapstructure:"ALERT_CHANNELS"`      // JSON format: [{"name":"ops","type":"webhook|slack|smtp","url":"..."}]
+	AlertEvalInterval time.Duration `mapstructure:"ALERT_EVAL_INTERVAL"` // 규칙 평가/host ping 주기 (0 : 10s)
+
+	HealPolicies string `mapstructure:"HEAL_POLICIES"` // JSON format: [{"name":"crash-restart","trigger":"crash","action":"restart","max_attempts":5}]
+	HealDryRun   bool   `mapstructure:"HEAL_DRY_RUN"`  // true : 모든 정책 dry-run (동작하지 않고 기록만)
+
+	CrashLoopThreshold int           `mapstructure:"CRASHLOOP_THRESHOLD"` // window 안 crash 횟수 (0 : 3)
+	CrashLoopWindow    time.Duration `mapstructure:"CRASHLOOP_WINDOW"`    // crash loop / flapping 집계 구간 (0 : 10m)
+	FlapThreshold      int           `mapstructure:"FLAP_THRESHOLD"`      // window 안 start 횟수 (0 : 6)
 }
 
 // GetDockerHosts는 DOCKER_HOSTS JSON 문자열을 파싱하여 반환
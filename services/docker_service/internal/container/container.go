@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"docker_service/internal/config"
@@ -12,6 +13,7 @@ import (
 	"docker_service/internal/docker"
 	"docker_service/internal/logger"
 	"docker_service/internal/memory"
+	"docker_service/internal/redact"
 
 	"github.com/gdygd/goglib/databus"
 )
@@ -84,6 +86,36 @@ func NewContainer() (*Container, error) {
 	}
 	container.DockerMng = dockerMng
 
+	// 민감정보 마스킹 설정
+	var keyPatterns []string
+	if config.RedactKeyPatterns != "" {
+		keyPatterns = strings.Split(config.RedactKeyPatterns, ",")
+	}
+	if err := redact.Configure(redact.Config{
+		KeyPatterns:      keyPatterns,
+		EntropyThreshold: config.RedactEntropy,
+	}); err != nil {
+		logger.Log.Error("redact config error..(%v)", err)
+	}
+
+	// 수집 대상 컨테이너 필터 (collector, event stream 공통)
+	if dockerMng != nil {
+		filterCfg, err := docker.ParseFilterConfig(config.ContainerFilters)
+		if err != nil {
+			logger.Log.Error("parse container filters config error..(%v)", err)
+		} else if err := dockerMng.SetFilters(filterCfg); err != nil {
+			logger.Log.Error("set container filters error..(%v)", err)
+		}
+
+		// 이벤트 수집 정책 (type/action/attribute)
+		policyCfg, err := docker.ParseEventPolicyConfig(config.EventPolicy)
+		if err != nil {
+			logger.Log.Error("parse event policy config error..(%v)", err)
+		} else if err := dockerMng.SetEventPolicy(policyCfg); err != nil {
+			logger.Log.Error("set event policy error..(%v)", err)
+		}
+	}
+
 	// // init databus
 	// container.Bus = databus.NewDataBus()
 
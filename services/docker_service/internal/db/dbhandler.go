@@ -19,8 +19,19 @@ type DbHandler interface {
 	ReadUserSession(ctx context.Context, id string) (Session, error)
 	ReadHost(ctx context.Context) ([]Host, error)
 	ReadHostInfo(ctx context.Context, hostid int) (Host, error)
+	ReadContainerEventLogs(ctx context.Context, arg EventLogQuery) ([]ContainerEventLog, error)
+	ReadMaxEventLogSeq(ctx context.Context, id int) (int64, error)
+	ReadAlertRules(ctx context.Context, agentId int) ([]AlertRule, error)
+	ReadAlertSilences(ctx context.Context, agentId int) ([]AlertSilence, error)
 
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
 	CreateUserSession(ctx context.Context, arg CreateSessionParams) (Session, error)
 	DeleteUserSession(ctx context.Context, id string) error
+
+	CreateContainerEventLogs(ctx context.Context, rows []ContainerEventLog) error
+	CreateAlertRule(ctx context.Context, arg AlertRule) (int64, error)
+	CreateAlertSilence(ctx context.Context, arg AlertSilence) (int64, error)
+	UpdateAlertRule(ctx context.Context, arg AlertRule) error
+	DeleteAlertRule(ctx context.Context, agentId int, id int64) error
+	DeleteAlertSilence(ctx context.Context, agentId int, id int64) error
 }
@@ -2,6 +2,7 @@ package mdb
 
 import (
 	"context"
+	"strings"
 
 	"docker_service/internal/db"
 )
@@ -78,3 +79,65 @@ func (q *MariaDbHandler) CreateUserSession(ctx context.Context, arg db.CreateSes
 	}
 	return se, err
 }
+
+func (q *MariaDbHandler) CreateContainerEventLogs(ctx context.Context, rows []db.ContainerEventLog) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	ado := q.GetDB()
+
+	values := strings.TrimSuffix(strings.Repeat("(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?), ", len(rows)), ", ")
+	args := make([]any, 0, len(rows)*12)
+	for _, r := range rows {
+		var attrs any
+		if r.Attrs != "" {
+			attrs = r.Attrs
+		}
+		args = append(args, r.Id, r.HostId, r.ContainerId, r.ReceivedAt, r.Seq, r.Hostname,
+			r.Type, r.Action, r.ActorId, r.ActorName, r.EventTimestamp, attrs)
+	}
+
+	query := `
+	INSERT IGNORE INTO container_event_log (id, host_id, container_id, received_at, seq, hostname,
+		type, action, actor_id, actor_name, event_timestamp, attrs)
+	VALUES ` + values
+	_, err := ado.ExecContext(ctx, query, args...)
+	return err
+}
+
+func (q *MariaDbHandler) CreateAlertRule(ctx context.Context, arg db.AlertRule) (int64, error) {
+	ado := q.GetDB()
+
+	query := `
+	INSERT INTO alert_rule (agent_id, name, enabled, spec, created_at, updated_at)
+	VALUES (?, ?, ?, ?, now(), now())
+	`
+
+	res, err := ado.ExecContext(ctx, query, arg.AgentId, arg.Name, arg.Enabled, arg.Spec)
+	if err != nil {
+		return 0, err
+	}
+	return res.LastInsertId()
+}
+
+func (q *MariaDbHandler) CreateAlertSilence(ctx context.Context, arg db.AlertSilence) (int64, error) {
+	ado := q.GetDB()
+
+	query := `
+	INSERT INTO alert_silence (agent_id, matchers, starts_at, ends_at, comment, created_by, created_at)
+	VALUES (?, ?, ?, ?, ?, ?, now())
+	`
+
+	res, err := ado.ExecContext(ctx, query,
+		arg.AgentId,
+		arg.Matchers,
+		arg.StartsAt,
+		arg.EndsAt,
+		arg.Comment,
+		arg.CreatedBy,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return res.LastInsertId()
+}
@@ -14,3 +14,21 @@ func (q *MariaDbHandler) DeleteUserSession(ctx context.Context, id string) error
 
 	return nil
 }
+
+func (q *MariaDbHandler) DeleteAlertRule(ctx context.Context, agentId int, id int64) error {
+	ado := q.GetDB()
+
+	query := `DELETE FROM alert_rule WHERE id = ? AND agent_id = ?`
+
+	_, err := ado.ExecContext(ctx, query, id, agentId)
+	return err
+}
+
+func (q *MariaDbHandler) DeleteAlertSilence(ctx context.Context, agentId int, id int64) error {
+	ado := q.GetDB()
+
+	query := `DELETE FROM alert_silence WHERE id = ? AND agent_id = ?`
+
+	_, err := ado.ExecContext(ctx, query, id, agentId)
+	return err
+}
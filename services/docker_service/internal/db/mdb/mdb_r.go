@@ -2,6 +2,7 @@ package mdb
 
 import (
 	"context"
+	"database/sql"
 
 	"docker_service/internal/db"
 	"docker_service/internal/logger"
@@ -204,6 +205,201 @@ func (q *MariaDbHandler) ReadHostInfo(ctx context.Context, hostid int) (db.Host,
 	return rst, nil
 }
 
+func (q *MariaDbHandler) ReadContainerEventLogs(ctx context.Context, arg db.EventLogQuery) ([]db.ContainerEventLog, error) {
+	ado := q.GetDB()
+
+	query := `
+	select a.id, a.host_id, a.container_id, a.received_at, a.seq, ifnull(a.hostname, ''),
+		ifnull(a.type, ''), ifnull(a.action, ''), ifnull(a.actor_id, ''), ifnull(a.actor_name, ''),
+		ifnull(a.event_timestamp, 0), a.attrs
+	from container_event_log a
+	where a.id = ?
+	`
+	args := []any{arg.Id}
+	if arg.Hostname != "" {
+		query += " and a.hostname = ?"
+		args = append(args, arg.Hostname)
+	}
+	if arg.Type != "" {
+		query += " and a.type = ?"
+		args = append(args, arg.Type)
+	}
+	if arg.Action != "" {
+		query += " and a.action = ?"
+		args = append(args, arg.Action)
+	}
+	if arg.Container != "" {
+		query += " and (a.actor_id like concat(?, '%') or a.actor_name = ?)"
+		args = append(args, arg.Container, arg.Container)
+	}
+	if arg.Since > 0 {
+		query += " and a.event_timestamp >= ?"
+		args = append(args, arg.Since)
+	}
+	if arg.Until > 0 {
+		query += " and a.event_timestamp <= ?"
+		args = append(args, arg.Until)
+	}
+	if arg.BeforeSeq > 0 {
+		query += " and a.seq < ?"
+		args = append(args, arg.BeforeSeq)
+	}
+	query += " order by a.seq desc limit ?"
+	args = append(args, arg.Limit)
+
+	rows, err := ado.QueryContext(ctx, query, args...)
+	if err != nil {
+		logger.Log.Error("ReadContainerEventLogs#1 error %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rst []db.ContainerEventLog = []db.ContainerEventLog{}
+
+	for rows.Next() {
+		row := db.ContainerEventLog{}
+		var attrs sql.NullString
+		if err := rows.Scan(
+			&row.Id,
+			&row.HostId,
+			&row.ContainerId,
+			&row.ReceivedAt,
+			&row.Seq,
+			&row.Hostname,
+			&row.Type,
+			&row.Action,
+			&row.ActorId,
+			&row.ActorName,
+			&row.EventTimestamp,
+			&attrs,
+		); err != nil {
+			logger.Log.Error("ReadContainerEventLogs#2 error %v", err)
+			return nil, err
+		}
+		row.Attrs = attrs.String
+		rst = append(rst, row)
+	}
+	if err := rows.Close(); err != nil {
+		logger.Log.Error("ReadContainerEventLogs#3 error %v", err)
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		logger.Log.Error("ReadContainerEventLogs#4 error %v", err)
+		return nil, err
+	}
+	return rst, nil
+}
+
+func (q *MariaDbHandler) ReadMaxEventLogSeq(ctx context.Context, id int) (int64, error) {
+	ado := q.GetDB()
+
+	query := `
+	select ifnull(max(a.seq), 0) from container_event_log a
+	where a.id = ?
+	`
+
+	var seq int64
+	if err := ado.QueryRowContext(ctx, query, id).Scan(&seq); err != nil {
+		logger.Log.Error("ReadMaxEventLogSeq error %v", err)
+		return 0, err
+	}
+	return seq, nil
+}
+
+func (q *MariaDbHandler) ReadAlertRules(ctx context.Context, agentId int) ([]db.AlertRule, error) {
+	ado := q.GetDB()
+
+	query := `
+	select a.id, a.agent_id, a.name, a.enabled, a.spec, a.created_at, a.updated_at
+	from alert_rule a
+	where a.agent_id = ?
+	order by a.id
+	`
+
+	rows, err := ado.QueryContext(ctx, query, agentId)
+	if err != nil {
+		logger.Log.Error("ReadAlertRules#1 error %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rst []db.AlertRule = []db.AlertRule{}
+
+	for rows.Next() {
+		row := db.AlertRule{}
+		if err := rows.Scan(
+			&row.Id,
+			&row.AgentId,
+			&row.Name,
+			&row.Enabled,
+			&row.Spec,
+			&row.CreatedAt,
+			&row.UpdatedAt,
+		); err != nil {
+			logger.Log.Error("ReadAlertRules#2 error %v", err)
+			return nil, err
+		}
+		rst = append(rst, row)
+	}
+	if err := rows.Close(); err != nil {
+		logger.Log.Error("ReadAlertRules#3 error %v", err)
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		logger.Log.Error("ReadAlertRules#4 error %v", err)
+		return nil, err
+	}
+	return rst, nil
+}
+
+func (q *MariaDbHandler) ReadAlertSilences(ctx context.Context, agentId int) ([]db.AlertSilence, error) {
+	ado := q.GetDB()
+
+	query := `
+	select a.id, a.agent_id, a.matchers, a.starts_at, a.ends_at, ifnull(a.comment, ''), ifnull(a.created_by, ''), a.created_at
+	from alert_silence a
+	where a.agent_id = ?
+	  and a.ends_at > now()
+	order by a.id
+	`
+
+	rows, err := ado.QueryContext(ctx, query, agentId)
+	if err != nil {
+		logger.Log.Error("ReadAlertSilences#1 error %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rst []db.AlertSilence = []db.AlertSilence{}
+
+	for rows.Next() {
+		row := db.AlertSilence{}
+		if err := rows.Scan(
+			&row.Id,
+			&row.AgentId,
+			&row.Matchers,
+			&row.StartsAt,
+			&row.EndsAt,
+			&row.Comment,
+			&row.CreatedBy,
+			&row.CreatedAt,
+		); err != nil {
+			logger.Log.Error("ReadAlertSilences#2 error %v", err)
+			return nil, err
+		}
+		rst = append(rst, row)
+	}
+	if err := rows.Close(); err != nil {
+		logger.Log.Error("ReadAlertSilences#3 error %v", err)
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		logger.Log.Error("ReadAlertSilences#4 error %v", err)
+		return nil, err
+	}
+	return rst, nil
+}
+
 // func (q *MariaDbHandler) DeleteUserSession(ctx context.Context, id string) error {
 // 	ado := q.GetDB()
 
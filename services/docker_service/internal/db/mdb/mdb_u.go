@@ -1 +1,21 @@
 package mdb
+
+import (
+	"context"
+
+	"docker_service/internal/db"
+)
+
+func (q *MariaDbHandler) UpdateAlertRule(ctx context.Context, arg db.AlertRule) error {
+	ado := q.GetDB()
+
+	query := `
+	UPDATE alert_rule
+	   SET name = ?, enabled = ?, spec = ?, updated_at = now()
+	 WHERE id = ?
+	   AND agent_id = ?
+	`
+
+	_, err := ado.ExecContext(ctx, query, arg.Name, arg.Enabled, arg.Spec, arg.Id, arg.AgentId)
+	return err
+}
@@ -2,6 +2,7 @@ package db
 
 import (
 	"database/sql"
+	"time"
 )
 
 type CreateUserParams struct {
@@ -47,3 +48,55 @@ type Host struct {
 	HostAddress string
 	Mode        int
 }
+
+// ContainerEventLog 컨테이너 이벤트 이력 (container_event_log)
+type ContainerEventLog struct {
+	Id             int       `json:"id"`
+	HostId         int       `json:"host_id"`
+	ContainerId    string    `json:"container_id"`
+	ReceivedAt     time.Time `json:"received_at"`
+	Seq            int64     `json:"seq"`
+	Hostname       string    `json:"hostname"`
+	Type           string    `json:"type"`
+	Action         string    `json:"action"`
+	ActorId        string    `json:"actor_id"`
+	ActorName      string    `json:"actor_name"`
+	EventTimestamp int64     `json:"event_timestamp"`
+	Attrs          string    `json:"attrs"` // JSON ("" : NULL)
+}
+
+// EventLogQuery 이벤트 이력 조회 조건 (빈 값 : 조건 없음, 결과는 seq 역순)
+type EventLogQuery struct {
+	Id        int
+	Hostname  string
+	Type      string
+	Action    string
+	Container string // actor_id prefix 또는 actor_name
+	Since     int64  // event_timestamp >=
+	Until     int64  // event_timestamp <=
+	BeforeSeq int64  // seq < (0 : 조건 없음)
+	Limit     int
+}
+
+// AlertRule 알림 규칙 (alert_rule)
+type AlertRule struct {
+	Id        int64        `json:"id"`
+	AgentId   int          `json:"agent_id"`
+	Name      string       `json:"name"`
+	Enabled   bool         `json:"enabled"`
+	Spec      string       `json:"spec"` // JSON (kind, scope, 조건, 채널)
+	CreatedAt sql.NullTime `json:"created_at"`
+	UpdatedAt sql.NullTime `json:"updated_at"`
+}
+
+// AlertSilence 알림 무음 (alert_silence)
+type AlertSilence struct {
+	Id        int64        `json:"id"`
+	AgentId   int          `json:"agent_id"`
+	Matchers  string       `json:"matchers"` // JSON (rule_id, host, container)
+	StartsAt  time.Time    `json:"starts_at"`
+	EndsAt    time.Time    `json:"ends_at"`
+	Comment   string       `json:"comment"`
+	CreatedBy string       `json:"created_by"`
+	CreatedAt sql.NullTime `json:"created_at"`
+}
@@ -5,6 +5,7 @@ package docker
 
 import (
 	"context"
+	"sync/atomic"
 
 	"github.com/moby/moby/client"
 )
@@ -19,14 +20,17 @@ type DockerAPI interface {
 	ContainerStats(ctx context.Context, id string, stream bool) (client.ContainerStatsResult, error)
 
 	EventStream(ctx context.Context) client.EventsResult
-	EventStreamRaw(ctx context.Context) client.EventsResult
+	EventStreamRaw(ctx context.Context, since string) client.EventsResult
 }
 
 // Docker Host
 type Client struct {
-	cli  *client.Client
-	addr string
-	name string // host name
+	cli    *client.Client
+	addr   string
+	name   string                          // host name
+	mode   int                             // 1:docker.sock, 2:tls
+	filter atomic.Pointer[ContainerFilter] // 수집 대상 필터 (nil: 전체)
+	policy atomic.Pointer[EventPolicy]     // 이벤트 수집 정책 (nil: 기본 정책)
 }
 
 func New() (*Client, error) {
@@ -53,6 +57,30 @@ func (c *Client) Name() string {
 	return c.name
 }
 
+func (c *Client) Mode() int {
+	return c.mode
+}
+
 func (c *Client) Raw() *client.Client {
 	return c.cli
 }
+
+// SetFilter 수집 대상 컨테이너 필터 설정
+func (c *Client) SetFilter(f *ContainerFilter) {
+	c.filter.Store(f)
+}
+
+// Filter 수집 대상 컨테이너 필터 (nil이면 전체 허용)
+func (c *Client) Filter() *ContainerFilter {
+	return c.filter.Load()
+}
+
+// SetEventPolicy 이벤트 수집 정책 설정 (daemon 필터는 다음 EventStreamRaw 부터 적용)
+func (c *Client) SetEventPolicy(p *EventPolicy) {
+	c.policy.Store(p)
+}
+
+// EventPolicy 이벤트 수집 정책 (nil이면 기본 정책으로 동작)
+func (c *Client) EventPolicy() *EventPolicy {
+	return c.policy.Load()
+}
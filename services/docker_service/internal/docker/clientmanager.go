@@ -1,6 +1,7 @@
 package docker
 
 import (
+	"context"
 	"fmt"
 	"path/filepath"
 	"sync"
@@ -21,6 +22,8 @@ var certPath = ""
 type DockerClientManager struct {
 	mu      sync.RWMutex
 	clients map[string]*Client // key : container server host name
+
+	policyCfg EventPolicyConfig // 적용 중인 이벤트 수집 정책 설정
 }
 
 func SetCertpaht(path string) {
@@ -62,6 +65,7 @@ func NewDockerClientManager(hosts []HostConfig) (*DockerClientManager, error) {
 			cli:  raw,
 			addr: h.Addr,
 			name: h.Name,
+			mode: h.Mode,
 		}
 	}
 
@@ -131,6 +135,69 @@ func (m *DockerClientManager) CloseAll() {
 	}
 }
 
+// SetFilters 전역/호스트별 컨테이너 필터를 각 클라이언트에 적용
+func (m *DockerClientManager) SetFilters(cfg FilterConfig) error {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	filters := make(map[string]*ContainerFilter, len(m.clients))
+	for name := range m.clients {
+		f, err := cfg.ForHost(name)
+		if err != nil {
+			return fmt.Errorf("container filter for %s: %w", name, err)
+		}
+		filters[name] = f
+	}
+	for name, c := range m.clients {
+		c.SetFilter(filters[name])
+	}
+	return nil
+}
+
+// SetEventPolicy 전역/호스트별 이벤트 수집 정책을 각 클라이언트에 적용 (하나라도 잘못되면 적용하지 않음)
+func (m *DockerClientManager) SetEventPolicy(cfg EventPolicyConfig) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for host := range cfg.Hosts {
+		if _, ok := m.clients[host]; !ok {
+			return fmt.Errorf("event policy: docker host not found: %s", host)
+		}
+	}
+	policies := make(map[string]*EventPolicy, len(m.clients))
+	for name := range m.clients {
+		p, err := cfg.ForHost(name)
+		if err != nil {
+			return fmt.Errorf("event policy for %s: %w", name, err)
+		}
+		policies[name] = p
+	}
+	for name, c := range m.clients {
+		c.SetEventPolicy(policies[name])
+	}
+	m.policyCfg = cfg
+	return nil
+}
+
+// EventPolicyConfig 적용 중인 이벤트 수집 정책 설정 (SetEventPolicy 로 적용한 값)
+func (m *DockerClientManager) EventPolicyConfig() EventPolicyConfig {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.policyCfg
+}
+
+// EffectiveEventRules 호스트별 실제 적용 규칙 (기본값 반영)
+func (m *DockerClientManager) EffectiveEventRules() map[string]EventRules {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	rules := make(map[string]EventRules, len(m.clients))
+	for name, c := range m.clients {
+		rules[name] = c.EventPolicy().Rules()
+	}
+	return rules
+}
+
 // GetHostNames는 등록된 모든 호스트 이름을 반환
 func (m *DockerClientManager) GetHostNames() []string {
 	m.mu.RLock()
@@ -142,3 +209,13 @@ func (m *DockerClientManager) GetHostNames() []string {
 	}
 	return names
 }
+
+// Ping 호스트 docker daemon 응답 확인
+func (m *DockerClientManager) Ping(ctx context.Context, name string) error {
+	c, err := m.Get(name)
+	if err != nil {
+		return err
+	}
+	_, err = c.cli.Ping(ctx, client.PingOptions{})
+	return err
+}
@@ -2,6 +2,8 @@ package docker
 
 import (
 	"context"
+	"errors"
+	"io"
 
 	"docker_service/internal/logger"
 
@@ -12,22 +14,41 @@ import (
 
 // container list
 func (c *Client) ListContainers(ctx context.Context) ([]Container, error) {
-	results, err := c.cli.ContainerList(ctx, client.ContainerListOptions{
+	return c.listContainers(ctx, client.ContainerListOptions{
 		All: true,
-	})
+	}, nil)
+}
+
+// ListTargetContainers 필터가 적용된 수집 대상 컨테이너 목록
+// label 조건은 daemon에서 먼저 거르고, 이름 정규식/이미지 패턴/exclude 조건은 여기서 검사한다.
+func (c *Client) ListTargetContainers(ctx context.Context) ([]Container, error) {
+	f := c.Filter()
+	return c.listContainers(ctx, client.ContainerListOptions{
+		All:     true,
+		Filters: f.ListFilters(),
+	}, f)
+}
+
+func (c *Client) listContainers(ctx context.Context, opts client.ContainerListOptions, f *ContainerFilter) ([]Container, error) {
+	results, err := c.cli.ContainerList(ctx, opts)
 	if err != nil {
 		return nil, err
 	}
 
 	result := make([]Container, 0, len(results.Items))
 	for _, v := range results.Items {
-		result = append(result, Container{
+		ct := Container{
 			ID:     v.ID[:12],
 			Name:   v.Names[0][1:],
 			Image:  v.Image,
 			State:  string(v.State),
 			Status: v.Status,
-		})
+			Labels: v.Labels,
+		}
+		if !f.Match(ct.Name, ct.Image, ct.Labels) {
+			continue
+		}
+		result = append(result, ct)
 	}
 	return result, nil
 }
@@ -67,6 +88,33 @@ func (c *Client) StopContainer(ctx context.Context, id string) (client.Container
 	return c.cli.ContainerStop(ctx, id, client.ContainerStopOptions{})
 }
 
+func (c *Client) RestartContainer(ctx context.Context, id string) (client.ContainerRestartResult, error) {
+	return c.cli.ContainerRestart(ctx, id, client.ContainerRestartOptions{})
+}
+
+func (c *Client) PauseContainer(ctx context.Context, id string) (client.ContainerPauseResult, error) {
+	return c.cli.ContainerPause(ctx, id, client.ContainerPauseOptions{})
+}
+
+func (c *Client) UnpauseContainer(ctx context.Context, id string) (client.ContainerUnpauseResult, error) {
+	return c.cli.ContainerUnpause(ctx, id, client.ContainerUnpauseOptions{})
+}
+
+// RemoveContainer 컨테이너 삭제 (force : 실행 중이어도 강제 삭제)
+func (c *Client) RemoveContainer(ctx context.Context, id string, force bool) (client.ContainerRemoveResult, error) {
+	return c.cli.ContainerRemove(ctx, id, client.ContainerRemoveOptions{Force: force})
+}
+
+// PullImage 이미지 pull (완료될 때까지 대기)
+func (c *Client) PullImage(ctx context.Context, ref string) error {
+	resp, err := c.cli.ImagePull(ctx, ref, client.ImagePullOptions{})
+	if err != nil {
+		return err
+	}
+	defer resp.Close()
+	return resp.Wait(ctx)
+}
+
 // container resource monitoring
 /*
 CPU 사용률
@@ -127,15 +175,49 @@ Auto-restart 트리거
 // }
 
 // EventStreamRaw는 Docker Events API의 결과를 직접 반환 (EventManager용)
-func (c *Client) EventStreamRaw(ctx context.Context) client.EventsResult {
-	return c.cli.Events(ctx, client.EventsListOptions{})
+// 컨테이너 필터 중 daemon에서 처리 가능한 조건과 이벤트 수집 정책의 type/action 은 EventsListOptions.Filters 로 전달한다.
+// since ("sec.nanos") 가 있으면 daemon 에 남아있는 해당 시각 이후 이벤트부터 전달한다. (재연결 시 누락 방지)
+func (c *Client) EventStreamRaw(ctx context.Context, since string) client.EventsResult {
+	return c.cli.Events(ctx, client.EventsListOptions{
+		Since:   since,
+		Filters: c.EventPolicy().DaemonFilters(c.Filter().EventFilters()),
+	})
+}
+
+// EventBacklog since ~ until 사이 daemon 에 남아있는 이벤트 수(최대 limit)와 가장 오래된 이벤트 시각(unix nano)
+// 필터를 적용하지 않으므로 daemon 이벤트 버퍼가 구간을 모두 보관하고 있는지 판단하는 데 사용한다.
+func (c *Client) EventBacklog(ctx context.Context, since, until string, limit int) (int, int64, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream := c.cli.Events(ctx, client.EventsListOptions{Since: since, Until: until})
+	count := 0
+	var oldest int64
+	for {
+		select {
+		case msg := <-stream.Messages:
+			if count == 0 || msg.TimeNano < oldest {
+				oldest = msg.TimeNano
+			}
+			count++
+			if count >= limit {
+				return count, oldest, nil
+			}
+		case err := <-stream.Err:
+			if err == nil || errors.Is(err, io.EOF) {
+				return count, oldest, nil // until 도달
+			}
+			return count, oldest, err
+		}
+	}
 }
 
 func (c *Client) EventStream(ctx context.Context) client.EventsResult {
-	// 이벤트 액션 맵 초기화
-	InitEventAction()
+	policy := c.EventPolicy()
 
-	stream := c.cli.Events(ctx, client.EventsListOptions{})
+	stream := c.cli.Events(ctx, client.EventsListOptions{
+		Filters: policy.DaemonFilters(nil),
+	})
 
 	for {
 		select {
@@ -147,10 +229,10 @@ func (c *Client) EventStream(ctx context.Context) client.EventsResult {
 
 			evt := msg
 			evtType := string(evt.Type)
-			evtAction := string(evt.Action)
+			evtAction, _ := NormalizeAction(string(evt.Action))
 
-			// 필터 함수 사용
-			if !FilterEvent(evtType, evtAction) {
+			// 이벤트 수집 정책
+			if !policy.Allow(evtType, evtAction) {
 				continue
 			}
 
@@ -158,10 +240,8 @@ func (c *Client) EventStream(ctx context.Context) client.EventsResult {
 			logger.Log.Print(2, "[EVENT] type=%s action=%s time=%d id=%s", evtType, evtAction, evt.Time, evt.Actor.ID)
 
 			// Attribute 화이트리스트 출력
-			for _, key := range EvtAttribytes {
-				if v, ok := evt.Actor.Attributes[key]; ok {
-					logger.Log.Print(2, "  - %s = %s", key, v)
-				}
+			for key, v := range policy.FilterAttrs(evt.Actor.Attributes) {
+				logger.Log.Print(2, "  - %s = %s", key, v)
 			}
 
 		case <-ctx.Done():
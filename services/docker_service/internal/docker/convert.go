@@ -32,6 +32,9 @@ func ConvertInspectResult(res client.ContainerInspectResult) ContainerInspect {
             StartedAt:  c.State.StartedAt,
             FinishedAt: c.State.FinishedAt,
         }
+        if c.State.Health != nil {
+            inspect.State.Health = string(c.State.Health.Status)
+        }
     }
 
     // Config 변환
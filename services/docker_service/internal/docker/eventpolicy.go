@@ -0,0 +1,261 @@
+package docker
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/moby/moby/client"
+)
+
+// knownEventTypes docker daemon 이벤트 타입
+var knownEventTypes = []string{"container", "image", "network", "volume", "daemon", "plugin", "service", "node", "secret", "config"}
+
+// EventRules 이벤트 수집 정책 (생략한 항목은 기본값)
+// - types : 타입별 허용 action ("*" : 해당 타입 전체), 목록에 없는 타입은 제외
+// - exclude_actions : 제외 action 패턴 (*, ?), 허용 목록보다 우선
+// - attributes : 허용 attribute, label_prefixes : 허용 label prefix (ex: com.docker.compose., app.)
+type EventRules struct {
+	Types          map[string][]string `json:"types,omitempty"`
+	ExcludeActions []string            `json:"exclude_actions,omitempty"`
+	Attributes     []string            `json:"attributes,omitempty"`
+	LabelPrefixes  []string            `json:"label_prefixes,omitempty"`
+}
+
+// DefaultEventRules 기본 수집 정책
+// exec_* 는 healthcheck 마다 발생하므로 기본 제외한다. (healthcheck 결과는 health_status 로 수신)
+func DefaultEventRules() EventRules {
+	return EventRules{
+		Types: map[string][]string{
+			"container": {
+				"create", "start", "restart", "stop", "die", "kill", "pause", "unpause", "destroy",
+				"oom", "health_status", "rename", "update", "attach", "detach",
+				"exec_create", "exec_start", "exec_die",
+			},
+			"image":   {"pull", "push", "tag", "untag", "delete", "save", "load"},
+			"network": {"create", "connect", "disconnect", "destroy"},
+			"volume":  {"create", "mount", "unmount", "destroy"},
+			"daemon":  {"reload", "shutdown"},
+		},
+		ExcludeActions: []string{"exec_*"},
+		Attributes: []string{
+			"name",         // 컨테이너 이름
+			"image",        // 이미지 이름
+			"exitCode",     // 종료 코드
+			"execDuration", // 실행 시간
+			"signal",       // kill signal
+			"container",    // container ID
+			LabelComposeProject,
+			LabelComposeService,
+		},
+	}
+}
+
+// EventPolicyConfig 전역 + 호스트별 이벤트 수집 정책
+// 호스트 설정에 지정한 항목은 전역 설정을 대체한다.
+/*
+	{
+	  "global": {"exclude_actions": ["exec_*", "attach", "detach"], "label_prefixes": ["com.docker.compose.", "app."]},
+	  "hosts": {
+	    "119server": {"types": {"container": ["*"], "image": ["pull", "delete"]}}
+	  }
+	}
+*/
+type EventPolicyConfig struct {
+	Global EventRules            `json:"global"`
+	Hosts  map[string]EventRules `json:"hosts,omitempty"`
+}
+
+// ParseEventPolicyConfig JSON 문자열 파싱 (빈 문자열이면 기본 정책)
+func ParseEventPolicyConfig(raw string) (EventPolicyConfig, error) {
+	var cfg EventPolicyConfig
+	if raw == "" {
+		return cfg, nil
+	}
+	if err := json.Unmarshal([]byte(raw), &cfg); err != nil {
+		return EventPolicyConfig{}, fmt.Errorf("parse event policy config: %w", err)
+	}
+	return cfg, nil
+}
+
+// ForHost 호스트에 적용할 정책 생성
+func (cfg EventPolicyConfig) ForHost(host string) (*EventPolicy, error) {
+	rules := []EventRules{cfg.Global}
+	if hr, ok := cfg.Hosts[host]; ok {
+		rules = append(rules, hr)
+	}
+	return NewEventPolicy(rules...)
+}
+
+// merge 지정한 항목만 대체
+func (r EventRules) merge(o EventRules) EventRules {
+	if o.Types != nil {
+		r.Types = o.Types
+	}
+	if o.ExcludeActions != nil {
+		r.ExcludeActions = o.ExcludeActions
+	}
+	if o.Attributes != nil {
+		r.Attributes = o.Attributes
+	}
+	if o.LabelPrefixes != nil {
+		r.LabelPrefixes = o.LabelPrefixes
+	}
+	return r
+}
+
+// EventPolicy 컴파일된 이벤트 수집 정책
+// 생성 후 변경하지 않으므로 여러 goroutine 에서 잠금 없이 사용하며, 변경은 새 정책으로 교체한다. (Client.SetEventPolicy)
+type EventPolicy struct {
+	rules   EventRules
+	actions map[string]map[string]bool // type -> 허용 action (nil : 전체)
+	exclude []*regexp.Regexp
+	attrs   map[string]bool
+}
+
+var defaultEventPolicy, _ = NewEventPolicy()
+
+// NewEventPolicy 기본 정책에 rules 를 순서대로 적용해 생성
+func NewEventPolicy(rules ...EventRules) (*EventPolicy, error) {
+	r := DefaultEventRules()
+	for _, o := range rules {
+		r = r.merge(o)
+	}
+
+	p := &EventPolicy{
+		rules:   r,
+		actions: make(map[string]map[string]bool, len(r.Types)),
+		attrs:   make(map[string]bool, len(r.Attributes)),
+	}
+	for tp, actions := range r.Types {
+		if !Contains(knownEventTypes, tp) {
+			return nil, fmt.Errorf("unknown event type %q", tp)
+		}
+		if Contains(actions, "*") {
+			p.actions[tp] = nil
+			continue
+		}
+		set := make(map[string]bool, len(actions))
+		for _, a := range actions {
+			set[a] = true
+		}
+		p.actions[tp] = set
+	}
+	for _, pattern := range r.ExcludeActions {
+		re, err := GlobToRegexp(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exclude action %q: %w", pattern, err)
+		}
+		p.exclude = append(p.exclude, re)
+	}
+	for _, a := range r.Attributes {
+		p.attrs[a] = true
+	}
+	return p, nil
+}
+
+// Rules 적용 규칙 (기본값 반영)
+func (p *EventPolicy) Rules() EventRules {
+	if p == nil {
+		p = defaultEventPolicy
+	}
+	return p.rules
+}
+
+// Allow 이벤트 Type/Action 수집 여부 (action 은 NormalizeAction 으로 정규화된 값)
+func (p *EventPolicy) Allow(evtType, action string) bool {
+	if p == nil {
+		p = defaultEventPolicy
+	}
+	actions, ok := p.actions[evtType]
+	if !ok {
+		return false
+	}
+	if actions != nil && !actions[action] {
+		return false
+	}
+	return !p.excluded(action)
+}
+
+func (p *EventPolicy) excluded(action string) bool {
+	for _, re := range p.exclude {
+		if re.MatchString(action) {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterAttrs 허용된 attribute 와 label prefix 에 해당하는 항목만 추출
+func (p *EventPolicy) FilterAttrs(attrs map[string]string) map[string]string {
+	if p == nil {
+		p = defaultEventPolicy
+	}
+	filtered := make(map[string]string)
+	for k, v := range attrs {
+		if p.attrs[k] || p.hasLabelPrefix(k) {
+			filtered[k] = v
+		}
+	}
+	return filtered
+}
+
+func (p *EventPolicy) hasLabelPrefix(key string) bool {
+	for _, prefix := range p.rules.LabelPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// DaemonFilters Events daemon 필터에 type/event 조건 추가
+// - type : 허용 타입 (filters 에 type 조건이 이미 있으면 그 중 허용 타입만)
+// - event : 허용 action 에서 제외 패턴을 뺀 목록 ("*" 타입이 있으면 전달하지 않음)
+// daemon 은 같은 키의 값들을 OR 로 처리하므로 event 조건은 타입 구분 없는 합집합이며, 세부 판단은 Allow 로 한다.
+func (p *EventPolicy) DaemonFilters(filters client.Filters) client.Filters {
+	if p == nil {
+		p = defaultEventPolicy
+	}
+	if filters == nil {
+		filters = make(client.Filters)
+	}
+
+	var types []string
+	for tp := range p.actions {
+		if existing, ok := filters["type"]; ok && !existing[tp] {
+			continue
+		}
+		types = append(types, tp)
+	}
+	if len(types) == 0 {
+		return filters // 허용 타입 없음 : 기존 조건 유지 (Allow 에서 제외)
+	}
+	sort.Strings(types)
+	delete(filters, "type")
+	filters.Add("type", types...)
+
+	events := make(map[string]bool)
+	for _, tp := range types {
+		actions := p.actions[tp]
+		if actions == nil {
+			return filters
+		}
+		for a := range actions {
+			if !p.excluded(a) {
+				events[a] = true
+			}
+		}
+	}
+	list := make([]string, 0, len(events))
+	for a := range events {
+		list = append(list, a)
+	}
+	sort.Strings(list)
+	if len(list) > 0 {
+		filters.Add("event", list...)
+	}
+	return filters
+}
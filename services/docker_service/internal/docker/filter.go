@@ -0,0 +1,318 @@
+package docker
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/moby/moby/client"
+)
+
+const (
+	LabelComposeProject = "com.docker.compose.project"
+	LabelComposeService = "com.docker.compose.service"
+)
+
+// FilterRules 컨테이너 include/exclude 규칙
+// - include : 비어있지 않은 항목(종류)별로 하나 이상 일치해야 함 (label은 모두 일치)
+// - exclude : 하나라도 일치하면 제외
+type FilterRules struct {
+	IncludeNames    []string `json:"include_names,omitempty"`    // 컨테이너명 정규식
+	ExcludeNames    []string `json:"exclude_names,omitempty"`    // 컨테이너명 정규식
+	IncludeImages   []string `json:"include_images,omitempty"`   // 이미지 패턴 (*, ? 사용)
+	ExcludeImages   []string `json:"exclude_images,omitempty"`   // 이미지 패턴 (*, ? 사용)
+	IncludeLabels   []string `json:"include_labels,omitempty"`   // label selector : key, key=value, key!=value, !key
+	ExcludeLabels   []string `json:"exclude_labels,omitempty"`   // label selector
+	IncludeProjects []string `json:"include_projects,omitempty"` // compose project
+	ExcludeProjects []string `json:"exclude_projects,omitempty"` // compose project
+
+	// EventsContainerOnly true면 container 이벤트만 수신하고 label/project 조건을 daemon 이벤트 필터로 전달
+	// (daemon label 필터는 image/network 이벤트까지 걸러내므로 명시적으로 선택한 경우에만 사용)
+	EventsContainerOnly bool `json:"events_container_only,omitempty"`
+}
+
+// FilterConfig 전역 + 호스트별 필터 설정
+/*
+	{
+	  "global": {"exclude_names": ["^ci-runner-"], "exclude_labels": ["role=sidecar"]},
+	  "hosts": {
+	    "119server": {"include_projects": ["docker-mng"]}
+	  }
+	}
+*/
+type FilterConfig struct {
+	Global FilterRules            `json:"global"`
+	Hosts  map[string]FilterRules `json:"hosts,omitempty"`
+}
+
+// ParseFilterConfig JSON 문자열 파싱 (빈 문자열이면 필터 없음)
+func ParseFilterConfig(raw string) (FilterConfig, error) {
+	var cfg FilterConfig
+	if raw == "" {
+		return cfg, nil
+	}
+	if err := json.Unmarshal([]byte(raw), &cfg); err != nil {
+		return FilterConfig{}, fmt.Errorf("parse container filter config: %w", err)
+	}
+	return cfg, nil
+}
+
+// labelSelector 단일 label 조건
+type labelSelector struct {
+	key    string
+	value  string
+	op     string // "exists", "!exists", "=", "!="
+	pushed bool   // daemon 필터로 전달 가능 여부 (exists, =)
+}
+
+func parseLabelSelector(s string) labelSelector {
+	switch {
+	case strings.HasPrefix(s, "!"):
+		return labelSelector{key: s[1:], op: "!exists"}
+	case strings.Contains(s, "!="):
+		kv := strings.SplitN(s, "!=", 2)
+		return labelSelector{key: kv[0], value: kv[1], op: "!="}
+	case strings.Contains(s, "="):
+		kv := strings.SplitN(s, "=", 2)
+		return labelSelector{key: kv[0], value: kv[1], op: "=", pushed: true}
+	default:
+		return labelSelector{key: s, op: "exists", pushed: true}
+	}
+}
+
+func (l labelSelector) match(labels map[string]string) bool {
+	v, ok := labels[l.key]
+	switch l.op {
+	case "exists":
+		return ok
+	case "!exists":
+		return !ok
+	case "=":
+		return ok && v == l.value
+	case "!=":
+		return !ok || v != l.value
+	}
+	return false
+}
+
+// daemonTerm daemon label 필터 형식 ("key" 또는 "key=value")
+func (l labelSelector) daemonTerm() string {
+	if l.op == "=" {
+		return l.key + "=" + l.value
+	}
+	return l.key
+}
+
+// compiledRules 컴파일된 FilterRules
+type compiledRules struct {
+	includeNames    []*regexp.Regexp
+	excludeNames    []*regexp.Regexp
+	includeImages   []*regexp.Regexp
+	excludeImages   []*regexp.Regexp
+	includeLabels   []labelSelector
+	excludeLabels   []labelSelector
+	includeProjects []string
+	excludeProjects []string
+	containerOnly   bool
+}
+
+// GlobToRegexp '*', '?' 패턴을 정규식으로 변환 (전체 일치)
+func GlobToRegexp(pattern string) (*regexp.Regexp, error) {
+	quoted := regexp.QuoteMeta(pattern)
+	quoted = strings.ReplaceAll(quoted, `\*`, ".*")
+	quoted = strings.ReplaceAll(quoted, `\?`, ".")
+	return regexp.Compile("^" + quoted + "$")
+}
+
+func compileRules(r FilterRules) (*compiledRules, error) {
+	c := &compiledRules{
+		includeProjects: r.IncludeProjects,
+		excludeProjects: r.ExcludeProjects,
+		containerOnly:   r.EventsContainerOnly,
+	}
+
+	for _, p := range r.IncludeNames {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("include_names %q: %w", p, err)
+		}
+		c.includeNames = append(c.includeNames, re)
+	}
+	for _, p := range r.ExcludeNames {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("exclude_names %q: %w", p, err)
+		}
+		c.excludeNames = append(c.excludeNames, re)
+	}
+	for _, p := range r.IncludeImages {
+		re, err := GlobToRegexp(p)
+		if err != nil {
+			return nil, fmt.Errorf("include_images %q: %w", p, err)
+		}
+		c.includeImages = append(c.includeImages, re)
+	}
+	for _, p := range r.ExcludeImages {
+		re, err := GlobToRegexp(p)
+		if err != nil {
+			return nil, fmt.Errorf("exclude_images %q: %w", p, err)
+		}
+		c.excludeImages = append(c.excludeImages, re)
+	}
+	for _, s := range r.IncludeLabels {
+		c.includeLabels = append(c.includeLabels, parseLabelSelector(s))
+	}
+	for _, s := range r.ExcludeLabels {
+		c.excludeLabels = append(c.excludeLabels, parseLabelSelector(s))
+	}
+	return c, nil
+}
+
+func matchAnyRegexp(list []*regexp.Regexp, v string) bool {
+	for _, re := range list {
+		if re.MatchString(v) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *compiledRules) match(name, image string, labels map[string]string) bool {
+	project := labels[LabelComposeProject]
+
+	// exclude : 하나라도 일치하면 제외
+	if matchAnyRegexp(c.excludeNames, name) || matchAnyRegexp(c.excludeImages, image) {
+		return false
+	}
+	for _, l := range c.excludeLabels {
+		if l.match(labels) {
+			return false
+		}
+	}
+	if project != "" && Contains(c.excludeProjects, project) {
+		return false
+	}
+
+	// include : 항목별로 일치해야 함
+	if len(c.includeNames) > 0 && !matchAnyRegexp(c.includeNames, name) {
+		return false
+	}
+	if len(c.includeImages) > 0 && !matchAnyRegexp(c.includeImages, image) {
+		return false
+	}
+	for _, l := range c.includeLabels {
+		if !l.match(labels) {
+			return false
+		}
+	}
+	if len(c.includeProjects) > 0 && !Contains(c.includeProjects, project) {
+		return false
+	}
+	return true
+}
+
+// daemonLabels daemon 필터로 전달 가능한 label 조건
+func (c *compiledRules) daemonLabels() []string {
+	var terms []string
+	for _, l := range c.includeLabels {
+		if l.pushed {
+			terms = append(terms, l.daemonTerm())
+		}
+	}
+	// daemon label 필터는 AND 조건이므로 project가 1개일 때만 전달
+	if len(c.includeProjects) == 1 {
+		terms = append(terms, LabelComposeProject+"="+c.includeProjects[0])
+	}
+	return terms
+}
+
+// ContainerFilter 호스트 하나에 적용되는 필터 (전역 + 호스트 규칙)
+// nil ContainerFilter는 모든 컨테이너를 허용한다.
+type ContainerFilter struct {
+	rules []*compiledRules
+}
+
+// NewContainerFilter 전역/호스트 규칙으로 필터 생성
+func NewContainerFilter(rules ...FilterRules) (*ContainerFilter, error) {
+	f := &ContainerFilter{}
+	for _, r := range rules {
+		c, err := compileRules(r)
+		if err != nil {
+			return nil, err
+		}
+		f.rules = append(f.rules, c)
+	}
+	return f, nil
+}
+
+// ForHost 호스트에 적용할 필터 생성
+func (cfg FilterConfig) ForHost(host string) (*ContainerFilter, error) {
+	rules := []FilterRules{cfg.Global}
+	if hr, ok := cfg.Hosts[host]; ok {
+		rules = append(rules, hr)
+	}
+	return NewContainerFilter(rules...)
+}
+
+// Match 컨테이너가 수집 대상인지 확인
+func (f *ContainerFilter) Match(name, image string, labels map[string]string) bool {
+	if f == nil {
+		return true
+	}
+	name = strings.TrimPrefix(name, "/")
+	for _, r := range f.rules {
+		if !r.match(name, image, labels) {
+			return false
+		}
+	}
+	return true
+}
+
+// MatchEvent 이벤트가 수집 대상인지 확인 (container 이벤트만 검사, 그 외 타입은 통과)
+// container 이벤트의 Actor.Attributes 에는 name, image 와 컨테이너 label 이 포함된다.
+func (f *ContainerFilter) MatchEvent(evtType string, attrs map[string]string) bool {
+	if f == nil || evtType != "container" {
+		return true
+	}
+	return f.Match(attrs["name"], attrs["image"], attrs)
+}
+
+// ListFilters ContainerList daemon 필터 (label 조건)
+func (f *ContainerFilter) ListFilters() client.Filters {
+	filters := make(client.Filters)
+	if f == nil {
+		return filters
+	}
+	for _, r := range f.rules {
+		if terms := r.daemonLabels(); len(terms) > 0 {
+			filters.Add("label", terms...)
+		}
+	}
+	return filters
+}
+
+// EventFilters Events daemon 필터
+// events_container_only 가 설정된 경우에만 type=container 및 label 조건을 전달한다.
+func (f *ContainerFilter) EventFilters() client.Filters {
+	filters := make(client.Filters)
+	if f == nil || !f.containerOnly() {
+		return filters
+	}
+	filters.Add("type", "container")
+	for _, r := range f.rules {
+		if terms := r.daemonLabels(); len(terms) > 0 {
+			filters.Add("label", terms...)
+		}
+	}
+	return filters
+}
+
+func (f *ContainerFilter) containerOnly() bool {
+	for _, r := range f.rules {
+		if r.containerOnly {
+			return true
+		}
+	}
+	return false
+}
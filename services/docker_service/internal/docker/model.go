@@ -7,6 +7,7 @@ type Container struct {
 	Image  string
 	State  string
 	Status string
+	Labels map[string]string
 }
 
 type ContainerAction string
@@ -48,6 +49,7 @@ type ContainerState struct {
 	Paused     bool
 	Restarting bool
 	OOMKilled  bool
+	Health     string // healthy, unhealthy, starting ("" : healthcheck 없음)
 	Dead       bool
 	Pid        int
 	ExitCode   int
@@ -267,7 +267,7 @@ func (em *EventManager) watchHostEvents(ctx context.Context, host string, client
 
 func (em *EventManager) streamEvents(ctx context.Context, host string, client *docker.Client) error {
 	// EventStreamRaw는 블로킹하지 않고 EventsResult를 직접 반환
-	stream := client.EventStreamRaw(ctx)
+	stream := client.EventStreamRaw(ctx, "")
 
 	logger.Log.Print(2, "[EventManager] streamEvents started for host: %s", host)
 
@@ -289,15 +289,16 @@ func (em *EventManager) streamEvents(ctx context.Context, host string, client *d
 			evtType := string(msg.Type)
 			evtAction := string(msg.Action)
 
-			// 이벤트 필터링 (허용되지 않은 Type/Action은 skip)
-			if !docker.FilterEvent(evtType, evtAction) {
+			// 이벤트 수집 정책 (허용되지 않은 Type/Action은 skip)
+			policy := client.EventPolicy()
+			if action, _ := docker.NormalizeAction(evtAction); !policy.Allow(evtType, action) {
 				continue
 			}
 
 			logger.Log.Print(2, "[EventManager] Received event: type=%s action=%s", evtType, evtAction)
 
 			// Attribute 필터링
-			filteredAttrs := docker.FilterAttrs(msg.Actor.Attributes)
+			filteredAttrs := policy.FilterAttrs(msg.Actor.Attributes)
 
 			evt := ContainerEvent{
 				Host:      host,
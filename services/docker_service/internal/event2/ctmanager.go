@@ -25,7 +25,7 @@ container event manager
 [Docker Daemon]
        │
        ▼
-EventStreamRaw() ──▶ client.EventsResult{Messages, Err}
+EventStreamRaw(since) ──▶ client.EventsResult{Messages, Err}   (재연결 시 마지막 이벤트 시각부터 재수신)
        │
        ▼
 streamEvents() ──▶ <-stream.Messages
@@ -34,10 +34,10 @@ streamEvents() ──▶ <-stream.Messages
 em.eventChan ──▶ dispatcher() ──▶ broadcast()
        │
        ▼
-sub.Events ──▶ bridgeEventsToSSE() ──▶ goglib.SendSSE()
+sub.Events ──▶ handleSSE() / wsHandler() (연결별 구독, EventFilter)
        │
        ▼
-/events (SSE) ──▶ 클라이언트
+/events (SSE), /ws ──▶ 클라이언트
 
 */
 import (
@@ -45,15 +45,142 @@ import (
 	"docker_service/internal/docker"
 	"docker_service/internal/logger"
 	"fmt"
+	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
+
+	"github.com/moby/moby/api/types/events"
 )
 
+// ReplaySize 재전송용으로 보관하는 최근 이벤트 수
+const ReplaySize = 1000
+
+// daemonEventBuffer docker daemon 이 보관하는 최근 이벤트 수 (since 로 재수신 가능한 범위)
+const daemonEventBuffer = 256
+
+// streamCursor 호스트별 이벤트 수신 위치 (재연결 시 since 로 이어받기)
+type streamCursor struct {
+	lastNano    int64               // 마지막 수신 이벤트 시각 (daemon 시각, unix nano)
+	boundary    map[string]struct{} // lastNano 와 같은 시각에 수신한 이벤트 (경계 중복 제거)
+	connectedAt time.Time           // 마지막 연결 시각 (수신 이벤트가 없을 때 since 로 사용)
+}
+
+func eventKey(msg events.Message) string {
+	return string(msg.Type) + "/" + string(msg.Action) + "/" + msg.Actor.ID
+}
+
+// advance 수신 위치 갱신, 이미 수신한 이벤트(재수신 경계)이면 false
+func (c *streamCursor) advance(msg events.Message) bool {
+	nano := msg.TimeNano
+	if nano == 0 {
+		nano = msg.Time * int64(time.Second)
+	}
+
+	switch {
+	case nano < c.lastNano:
+		return false
+	case nano == c.lastNano && c.boundary != nil:
+		key := eventKey(msg)
+		if _, ok := c.boundary[key]; ok {
+			return false
+		}
+		c.boundary[key] = struct{}{}
+	default:
+		c.lastNano = nano
+		c.boundary = map[string]struct{}{eventKey(msg): {}}
+	}
+	return true
+}
+
+// since 재연결 since 값 ("sec.nanos", 최초 연결이면 "")
+func (c *streamCursor) since() string {
+	nano := c.lastNano
+	if nano == 0 {
+		if c.connectedAt.IsZero() {
+			return ""
+		}
+		nano = c.connectedAt.UnixNano()
+	}
+	return fmt.Sprintf("%d.%09d", nano/int64(time.Second), nano%int64(time.Second))
+}
+
+// spillLimit spill 구독자의 대기열 최대 이벤트 수 (초과 시 버리고 dropped 로 집계)
+const spillLimit = 100000
+
 // Subscriber는 이벤트를 받을 채널
 type Subscriber struct {
 	ID     string
 	Events chan ContainerEvent
 	Filter func(ContainerEvent) bool // optional filter
+
+	dropped atomic.Uint64 // 버퍼 full로 버려진 이벤트 수
+	spill   *spillQueue   // nil : 버퍼가 가득 차면 버림 (SubscribeSpill 이면 대기열에 쌓음)
+}
+
+// spillQueue 느린 구독자용 대기열 : broadcast 는 막히지 않고 쌓기만 하며, forwarder 가 순서대로 Events 로 전달
+type spillQueue struct {
+	mu    sync.Mutex
+	items []ContainerEvent
+	wake  chan struct{}
+	done  chan struct{}
+	once  sync.Once
+}
+
+func (q *spillQueue) push(evt ContainerEvent) bool {
+	q.mu.Lock()
+	if len(q.items) >= spillLimit {
+		q.mu.Unlock()
+		return false
+	}
+	q.items = append(q.items, evt)
+	q.mu.Unlock()
+
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+	return true
+}
+
+// pop 다음 이벤트 (비어 있으면 대기, close 후 false)
+func (q *spillQueue) pop() (ContainerEvent, bool) {
+	for {
+		q.mu.Lock()
+		if len(q.items) > 0 {
+			evt := q.items[0]
+			q.items[0] = ContainerEvent{}
+			q.items = q.items[1:]
+			q.mu.Unlock()
+			return evt, true
+		}
+		q.mu.Unlock()
+
+		select {
+		case <-q.wake:
+		case <-q.done:
+			return ContainerEvent{}, false
+		}
+	}
+}
+
+func (q *spillQueue) len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.items)
+}
+
+func (q *spillQueue) close() {
+	q.once.Do(func() { close(q.done) })
+}
+
+// SubscriberStatus 구독자 버퍼 사용 현황
+type SubscriberStatus struct {
+	ID        string `json:"id"`
+	BufferLen int    `json:"buffer_len"`
+	BufferCap int    `json:"buffer_cap"`
+	Spilled   int    `json:"spilled,omitempty"` // spill 대기열에 쌓인 이벤트 수
+	Dropped   uint64 `json:"dropped"`
 }
 
 type EventManager struct {
@@ -70,8 +197,18 @@ type EventManager struct {
 
 	// 호스트별 watcher 관리
 	watchers  map[string]context.CancelFunc
+	streams   map[string]*streamConn // 현재 연결 중인 daemon 스트림 (ReconnectHosts)
 	watcherMu sync.Mutex
 
+	// 이벤트 이력 (nil : 보관하지 않음)
+	history HistoryStore
+
+	// 재전송용 최근 이벤트 (SubscribeSince), subMu 로 보호
+	seq        uint64
+	replay     []ContainerEvent
+	replayNext int
+	replayFull bool
+
 	wg sync.WaitGroup
 }
 
@@ -81,8 +218,10 @@ func NewEventManager(docMng *docker.DockerClientManager) *EventManager {
 		// cancel:      cancel,
 		docMng:      docMng,
 		eventChan:   make(chan ContainerEvent, 100),
+		replay:      make([]ContainerEvent, ReplaySize),
 		subscribers: make(map[string]*Subscriber),
 		watchers:    make(map[string]context.CancelFunc),
+		streams:     make(map[string]*streamConn),
 	}
 }
 
@@ -103,7 +242,7 @@ func (em *EventManager) Stop() {
 	// 모든 구독자 채널 닫기 (for range 루프 종료시킴)
 	em.subMu.Lock()
 	for id, sub := range em.subscribers {
-		close(sub.Events)
+		sub.close()
 		delete(em.subscribers, id)
 		logger.Log.Print(2, "[EventManager] Closing subscriber: %s", id)
 	}
@@ -115,9 +254,24 @@ func (em *EventManager) Stop() {
 	// 내부 채널 닫기
 	close(em.eventChan)
 
+	// 이력 저장소 종료 (남은 이벤트 저장)
+	if em.history != nil {
+		em.history.Close()
+	}
+
 	logger.Log.Print(2, "[EventManager] Stopped")
 }
 
+// SetHistory 이벤트 이력 저장소 설정 (Start 전에 호출)
+func (em *EventManager) SetHistory(h HistoryStore) {
+	em.history = h
+}
+
+// History 이벤트 이력 저장소 (nil : 미설정)
+func (em *EventManager) History() HistoryStore {
+	return em.history
+}
+
 // WatchHost는 특정 호스트의 이벤트 스트림을 시작
 func (em *EventManager) WatchHost(host string) error {
 	em.watcherMu.Lock()
@@ -155,6 +309,18 @@ func (em *EventManager) UnwatchHost(host string) {
 	}
 }
 
+// ReconnectHosts 감시 중인 모든 호스트의 daemon 스트림을 다시 연결 (이벤트 수집 정책의 daemon 필터 반영)
+// 끊긴 위치부터 since 로 이어 받으므로 backoff 없이 즉시 재연결한다.
+func (em *EventManager) ReconnectHosts() {
+	em.watcherMu.Lock()
+	defer em.watcherMu.Unlock()
+
+	for host, conn := range em.streams {
+		conn.cancel()
+		logger.Log.Print(2, "[EventManager] Reconnecting host: %s", host)
+	}
+}
+
 // Subscribe는 이벤트 구독자를 등록
 func (em *EventManager) Subscribe(id string, bufferSize int, filter func(ContainerEvent) bool) *Subscriber {
 	em.subMu.Lock()
@@ -171,6 +337,101 @@ func (em *EventManager) Subscribe(id string, bufferSize int, filter func(Contain
 	return sub
 }
 
+// SubscribeSpill 이벤트를 버리지 않는 구독자 등록
+// Events 버퍼가 가득 차도 broadcast 를 막지 않고 대기열(최대 spillLimit)에 쌓아 순서대로 전달한다.
+// 이벤트를 다른 곳(수집 서버 등)으로 넘기는 구독자용이며, 대기열도 가득 차면 버리고 dropped 로 집계한다.
+func (em *EventManager) SubscribeSpill(id string, bufferSize int, filter func(ContainerEvent) bool) *Subscriber {
+	em.subMu.Lock()
+	defer em.subMu.Unlock()
+
+	sub := &Subscriber{
+		ID:     id,
+		Events: make(chan ContainerEvent, bufferSize),
+		Filter: filter,
+		spill:  &spillQueue{wake: make(chan struct{}, 1), done: make(chan struct{})},
+	}
+	em.subscribers[id] = sub
+
+	em.wg.Add(1)
+	go em.forwardSpill(sub)
+
+	logger.Log.Print(2, "[EventManager] Subscriber added: %s (spill)", id)
+	return sub
+}
+
+// forwardSpill spill 대기열 -> Events (구독 해제 시 Events 를 닫고 종료)
+func (em *EventManager) forwardSpill(sub *Subscriber) {
+	defer em.wg.Done()
+	defer close(sub.Events)
+
+	for {
+		evt, ok := sub.spill.pop()
+		if !ok {
+			return
+		}
+		select {
+		case sub.Events <- evt:
+		case <-sub.spill.done:
+			return
+		}
+	}
+}
+
+// close 구독 종료 (spill 구독자는 forwarder 가 Events 를 닫는다)
+func (s *Subscriber) close() {
+	if s.spill != nil {
+		s.spill.close()
+		return
+	}
+	// Stop()에서 이미 닫혔을 수 있으므로 recover
+	defer func() { recover() }()
+	close(s.Events)
+}
+
+// SubscribeSince 구독자 등록과 함께 lastID 이후의 최근 이벤트(filter 적용) 반환
+// backlog 에 없는 이벤트는 Events 로 전달되므로 누락이 없다. (backlog 와 Events 의 중복은 ID 로 제거)
+// complete 가 false 면 lastID 이후 이벤트 일부가 이미 backlog 에서 밀려난 경우
+func (em *EventManager) SubscribeSince(id string, bufferSize int, filter func(ContainerEvent) bool, lastID uint64) (sub *Subscriber, backlog []ContainerEvent, complete bool) {
+	em.subMu.Lock()
+	defer em.subMu.Unlock()
+
+	sub = &Subscriber{
+		ID:     id,
+		Events: make(chan ContainerEvent, bufferSize),
+		Filter: filter,
+	}
+	em.subscribers[id] = sub
+	logger.Log.Print(2, "[EventManager] Subscriber added: %s (since %d)", id, lastID)
+
+	complete = true
+	if lastID >= em.seq {
+		// lastID > seq : agent 재시작 전 ID (누락 여부를 알 수 없음)
+		return sub, nil, lastID == em.seq
+	}
+
+	n := em.replayNext
+	if em.replayFull {
+		n = len(em.replay)
+	}
+	start := em.replayNext - n
+	for i := 0; i < n; i++ {
+		e := em.replay[(start+i+len(em.replay))%len(em.replay)]
+		if i == 0 && e.ID > lastID+1 {
+			complete = false
+		}
+		if e.ID <= lastID || (filter != nil && !filter(e)) {
+			continue
+		}
+		backlog = append(backlog, e)
+	}
+	return sub, backlog, complete
+}
+
+// Dropped 버퍼 full로 버려진 이벤트 수
+func (s *Subscriber) Dropped() uint64 {
+	return s.dropped.Load()
+}
+
 // Unsubscribe는 구독자를 제거
 // Stop()에서 이미 닫혔을 수 있으므로 안전하게 처리
 func (em *EventManager) Unsubscribe(id string) {
@@ -178,16 +439,90 @@ func (em *EventManager) Unsubscribe(id string) {
 	defer em.subMu.Unlock()
 
 	if sub, exists := em.subscribers[id]; exists {
-		// Stop()에서 이미 닫혔을 수 있으므로 recover
-		func() {
-			defer func() { recover() }()
-			close(sub.Events)
-		}()
+		sub.close()
 		delete(em.subscribers, id)
 		logger.Log.Print(2, "[EventManager] Subscriber removed: %s", id)
 	}
 }
 
+// SetFilter 구독자 필터 변경 (nil : 전체 수신), 구독자가 없으면 false
+func (em *EventManager) SetFilter(id string, filter func(ContainerEvent) bool) bool {
+	em.subMu.Lock()
+	defer em.subMu.Unlock()
+
+	sub, exists := em.subscribers[id]
+	if exists {
+		sub.Filter = filter
+	}
+	return exists
+}
+
+// SubscriberStatus 구독자별 버퍼 사용 현황
+func (em *EventManager) SubscriberStatus() []SubscriberStatus {
+	em.subMu.RLock()
+	defer em.subMu.RUnlock()
+
+	result := make([]SubscriberStatus, 0, len(em.subscribers))
+	for _, sub := range em.subscribers {
+		st := SubscriberStatus{
+			ID:        sub.ID,
+			BufferLen: len(sub.Events),
+			BufferCap: cap(sub.Events),
+			Dropped:   sub.dropped.Load(),
+		}
+		if sub.spill != nil {
+			st.Spilled = sub.spill.len()
+		}
+		result = append(result, st)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result
+}
+
+// WatchedHosts 이벤트 스트림을 감시 중인 호스트 목록
+func (em *EventManager) WatchedHosts() []string {
+	em.watcherMu.Lock()
+	defer em.watcherMu.Unlock()
+
+	hosts := make([]string, 0, len(em.watchers))
+	for host := range em.watchers {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+	return hosts
+}
+
+// Publish agent 내부 이벤트(수집 서버 연결 상태 등)를 구독자에게 전달
+// 시작 전/종료 후에는 버리며, 내부 채널이 가득 차면 blocking 하지 않고 버린다. (버리면 안 되는 이벤트는 PublishWait)
+func (em *EventManager) Publish(evt ContainerEvent) {
+	if em.ctx == nil || em.ctx.Err() != nil {
+		return
+	}
+	defer func() { recover() }() // Stop()에서 eventChan 이 닫힌 경우
+
+	select {
+	case em.eventChan <- evt:
+	default:
+		logger.Log.Warn("[EventManager] event channel full, dropping %s/%s", evt.Type, evt.Action)
+	}
+}
+
+// PublishWait 내부 채널에 자리가 날 때까지 기다려 전달 (ctx 또는 EventManager 종료 시 false)
+// watcher goroutine 에서 호출 : Stop 은 watcher 종료(wg) 후 eventChan 을 닫는다.
+func (em *EventManager) PublishWait(ctx context.Context, evt ContainerEvent) bool {
+	if em.ctx == nil || em.ctx.Err() != nil {
+		return false
+	}
+	select {
+	case em.eventChan <- evt:
+		return true
+	case <-ctx.Done():
+	case <-em.ctx.Done():
+	}
+	logger.Log.Warn("[EventManager] stopped before publishing %s/%s", evt.Type, evt.Action)
+	return false
+}
+
 // dispatcher는 이벤트를 모든 구독자에게 분배
 func (em *EventManager) dispatcher() {
 	defer em.wg.Done()
@@ -200,12 +535,32 @@ func (em *EventManager) dispatcher() {
 			if !ok {
 				return
 			}
+			evt = em.stamp(evt)
 			logger.Log.Print(2, "dispatcher : %v", evt)
+			if em.history != nil {
+				em.history.Append(evt)
+			}
 			em.broadcast(evt)
 		}
 	}
 }
 
+// stamp ID 부여 및 재전송용 보관
+func (em *EventManager) stamp(evt ContainerEvent) ContainerEvent {
+	em.subMu.Lock()
+	defer em.subMu.Unlock()
+
+	em.seq++
+	evt.ID = em.seq
+
+	em.replay[em.replayNext] = evt
+	em.replayNext = (em.replayNext + 1) % len(em.replay)
+	if em.replayNext == 0 {
+		em.replayFull = true
+	}
+	return evt
+}
+
 func (em *EventManager) broadcast(evt ContainerEvent) {
 	em.subMu.RLock()
 	defer em.subMu.RUnlock()
@@ -216,12 +571,21 @@ func (em *EventManager) broadcast(evt ContainerEvent) {
 			continue
 		}
 
+		if sub.spill != nil {
+			if !sub.spill.push(evt) {
+				sub.dropped.Add(1)
+				logger.Log.Warn("[EventManager] Subscriber %s spill queue full (%d), dropping event", sub.ID, spillLimit)
+			}
+			continue
+		}
+
 		// non-blocking send
 		select {
 		case sub.Events <- evt:
 
 		default:
 			// 버퍼 가득 찼으면 skip (또는 로그)
+			sub.dropped.Add(1)
 			logger.Log.Warn("[EventManager] Subscriber %s buffer full, dropping event", sub.ID)
 		}
 	}
@@ -233,6 +597,7 @@ func (em *EventManager) watchHostEvents(ctx context.Context, host string, client
 
 	backoff := time.Second
 	maxBackoff := 30 * time.Second
+	cursor := &streamCursor{}
 
 	for {
 		select {
@@ -241,11 +606,24 @@ func (em *EventManager) watchHostEvents(ctx context.Context, host string, client
 		default:
 		}
 
-		err := em.streamEvents(ctx, host, client)
+		// 재연결 : 끊긴 구간을 daemon 이 모두 보관하고 있는지 확인 (아니면 stream_gap)
+		since := cursor.since()
+		if since != "" {
+			em.checkGap(ctx, host, client, cursor, since)
+		}
+		cursor.connectedAt = time.Now()
+
+		streamCtx, conn := em.openStream(ctx, host)
+		err := em.streamEvents(streamCtx, host, client, cursor, since)
+		em.closeStream(host, conn)
 
 		if ctx.Err() != nil {
 			return // context 취소됨
 		}
+		if streamCtx.Err() != nil {
+			backoff = time.Second // ReconnectHosts : 즉시 재연결
+			continue
+		}
 
 		// 연결 끊김 - backoff 후 재시도
 		logger.Log.Warn("[EventManager] Host %s stream disconnected: %v, retrying in %v",
@@ -265,11 +643,83 @@ func (em *EventManager) watchHostEvents(ctx context.Context, host string, client
 	}
 }
 
-func (em *EventManager) streamEvents(ctx context.Context, host string, client *docker.Client) error {
+// streamConn 호스트별 daemon 스트림 연결
+type streamConn struct {
+	cancel context.CancelFunc
+}
+
+func (em *EventManager) openStream(ctx context.Context, host string) (context.Context, *streamConn) {
+	streamCtx, cancel := context.WithCancel(ctx)
+	conn := &streamConn{cancel: cancel}
+
+	em.watcherMu.Lock()
+	em.streams[host] = conn
+	em.watcherMu.Unlock()
+	return streamCtx, conn
+}
+
+func (em *EventManager) closeStream(host string, conn *streamConn) {
+	conn.cancel()
+
+	em.watcherMu.Lock()
+	if em.streams[host] == conn { // UnwatchHost 후 다시 watch 한 경우 새 연결은 유지
+		delete(em.streams, host)
+	}
+	em.watcherMu.Unlock()
+}
+
+// checkGap since 이후 이벤트가 daemon 버퍼 크기 이상이면 앞부분이 유실되었을 수 있으므로 stream_gap 이벤트 발행
+// since 는 마지막 수신 시각을 포함하므로 daemon 이 그 이벤트를 보관 중이면 backlog 는 1 이상이다.
+// backlog 가 비어 있으면 daemon 재시작 등으로 이력이 사라진 것이므로 gap 으로 본다.
+func (em *EventManager) checkGap(ctx context.Context, host string, client *docker.Client, cursor *streamCursor, since string) {
+	now := time.Now()
+	until := fmt.Sprintf("%d.%09d", now.Unix(), now.Nanosecond())
+
+	bctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	count, oldest, err := client.EventBacklog(bctx, since, until, daemonEventBuffer)
+
+	var reason string
+	switch {
+	case err != nil:
+		reason = fmt.Sprintf("backlog check failed: %v", err)
+	case count >= daemonEventBuffer && oldest > cursor.lastNano:
+		reason = fmt.Sprintf("daemon event buffer (%d) exceeded", daemonEventBuffer)
+	case count == 0 && cursor.lastNano > 0:
+		reason = "daemon event history lost (daemon restarted)"
+	default:
+		logger.Log.Print(2, "[EventManager] Host %s resume since %s, backlog %d", host, since, count)
+		return
+	}
+	if ctx.Err() != nil {
+		return
+	}
+
+	sinceTime := cursor.connectedAt
+	if cursor.lastNano > 0 {
+		sinceTime = time.Unix(0, cursor.lastNano)
+	}
+	logger.Log.Warn("[EventManager] Host %s event stream gap %s ~ %s: %s",
+		host, sinceTime.Format(time.RFC3339), now.Format(time.RFC3339), reason)
+
+	em.PublishWait(ctx, ContainerEvent{
+		Host:      host,
+		Type:      TypeStream,
+		Action:    ActionStreamGap,
+		Timestamp: now.Unix(),
+		Attrs: map[string]string{
+			"since":  sinceTime.Format(time.RFC3339Nano),
+			"until":  now.Format(time.RFC3339Nano),
+			"reason": reason,
+		},
+	})
+}
+
+func (em *EventManager) streamEvents(ctx context.Context, host string, client *docker.Client, cursor *streamCursor, since string) error {
 	// EventStreamRaw는 블로킹하지 않고 EventsResult를 직접 반환
-	stream := client.EventStreamRaw(ctx)
+	stream := client.EventStreamRaw(ctx, since)
 
-	logger.Log.Print(2, "[EventManager] streamEvents started for host: %s", host)
+	logger.Log.Print(2, "[EventManager] streamEvents started for host: %s (since: %q)", host, since)
 
 	for {
 		select {
@@ -286,18 +736,32 @@ func (em *EventManager) streamEvents(ctx context.Context, host string, client *d
 				return fmt.Errorf("event channel closed")
 			}
 
+			// 수신 위치 갱신 (필터 전, 재연결 경계의 중복 이벤트 skip)
+			if !cursor.advance(msg) {
+				continue
+			}
+
 			evtType := string(msg.Type)
-			evtAction := string(msg.Action)
+			evtAction, health := docker.NormalizeAction(string(msg.Action))
 
-			// 이벤트 필터링 (허용되지 않은 Type/Action은 skip)
-			if !docker.FilterEvent(evtType, evtAction) {
+			// 이벤트 수집 정책 (허용되지 않은 Type/Action은 skip)
+			policy := client.EventPolicy()
+			if !policy.Allow(evtType, evtAction) {
+				continue
+			}
+
+			// 컨테이너 필터링 (수집 대상이 아닌 컨테이너 이벤트는 skip)
+			if !client.Filter().MatchEvent(evtType, msg.Actor.Attributes) {
 				continue
 			}
 
 			logger.Log.Print(2, "[EventManager] Received event: type=%s action=%s", evtType, evtAction)
 
 			// Attribute 필터링
-			filteredAttrs := docker.FilterAttrs(msg.Actor.Attributes)
+			filteredAttrs := policy.FilterAttrs(msg.Actor.Attributes)
+			if evtAction == docker.ActionHealthStatus && health != "" {
+				filteredAttrs[docker.AttrHealthStatus] = health
+			}
 
 			evt := ContainerEvent{
 				Host:      host,
@@ -0,0 +1,116 @@
+package event2
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	"docker_service/internal/docker"
+)
+
+// EventFilter 구독자별 이벤트 필터 (SSE/WebSocket 클라이언트)
+// 항목(종류)별로 하나 이상 일치해야 하며, 비어있는 항목은 조건 없음
+type EventFilter struct {
+	Hosts      []string `json:"hosts,omitempty"`
+	Types      []string `json:"types,omitempty"`      // container, image, network, upstream ...
+	Actions    []string `json:"actions,omitempty"`    // start, die ...
+	Containers []string `json:"containers,omitempty"` // 컨테이너 이름/ID 패턴 (*, ? 사용)
+	Projects   []string `json:"projects,omitempty"`   // compose project
+}
+
+// ParseEventFilterQuery query 파라미터 -> 필터
+// host, type, action, container, project (반복 또는 comma 구분)
+//
+//	/events?host=119server&type=container&action=start,die&container=web-*
+func ParseEventFilterQuery(values url.Values) (EventFilter, error) {
+	f := EventFilter{
+		Hosts:      queryList(values, "host"),
+		Types:      queryList(values, "type"),
+		Actions:    queryList(values, "action"),
+		Containers: queryList(values, "container"),
+		Projects:   queryList(values, "project"),
+	}
+	return f, f.Validate()
+}
+
+func queryList(values url.Values, key string) []string {
+	var list []string
+	for _, v := range values[key] {
+		for _, s := range strings.Split(v, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				list = append(list, s)
+			}
+		}
+	}
+	return list
+}
+
+// Validate 컨테이너 패턴 검사
+func (f EventFilter) Validate() error {
+	for _, p := range f.Containers {
+		if _, err := path.Match(p, ""); err != nil {
+			return fmt.Errorf("invalid container pattern %q: %w", p, err)
+		}
+	}
+	return nil
+}
+
+// Empty 조건 없음 여부
+func (f EventFilter) Empty() bool {
+	return len(f.Hosts) == 0 && len(f.Types) == 0 && len(f.Actions) == 0 &&
+		len(f.Containers) == 0 && len(f.Projects) == 0
+}
+
+// Match 필터 일치 여부
+func (f EventFilter) Match(evt ContainerEvent) bool {
+	if len(f.Hosts) > 0 && !contains(f.Hosts, evt.Host) {
+		return false
+	}
+	if len(f.Types) > 0 && !contains(f.Types, evt.Type) {
+		return false
+	}
+	if len(f.Actions) > 0 && !contains(f.Actions, evt.Action) {
+		return false
+	}
+	if len(f.Containers) > 0 && !matchAny(f.Containers, evt.ActorName, evt.ActorID) {
+		return false
+	}
+	if len(f.Projects) > 0 && !contains(f.Projects, evt.Attrs[docker.LabelComposeProject]) {
+		return false
+	}
+	return true
+}
+
+// Func Subscribe 용 필터 함수 (조건이 없으면 nil : 전체 수신)
+func (f EventFilter) Func() func(ContainerEvent) bool {
+	if f.Empty() {
+		return nil
+	}
+	return f.Match
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// matchAny 패턴 중 하나가 이름 또는 ID 와 일치 (ID 는 short ID 처럼 prefix 도 허용)
+func matchAny(patterns []string, name, id string) bool {
+	for _, p := range patterns {
+		if ok, _ := path.Match(p, name); ok && name != "" {
+			return true
+		}
+		if ok, _ := path.Match(p, id); ok && id != "" {
+			return true
+		}
+		if id != "" && len(p) >= 4 && !strings.ContainsAny(p, "*?[") && strings.HasPrefix(id, p) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,197 @@
+package event2
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// 이벤트 이력 (broadcast 후 사라지는 이벤트를 보관, UI 재접속/재시작 후 조회용)
+// - memory : 호스트별 최근 N건 (ring buffer)
+// - mysql  : container_event_log 테이블 (history_db.go)
+// 모든 이벤트는 seq(단조 증가)를 부여받고, 조회는 seq 역순(최신 먼저)이며 cursor 는 마지막 seq 이다.
+
+const (
+	DefaultHistorySize  = 1000 // 호스트별 보관 건수 (memory)
+	DefaultHistoryLimit = 100
+	MaxHistoryLimit     = 1000
+)
+
+// HistoryQuery 이벤트 이력 조회 조건 (빈 값 : 조건 없음)
+type HistoryQuery struct {
+	Host      string
+	Type      string
+	Action    string
+	Container string // 컨테이너 ID(prefix) 또는 이름
+	Since     int64  // unix sec (이벤트 발생 시각, 포함)
+	Until     int64  // unix sec (포함)
+	Limit     int
+	Cursor    string // 이전 페이지의 next_cursor
+}
+
+// HistoryPage 조회 결과 (events 는 live SSE 와 같은 ContainerEvent JSON)
+type HistoryPage struct {
+	Events     []ContainerEvent `json:"events"`
+	NextCursor string           `json:"next_cursor,omitempty"` // "" : 마지막 페이지
+}
+
+// HistoryStore 이벤트 이력 저장소
+type HistoryStore interface {
+	Append(evt ContainerEvent)
+	Query(ctx context.Context, q HistoryQuery) (HistoryPage, error)
+	Close()
+}
+
+// Normalize limit 기본값/최대값 적용 및 cursor 검증, cursor seq 반환 (0 : 처음부터)
+func (q *HistoryQuery) Normalize() (uint64, error) {
+	if q.Limit <= 0 {
+		q.Limit = DefaultHistoryLimit
+	}
+	if q.Limit > MaxHistoryLimit {
+		q.Limit = MaxHistoryLimit
+	}
+	if q.Cursor == "" {
+		return 0, nil
+	}
+	seq, err := strconv.ParseUint(q.Cursor, 10, 64)
+	if err != nil || seq == 0 {
+		return 0, fmt.Errorf("invalid cursor %q", q.Cursor)
+	}
+	return seq, nil
+}
+
+// Match 조회 조건 일치 여부 (seq/limit 제외)
+func (q HistoryQuery) Match(evt ContainerEvent) bool {
+	if q.Host != "" && evt.Host != q.Host {
+		return false
+	}
+	if q.Type != "" && evt.Type != q.Type {
+		return false
+	}
+	if q.Action != "" && evt.Action != q.Action {
+		return false
+	}
+	if q.Container != "" && evt.ActorName != q.Container && !strings.HasPrefix(evt.ActorID, q.Container) {
+		return false
+	}
+	if q.Since > 0 && evt.Timestamp < q.Since {
+		return false
+	}
+	if q.Until > 0 && evt.Timestamp > q.Until {
+		return false
+	}
+	return true
+}
+
+func formatCursor(seq uint64) string {
+	return strconv.FormatUint(seq, 10)
+}
+
+// historyEntry seq 가 부여된 이벤트
+type historyEntry struct {
+	seq uint64
+	evt ContainerEvent
+}
+
+// hostRing 호스트별 ring buffer
+type hostRing struct {
+	items []historyEntry
+	next  int // 다음 쓰기 위치
+	full  bool
+}
+
+func (r *hostRing) add(e historyEntry) {
+	r.items[r.next] = e
+	r.next = (r.next + 1) % len(r.items)
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+// each 최신 -> 과거 순회 (fn 이 false 면 중단)
+func (r *hostRing) each(fn func(historyEntry) bool) {
+	n := r.next
+	if r.full {
+		n = len(r.items)
+	}
+	for i := 1; i <= n; i++ {
+		idx := (r.next - i + len(r.items)) % len(r.items)
+		if !fn(r.items[idx]) {
+			return
+		}
+	}
+}
+
+// MemHistory 메모리 이벤트 이력 (호스트별 최근 size 건)
+type MemHistory struct {
+	mu    sync.RWMutex
+	size  int
+	seq   uint64
+	hosts map[string]*hostRing
+}
+
+func NewMemHistory(size int) *MemHistory {
+	if size <= 0 {
+		size = DefaultHistorySize
+	}
+	return &MemHistory{size: size, hosts: make(map[string]*hostRing)}
+}
+
+func (h *MemHistory) Append(evt ContainerEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	r, ok := h.hosts[evt.Host]
+	if !ok {
+		r = &hostRing{items: make([]historyEntry, h.size)}
+		h.hosts[evt.Host] = r
+	}
+	h.seq++
+	r.add(historyEntry{seq: h.seq, evt: evt})
+}
+
+func (h *MemHistory) Query(_ context.Context, q HistoryQuery) (HistoryPage, error) {
+	before, err := q.Normalize()
+	if err != nil {
+		return HistoryPage{}, err
+	}
+
+	h.mu.RLock()
+	var found []historyEntry
+	for host, r := range h.hosts {
+		if q.Host != "" && host != q.Host {
+			continue
+		}
+		// 호스트별로 최신부터 limit+1 건까지만 수집 (다음 페이지 여부 확인용 1건)
+		n := 0
+		r.each(func(e historyEntry) bool {
+			if before > 0 && e.seq >= before {
+				return true
+			}
+			if !q.Match(e.evt) {
+				return true
+			}
+			found = append(found, e)
+			n++
+			return n <= q.Limit
+		})
+	}
+	h.mu.RUnlock()
+
+	sort.Slice(found, func(i, j int) bool { return found[i].seq > found[j].seq })
+
+	page := HistoryPage{Events: make([]ContainerEvent, 0, q.Limit)}
+	for i, e := range found {
+		if i == q.Limit {
+			page.NextCursor = formatCursor(found[i-1].seq)
+			break
+		}
+		page.Events = append(page.Events, e.evt)
+	}
+	return page, nil
+}
+
+func (h *MemHistory) Close() {}
@@ -0,0 +1,182 @@
+package event2
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"docker_service/internal/db"
+	"docker_service/internal/logger"
+)
+
+const (
+	dbHistoryQueue    = 1000            // 저장 대기 이벤트 수 (초과 시 버림)
+	dbHistoryBatch    = 100             // 1회 INSERT 건수
+	dbHistoryFlush    = time.Second     // flush 주기
+	dbHistoryTimeout  = 5 * time.Second // INSERT/조회 제한 시간
+	containerIdMaxLen = 64              // container_event_log.container_id
+)
+
+// DbHistory MySQL(container_event_log) 이벤트 이력
+// Append 는 blocking 하지 않도록 큐에 넣고 writer goroutine 이 배치로 INSERT 한다.
+type DbHistory struct {
+	dbHnd   db.DbHandler
+	agentId int
+	hostIds map[string]int // 호스트명 -> host_id (DOCKER_HOSTS)
+
+	seq     atomic.Int64
+	queue   chan db.ContainerEventLog
+	dropped atomic.Uint64
+
+	closeOnce sync.Once
+	done      chan struct{}
+}
+
+// NewDbHistory seq 는 기존 이력의 최대값부터 이어서 부여
+func NewDbHistory(dbHnd db.DbHandler, agentId int, hostIds map[string]int) (*DbHistory, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbHistoryTimeout)
+	defer cancel()
+
+	maxSeq, err := dbHnd.ReadMaxEventLogSeq(ctx, agentId)
+	if err != nil {
+		return nil, err
+	}
+
+	h := &DbHistory{
+		dbHnd:   dbHnd,
+		agentId: agentId,
+		hostIds: hostIds,
+		queue:   make(chan db.ContainerEventLog, dbHistoryQueue),
+		done:    make(chan struct{}),
+	}
+	h.seq.Store(maxSeq)
+
+	go h.writer()
+	return h, nil
+}
+
+func (h *DbHistory) Append(evt ContainerEvent) {
+	row := db.ContainerEventLog{
+		Id:             h.agentId,
+		HostId:         h.hostIds[evt.Host],
+		ContainerId:    evt.ActorID,
+		ReceivedAt:     time.Now(),
+		Seq:            h.seq.Add(1),
+		Hostname:       evt.Host,
+		Type:           evt.Type,
+		Action:         evt.Action,
+		ActorId:        evt.ActorID,
+		ActorName:      evt.ActorName,
+		EventTimestamp: evt.Timestamp,
+	}
+	if len(row.ContainerId) > containerIdMaxLen {
+		row.ContainerId = row.ContainerId[:containerIdMaxLen]
+	}
+	if len(evt.Attrs) > 0 {
+		if b, err := json.Marshal(evt.Attrs); err == nil {
+			row.Attrs = string(b)
+		}
+	}
+
+	defer func() { recover() }() // Close() 후 호출
+	select {
+	case h.queue <- row:
+	default:
+		if h.dropped.Add(1)%100 == 1 {
+			logger.Log.Warn("[EventHistory] write queue full, dropped=%d", h.dropped.Load())
+		}
+	}
+}
+
+// writer 큐의 이벤트를 배치로 INSERT (Close 시 남은 이벤트 flush 후 종료)
+func (h *DbHistory) writer() {
+	defer close(h.done)
+
+	ticker := time.NewTicker(dbHistoryFlush)
+	defer ticker.Stop()
+
+	batch := make([]db.ContainerEventLog, 0, dbHistoryBatch)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), dbHistoryTimeout)
+		if err := h.dbHnd.CreateContainerEventLogs(ctx, batch); err != nil {
+			logger.Log.Error("[EventHistory] insert %d events error: %v", len(batch), err)
+		}
+		cancel()
+		batch = batch[:0]
+	}
+
+	for {
+		select {
+		case row, ok := <-h.queue:
+			if !ok {
+				flush()
+				return
+			}
+			batch = append(batch, row)
+			if len(batch) >= dbHistoryBatch {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
+
+func (h *DbHistory) Query(ctx context.Context, q HistoryQuery) (HistoryPage, error) {
+	before, err := q.Normalize()
+	if err != nil {
+		return HistoryPage{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, dbHistoryTimeout)
+	defer cancel()
+
+	rows, err := h.dbHnd.ReadContainerEventLogs(ctx, db.EventLogQuery{
+		Id:        h.agentId,
+		Hostname:  q.Host,
+		Type:      q.Type,
+		Action:    q.Action,
+		Container: q.Container,
+		Since:     q.Since,
+		Until:     q.Until,
+		BeforeSeq: int64(before),
+		Limit:     q.Limit + 1, // 다음 페이지 여부 확인용 1건
+	})
+	if err != nil {
+		return HistoryPage{}, err
+	}
+
+	page := HistoryPage{Events: make([]ContainerEvent, 0, len(rows))}
+	for i, row := range rows {
+		if i == q.Limit {
+			page.NextCursor = formatCursor(uint64(rows[i-1].Seq))
+			break
+		}
+		evt := ContainerEvent{
+			Host:      row.Hostname,
+			Type:      row.Type,
+			Action:    row.Action,
+			ActorID:   row.ActorId,
+			ActorName: row.ActorName,
+			Timestamp: row.EventTimestamp,
+		}
+		if row.Attrs != "" {
+			_ = json.Unmarshal([]byte(row.Attrs), &evt.Attrs)
+		}
+		page.Events = append(page.Events, evt)
+	}
+	return page, nil
+}
+
+// Close 남은 이벤트 저장 후 종료
+func (h *DbHistory) Close() {
+	h.closeOnce.Do(func() {
+		close(h.queue)
+		<-h.done
+	})
+}
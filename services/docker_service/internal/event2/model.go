@@ -9,4 +9,31 @@ type ContainerEvent struct {
 	ActorName string            `json:"actor_name"`
 	Timestamp int64             `json:"timestamp"`
 	Attrs     map[string]string `json:"attrs,omitempty"`
+
+	ID uint64 `json:"-"` // EventManager 가 부여하는 단조 증가 ID (SSE id, Last-Event-ID 재전송)
+}
+
+// agent 자체 이벤트 (docker 이벤트가 아님, stream 외에는 pipeline 으로 전송하지 않음)
+const (
+	TypeUpstream  = "upstream"  // 수집 서버 연결 상태 (Action : up / down)
+	TypeAlert     = "alert"     // 알림 상태 전환 (Action : firing / resolved)
+	TypeHeal      = "heal"      // 자가 복구 동작 (Action : restart / stop / gave_up)
+	TypeLifecycle = "lifecycle" // lifecycle 상관 분석 (Action : crashloop_detected / crashloop_resolved / flapping_detected / flapping_resolved)
+	TypeStream    = "stream"    // docker 이벤트 수신 상태 (Action : stream_gap)
+
+	ActionUp        = "up"
+	ActionDown      = "down"
+	ActionFiring    = "firing"
+	ActionResolved  = "resolved"
+	ActionStreamGap = "stream_gap" // 재연결 시 끊긴 구간 이벤트 재수신 불가 (재동기화 필요)
+)
+
+// IsAgentEvent agent 자체 이벤트 여부
+func (e ContainerEvent) IsAgentEvent() bool {
+	return e.Type == TypeUpstream || e.Type == TypeAlert || e.Type == TypeHeal || e.Type == TypeLifecycle || e.Type == TypeStream
+}
+
+// IsUpstreamEvent 수집 서버로 보내는 이벤트 여부 (docker 이벤트와 stream_gap : 서버도 끊긴 구간을 알아야 한다)
+func (e ContainerEvent) IsUpstreamEvent() bool {
+	return !e.IsAgentEvent() || e.Type == TypeStream
 }
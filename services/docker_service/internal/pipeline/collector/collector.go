@@ -2,6 +2,7 @@ package collector
 
 import (
 	"context"
+	"time"
 
 	"docker_service/internal/pipeline"
 )
@@ -18,23 +19,53 @@ type Collector interface {
 	Stop() error
 }
 
+// Triggerable 이벤트 기반 즉시 수집을 지원하는 수집기
+// 주기 수집은 그대로 유지되며 (reconcile), Trigger 요청은 debounce 후 일괄 처리된다.
+type Triggerable interface {
+	Trigger(containerID string)
+}
+
+// Flushable 즉시 전체 수집을 지원하는 수집기 (원격 명령 COLLECT_NOW)
+type Flushable interface {
+	CollectNow()
+}
+
+// triggerDebounce 이벤트 트리거 수집 지연 (연속 이벤트를 묶어서 처리)
+const triggerDebounce = 500 * time.Millisecond
+
 // Config 수집기 공통 설정
 type Config struct {
 	// Host Docker 호스트명
-	Host string
+	Host string `json:"host"`
+
+	// Enabled 수집기 사용 여부
+	Enabled bool `json:"enabled"`
 
 	// Interval 수집 주기 (List, Stats용)
-	IntervalSec int
+	IntervalSec int `json:"interval_sec"`
 
 	// BufferSize 채널 버퍼 크기
-	BufferSize int
+	BufferSize int `json:"buffer_size"`
+
+	// TimeoutSec 1회 수집 타임아웃 (inspect 는 daemon 호출마다 적용)
+	TimeoutSec int `json:"timeout_sec"`
 }
 
 // DefaultConfig 기본 설정
 func DefaultConfig(host string) Config {
 	return Config{
 		Host:        host,
+		Enabled:     true,
 		IntervalSec: 10,
 		BufferSize:  100,
+		TimeoutSec:  5,
+	}
+}
+
+// Timeout 1회 수집 타임아웃 (미설정 시 수집 주기)
+func (c Config) Timeout() time.Duration {
+	if c.TimeoutSec > 0 {
+		return time.Duration(c.TimeoutSec) * time.Second
 	}
+	return time.Duration(c.IntervalSec) * time.Second
 }
@@ -8,6 +8,9 @@ import (
 	"docker_service/internal/docker"
 	"docker_service/internal/logger"
 	"docker_service/internal/pipeline"
+	"docker_service/internal/redact"
+
+	"github.com/moby/moby/client"
 )
 
 // InspectCollector Container Inspect 수집기
@@ -18,15 +21,22 @@ type InspectCollector struct {
 	stopCh   chan struct{}
 	stopOnce sync.Once
 	wg       sync.WaitGroup // 루틴 종료 대기
+
+	triggerCh chan string   // 이벤트 트리거 (container id)
+	nowCh     chan struct{} // 즉시 전체 수집 요청
+
+	stats runStats // 실행 통계
 }
 
 // NewInspectCollector InspectCollector 생성
 func NewInspectCollector(client *docker.Client, cfg Config) *InspectCollector {
 	return &InspectCollector{
-		client: client,
-		config: cfg,
-		buffer: NewRingBuffer(cfg.BufferSize),
-		stopCh: make(chan struct{}),
+		client:    client,
+		config:    cfg,
+		buffer:    NewRingBuffer(cfg.BufferSize),
+		stopCh:    make(chan struct{}),
+		triggerCh: make(chan string, 64),
+		nowCh:     make(chan struct{}, 1),
 	}
 }
 
@@ -34,6 +44,11 @@ func (c *InspectCollector) Name() string {
 	return "inspect-collector"
 }
 
+// Status 수집기 실행 상태
+func (c *InspectCollector) Status() Status {
+	return c.stats.status(c.Name(), c.buffer)
+}
+
 func (c *InspectCollector) Start(ctx context.Context) (<-chan pipeline.Message, error) {
 	c.wg.Add(1)
 	go c.run(ctx)
@@ -58,10 +73,29 @@ func (c *InspectCollector) run(ctx context.Context) {
 	// 시작 시 즉시 한 번 수집
 	c.collect(ctx)
 
+	// 이벤트 트리거 대기 목록 (debounce)
+	pending := make(map[string]struct{})
+	var debounceC <-chan time.Time
+
 	for {
 		select {
 		case <-ticker.C:
 			c.collect(ctx)
+		case <-c.nowCh:
+			c.collect(ctx)
+		case id := <-c.triggerCh:
+			pending[id] = struct{}{}
+			if debounceC == nil {
+				debounceC = time.After(triggerDebounce)
+			}
+		case <-debounceC:
+			debounceC = nil
+			ids := make([]string, 0, len(pending))
+			for id := range pending {
+				ids = append(ids, id)
+			}
+			pending = make(map[string]struct{})
+			c.collectContainers(ctx, ids)
 		case <-c.stopCh:
 			logger.Log.Print(2, "[InspectCollector] stopped")
 			return
@@ -72,9 +106,18 @@ func (c *InspectCollector) run(ctx context.Context) {
 	}
 }
 
+// collect 전체 컨테이너 inspect 수집
+// timeout 은 수집 전체가 아니라 daemon 호출(목록 조회, 컨테이너별 inspect)마다 적용한다. (컨테이너가 많아도 뒤쪽이 잘리지 않음)
 func (c *InspectCollector) collect(ctx context.Context) {
+	start := time.Now()
+	items := 0
+	var err error
+	defer func() { c.stats.record(start, items, err) }()
+
 	// 먼저 컨테이너 목록 조회
-	containers, err := c.client.ListContainers(ctx)
+	lctx, cancel := context.WithTimeout(ctx, c.config.Timeout())
+	containers, err := c.client.ListTargetContainers(lctx)
+	cancel()
 	if err != nil {
 		logger.Log.Error("[InspectCollector] failed to list containers: %v", err)
 		return
@@ -84,14 +127,19 @@ func (c *InspectCollector) collect(ctx context.Context) {
 
 	// 각 컨테이너의 inspect 수집  (추후 동시 작업을 위한 worker 고려.)
 	for _, ct := range containers {
-		inspectResult, err := c.client.InspectContainer(ctx, ct.ID)
+		if ctx.Err() != nil {
+			logger.Log.Print(2, "[InspectCollector] context cancelled, %d/%d inspected", len(inspects), len(containers))
+			return
+		}
+		inspectResult, err := c.inspect(ctx, ct.ID)
 		if err != nil {
 			logger.Log.Error("[InspectCollector] failed to inspect %s: %v", ct.ID, err)
 			continue
 		}
 
 		// SDK 결과 -> docker.ContainerInspect -> pipeline.ContainerInspectInfo 변환
-		dockerInspect := docker.ConvertInspectResult(inspectResult)
+		// 민감정보(Env, Cmd/Entrypoint, Label)는 agent 밖으로 나가기 전에 마스킹
+		dockerInspect := redact.Default().Inspect(docker.ConvertInspectResult(inspectResult))
 		info := convertInspectResult(dockerInspect, ct.ID)
 		inspects = append(inspects, info)
 	}
@@ -106,9 +154,66 @@ func (c *InspectCollector) collect(ctx context.Context) {
 	}
 
 	c.buffer.Send(msg)
+	items = len(inspects)
 	logger.Log.Print(2, "[InspectCollector] collected %d inspects from %s", len(inspects), c.config.Host)
 }
 
+// Trigger 컨테이너 재수집 요청 (대기열이 가득 차면 무시, 주기 수집에서 보정됨)
+func (c *InspectCollector) Trigger(containerID string) {
+	select {
+	case c.triggerCh <- containerID:
+	default:
+		logger.Log.Warn("[InspectCollector] trigger queue full, skip %s", containerID)
+	}
+}
+
+// CollectNow 즉시 전체 수집 요청 (이미 대기 중이면 합쳐짐)
+func (c *InspectCollector) CollectNow() {
+	select {
+	case c.nowCh <- struct{}{}:
+	default:
+	}
+}
+
+// collectContainers 지정한 컨테이너만 inspect 수집 (컨테이너별 메시지 전송)
+func (c *InspectCollector) collectContainers(ctx context.Context, ids []string) {
+	for _, id := range ids {
+		if ctx.Err() != nil {
+			return
+		}
+		inspectResult, err := c.inspect(ctx, id)
+		if err != nil {
+			logger.Log.Warn("[InspectCollector] triggered inspect %s fail: %v", id, err)
+			continue
+		}
+
+		shortID := id
+		if len(shortID) > 12 {
+			shortID = shortID[:12]
+		}
+
+		dockerInspect := redact.Default().Inspect(docker.ConvertInspectResult(inspectResult))
+		msg := pipeline.Message{
+			Type:      pipeline.DataTypeInspect,
+			Host:      c.config.Host,
+			Scope:     shortID,
+			Timestamp: time.Now(),
+			Data: pipeline.ContainerInspectData{
+				Inspects: []pipeline.ContainerInspectInfo{convertInspectResult(dockerInspect, shortID)},
+			},
+		}
+		c.buffer.Send(msg)
+	}
+	logger.Log.Print(2, "[InspectCollector] triggered inspect %d containers from %s", len(ids), c.config.Host)
+}
+
+// inspect 컨테이너 1건 inspect (호출마다 timeout 적용)
+func (c *InspectCollector) inspect(ctx context.Context, id string) (client.ContainerInspectResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.config.Timeout())
+	defer cancel()
+	return c.client.InspectContainer(ctx, id)
+}
+
 // convertInspectResult docker API 결과를 pipeline 타입으로 변환
 func convertInspectResult(result docker.ContainerInspect, containerid string) pipeline.ContainerInspectInfo {
 	info := pipeline.ContainerInspectInfo{
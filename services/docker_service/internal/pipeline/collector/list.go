@@ -18,15 +18,22 @@ type ListCollector struct {
 	stopCh   chan struct{}
 	stopOnce sync.Once
 	wg       sync.WaitGroup // 루틴 종료 대기
+
+	refreshCh chan struct{} // 이벤트 트리거 (목록 갱신 요청)
+	nowCh     chan struct{} // 즉시 전체 수집 요청
+
+	stats runStats // 실행 통계
 }
 
 // NewListCollector ListCollector 생성
 func NewListCollector(client *docker.Client, cfg Config) *ListCollector {
 	return &ListCollector{
-		client: client,
-		config: cfg,
-		buffer: NewRingBuffer(cfg.BufferSize),
-		stopCh: make(chan struct{}),
+		client:    client,
+		config:    cfg,
+		buffer:    NewRingBuffer(cfg.BufferSize),
+		stopCh:    make(chan struct{}),
+		refreshCh: make(chan struct{}, 1),
+		nowCh:     make(chan struct{}, 1),
 	}
 }
 
@@ -34,6 +41,11 @@ func (c *ListCollector) Name() string {
 	return "list-collector"
 }
 
+// Status 수집기 실행 상태
+func (c *ListCollector) Status() Status {
+	return c.stats.status(c.Name(), c.buffer)
+}
+
 func (c *ListCollector) Start(ctx context.Context) (<-chan pipeline.Message, error) {
 	c.wg.Add(1)
 	go c.run(ctx)
@@ -58,10 +70,21 @@ func (c *ListCollector) run(ctx context.Context) {
 	// 시작 시 즉시 한 번 수집
 	c.collect(ctx)
 
+	var debounceC <-chan time.Time
+
 	for {
 		select {
 		case <-ticker.C:
 			c.collect(ctx)
+		case <-c.nowCh:
+			c.collect(ctx)
+		case <-c.refreshCh:
+			if debounceC == nil {
+				debounceC = time.After(triggerDebounce)
+			}
+		case <-debounceC:
+			debounceC = nil
+			c.collect(ctx)
 		case <-c.stopCh:
 			logger.Log.Print(2, "[ListCollector] stopped")
 			return
@@ -73,7 +96,15 @@ func (c *ListCollector) run(ctx context.Context) {
 }
 
 func (c *ListCollector) collect(ctx context.Context) {
-	containers, err := c.client.ListContainers(ctx)
+	start := time.Now()
+	items := 0
+	var err error
+	defer func() { c.stats.record(start, items, err) }()
+
+	ctx, cancel := context.WithTimeout(ctx, c.config.Timeout())
+	defer cancel()
+
+	containers, err := c.client.ListTargetContainers(ctx)
 	if err != nil {
 		logger.Log.Error("[ListCollector] failed to list containers: %v", err)
 		return
@@ -101,12 +132,29 @@ func (c *ListCollector) collect(ctx context.Context) {
 	}
 
 	c.buffer.Send(msg)
+	items = len(infos)
 	logger.Log.Print(2, "[ListCollector] collected %d containers from %s", len(containers), c.config.Host)
 }
 
+// Trigger 호스트 목록 갱신 요청 (이미 대기 중이면 합쳐짐)
+func (c *ListCollector) Trigger(containerID string) {
+	select {
+	case c.refreshCh <- struct{}{}:
+	default:
+	}
+}
+
+// CollectNow 즉시 전체 수집 요청 (이미 대기 중이면 합쳐짐)
+func (c *ListCollector) CollectNow() {
+	select {
+	case c.nowCh <- struct{}{}:
+	default:
+	}
+}
+
 // CollectOnce 단발성 수집 (즉시 수집이 필요할 때)
 func (c *ListCollector) CollectOnce(ctx context.Context) (*pipeline.Message, error) {
-	containers, err := c.client.ListContainers(ctx)
+	containers, err := c.client.ListTargetContainers(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -5,6 +5,8 @@ import (
 	"docker_service/internal/docker"
 	"docker_service/internal/logger"
 	"docker_service/internal/pipeline"
+	"fmt"
+	"sort"
 	"sync"
 )
 
@@ -17,11 +19,20 @@ const (
 	TypeStats   CollectorType = "stat"
 )
 
+// managedCollector 실행 중인 수집기와 적용된 설정
+type managedCollector struct {
+	collector Collector
+	config    Config
+	running   bool
+}
+
 // Manager 멀티 호스트 Collector 관리자
 type Manager struct {
 	dockerMng  *docker.DockerClientManager
-	collectors map[string][]Collector // key: host name
+	collectors map[string]map[CollectorType]*managedCollector // key: host name, collector type
+	settings   PipelineConfig
 	outCh      chan pipeline.Message
+	ctx        context.Context // Start 시점의 context (재시작된 수집기에 사용)
 	mu         sync.RWMutex
 	wg         sync.WaitGroup
 }
@@ -30,11 +41,25 @@ type Manager struct {
 func NewManager(dockerMng *docker.DockerClientManager, bufferSize int) *Manager {
 	return &Manager{
 		dockerMng:  dockerMng,
-		collectors: make(map[string][]Collector),
+		collectors: make(map[string]map[CollectorType]*managedCollector),
+		settings:   DefaultPipelineConfig(),
 		outCh:      make(chan pipeline.Message, bufferSize),
 	}
 }
 
+// newCollector 타입에 맞는 수집기 생성
+func newCollector(client *docker.Client, t CollectorType, cfg Config) Collector {
+	switch t {
+	case TypeList:
+		return NewListCollector(client, cfg)
+	case TypeInspect:
+		return NewInspectCollector(client, cfg)
+	case TypeStats:
+		return NewStatsCollector(client, cfg)
+	}
+	return nil
+}
+
 // RegisterCollectors 특정 호스트에 대한 수집기 등록
 func (m *Manager) RegisterCollectors(hostName string, types []CollectorType, cfg Config) error {
 	m.mu.Lock()
@@ -46,20 +71,12 @@ func (m *Manager) RegisterCollectors(hostName string, types []CollectorType, cfg
 	}
 
 	cfg.Host = hostName
-	var collectors []Collector
+	collectors := make(map[CollectorType]*managedCollector)
 
 	for _, t := range types {
-		var c Collector
-		switch t {
-		case TypeList:
-			c = NewListCollector(client, cfg)
-		case TypeInspect:
-			c = NewInspectCollector(client, cfg)
-		case TypeStats:
-			c = NewStatsCollector(client, cfg)
-		}
+		c := newCollector(client, t, cfg)
 		if c != nil {
-			collectors = append(collectors, c)
+			collectors[t] = &managedCollector{collector: c, config: cfg}
 		}
 	}
 
@@ -82,53 +99,277 @@ func (m *Manager) RegisterAllHosts(types []CollectorType, cfg Config) error {
 	return nil
 }
 
+// RegisterWithSettings 모든 호스트에 설정(PipelineConfig)에 따라 수집기 등록
+// enabled=false인 타입은 등록하지 않는다.
+func (m *Manager) RegisterWithSettings(settings PipelineConfig) error {
+	if err := settings.Validate(); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.settings = settings
+	for _, hostName := range m.dockerMng.GetHostNames() {
+		client, err := m.dockerMng.Get(hostName)
+		if err != nil {
+			logger.Log.Error("[CollectorManager] failed to register collectors for %s: %v", hostName, err)
+			continue
+		}
+
+		collectors := make(map[CollectorType]*managedCollector)
+		for _, t := range AllTypes {
+			cfg := settings.Resolve(hostName, t)
+			if !cfg.Enabled {
+				continue
+			}
+			collectors[t] = &managedCollector{collector: newCollector(client, t, cfg), config: cfg}
+		}
+		m.collectors[hostName] = collectors
+		logger.Log.Print(2, "[CollectorManager] registered %d collectors for host: %s", len(collectors), hostName)
+	}
+	return nil
+}
+
 // Start 모든 수집기 시작
 func (m *Manager) Start(ctx context.Context) (<-chan pipeline.Message, error) {
-	m.mu.RLock()
-	defer m.mu.RUnlock()
+	m.mu.Lock()
+	defer m.mu.Unlock()
 
+	m.ctx = ctx
 	for hostName, collectors := range m.collectors {
-		for _, c := range collectors {
-			ch, err := c.Start(ctx)
-			if err != nil {
+		for _, mc := range collectors {
+			if err := m.startCollector(mc); err != nil {
 				logger.Log.Error("[CollectorManager] failed to start %s for %s: %v",
-					c.Name(), hostName, err)
+					mc.collector.Name(), hostName, err)
 				continue
 			}
+			logger.Log.Print(2, "[CollectorManager] started %s for host: %s", mc.collector.Name(), hostName)
+		}
+	}
+
+	return m.outCh, nil
+}
 
-			// 각 수집기의 출력을 통합 채널로 전달
-			m.wg.Add(1)
-			go func(ch <-chan pipeline.Message, name string) {
-				defer m.wg.Done()
-				for msg := range ch {
-					select {
-					case m.outCh <- msg:
-					case <-ctx.Done():
-						return
-					}
-				}
-			}(ch, c.Name())
+// startCollector 수집기 시작 및 출력 채널 연결 (m.mu 잠금 상태에서 호출)
+func (m *Manager) startCollector(mc *managedCollector) error {
+	ch, err := mc.collector.Start(m.ctx)
+	if err != nil {
+		return err
+	}
+	mc.running = true
 
-			logger.Log.Print(2, "[CollectorManager] started %s for host: %s", c.Name(), hostName)
+	// 각 수집기의 출력을 통합 채널로 전달
+	ctx := m.ctx
+	m.wg.Add(1)
+	go func(ch <-chan pipeline.Message) {
+		defer m.wg.Done()
+		for msg := range ch {
+			select {
+			case m.outCh <- msg:
+			case <-ctx.Done():
+				return
+			}
 		}
+	}(ch)
+	return nil
+}
+
+// stopCollector 수집기 중지 (m.mu 잠금 상태에서 호출)
+func (m *Manager) stopCollector(hostName string, mc *managedCollector) {
+	if !mc.running {
+		return
+	}
+	if err := mc.collector.Stop(); err != nil {
+		logger.Log.Error("[CollectorManager] failed to stop %s for %s: %v",
+			mc.collector.Name(), hostName, err)
 	}
+	mc.running = false
+}
 
-	return m.outCh, nil
+// Reconfigure 설정 변경 적용
+// 적용 설정이 바뀐 (호스트, 타입) 수집기만 중지 후 새 설정으로 재시작한다.
+func (m *Manager) Reconfigure(settings PipelineConfig) error {
+	if err := settings.Validate(); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.ctx == nil {
+		return fmt.Errorf("collector manager not started")
+	}
+
+	m.settings = settings
+	for _, hostName := range m.dockerMng.GetHostNames() {
+		client, err := m.dockerMng.Get(hostName)
+		if err != nil {
+			logger.Log.Error("[CollectorManager] reconfigure: %s: %v", hostName, err)
+			continue
+		}
+
+		collectors, ok := m.collectors[hostName]
+		if !ok {
+			collectors = make(map[CollectorType]*managedCollector)
+			m.collectors[hostName] = collectors
+		}
+
+		for _, t := range AllTypes {
+			cfg := settings.Resolve(hostName, t)
+			current, exists := collectors[t]
+
+			if exists && current.config == cfg {
+				continue // 변경 없음
+			}
+
+			if exists {
+				m.stopCollector(hostName, current)
+				delete(collectors, t)
+				logger.Log.Print(2, "[CollectorManager] stopped %s for host: %s (reconfigure)", current.collector.Name(), hostName)
+			}
+
+			if !cfg.Enabled {
+				continue
+			}
+
+			mc := &managedCollector{collector: newCollector(client, t, cfg), config: cfg}
+			if err := m.startCollector(mc); err != nil {
+				logger.Log.Error("[CollectorManager] failed to restart %s for %s: %v", mc.collector.Name(), hostName, err)
+				continue
+			}
+			collectors[t] = mc
+			logger.Log.Print(2, "[CollectorManager] restarted %s for host: %s interval=%ds buffer=%d timeout=%ds",
+				mc.collector.Name(), hostName, cfg.IntervalSec, cfg.BufferSize, cfg.TimeoutSec)
+		}
+	}
+
+	return nil
 }
 
-// Stop 모든 수집기 중지
-func (m *Manager) Stop() error {
+// 이벤트 트리거 대상 action
+var (
+	inspectTriggerActions = []string{"create", "start", "die", "stop", "kill", "restart", "rename", "update", "pause", "unpause", "oom"}
+	listTriggerActions    = []string{"create", "start", "die", "stop", "restart", "rename", "pause", "unpause", "destroy"}
+)
+
+// HandleEvent 컨테이너 이벤트로 해당 호스트의 list 갱신 / 컨테이너 재inspect 요청
+func (m *Manager) HandleEvent(hostName, evtType, action, containerID string) {
+	if evtType != "container" {
+		return
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	collectors, ok := m.collectors[hostName]
+	if !ok {
+		return
+	}
+
+	if mc, ok := collectors[TypeInspect]; ok && mc.running && docker.Contains(inspectTriggerActions, action) {
+		if t, ok := mc.collector.(Triggerable); ok {
+			t.Trigger(containerID)
+		}
+	}
+	if mc, ok := collectors[TypeList]; ok && mc.running && docker.Contains(listTriggerActions, action) {
+		if t, ok := mc.collector.(Triggerable); ok {
+			t.Trigger(containerID)
+		}
+	}
+}
+
+// CollectNow 호스트의 수집기에 즉시 수집 요청 (types 가 비어 있으면 전체 타입)
+// 요청이 전달된 수집기 수를 반환한다.
+func (m *Manager) CollectNow(hostName string, types []CollectorType) (int, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	collectors, ok := m.collectors[hostName]
+	if !ok {
+		return 0, fmt.Errorf("no collectors for host: %s", hostName)
+	}
+	if len(types) == 0 {
+		types = []CollectorType{TypeList, TypeInspect, TypeStats}
+	}
+
+	n := 0
+	for _, t := range types {
+		mc, ok := collectors[t]
+		if !ok || !mc.running {
+			continue
+		}
+		if f, ok := mc.collector.(Flushable); ok {
+			f.CollectNow()
+			n++
+		}
+	}
+	return n, nil
+}
+
+// Status 호스트/타입별 수집기 실행 상태
+func (m *Manager) Status() []Status {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
+	result := make([]Status, 0)
 	for hostName, collectors := range m.collectors {
-		for _, c := range collectors {
-			if err := c.Stop(); err != nil {
-				logger.Log.Error("[CollectorManager] failed to stop %s for %s: %v",
-					c.Name(), hostName, err)
+		for _, t := range AllTypes {
+			mc, ok := collectors[t]
+			if !ok {
+				continue
 			}
+			st := Status{Name: mc.collector.Name()}
+			if r, ok := mc.collector.(StatusReporter); ok {
+				st = r.Status()
+			}
+			st.Host = hostName
+			st.Type = t
+			st.Running = mc.running
+			result = append(result, st)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool { return result[i].Host < result[j].Host })
+	return result
+}
+
+// Settings 현재 수집기 설정 반환
+func (m *Manager) Settings() PipelineConfig {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.settings
+}
+
+// EffectiveConfig 호스트/타입별 실제 적용 중인 설정 반환 (중지된 수집기는 enabled=false)
+func (m *Manager) EffectiveConfig() map[string]map[CollectorType]Config {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	result := make(map[string]map[CollectorType]Config)
+	for hostName, collectors := range m.collectors {
+		result[hostName] = make(map[CollectorType]Config)
+		for _, t := range AllTypes {
+			if mc, ok := collectors[t]; ok && mc.running {
+				result[hostName][t] = mc.config
+				continue
+			}
+			cfg := m.settings.Resolve(hostName, t)
+			cfg.Enabled = false
+			result[hostName][t] = cfg
+		}
+	}
+	return result
+}
+
+// Stop 모든 수집기 중지
+func (m *Manager) Stop() error {
+	m.mu.Lock()
+	for hostName, collectors := range m.collectors {
+		for _, mc := range collectors {
+			m.stopCollector(hostName, mc)
 		}
 	}
+	m.mu.Unlock()
 
 	m.wg.Wait()
 	close(m.outCh)
@@ -4,13 +4,15 @@ import (
 	"docker_service/internal/logger"
 	"docker_service/internal/pipeline"
 	"sync"
+	"sync/atomic"
 )
 
 // RingBuffer 버퍼가 가득 차면 오래된 데이터를 제거하는 채널 래퍼
 type RingBuffer struct {
-	ch   chan pipeline.Message
-	size int
-	mu   sync.Mutex
+	ch      chan pipeline.Message
+	size    int
+	mu      sync.Mutex
+	dropped atomic.Uint64 // 버퍼 full로 제거된 메시지 수
 }
 
 // NewRingBuffer RingBuffer 생성
@@ -33,6 +35,7 @@ func (rb *RingBuffer) Send(msg pipeline.Message) {
 		// 버퍼 full - 오래된 데이터 제거 후 추가
 		select {
 		case dropped := <-rb.ch:
+			rb.dropped.Add(1)
 			logger.Log.Print(1, "[RingBuffer] buffer full, dropped old message: type=%s host=%s",
 				dropped.Type, dropped.Host)
 		default:
@@ -62,3 +65,13 @@ func (rb *RingBuffer) Close() {
 func (rb *RingBuffer) Len() int {
 	return len(rb.ch)
 }
+
+// Cap 버퍼 크기
+func (rb *RingBuffer) Cap() int {
+	return rb.size
+}
+
+// Dropped 버퍼 full로 제거된 메시지 수
+func (rb *RingBuffer) Dropped() uint64 {
+	return rb.dropped.Load()
+}
@@ -0,0 +1,132 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// AllTypes 지원하는 수집기 타입 목록
+var AllTypes = []CollectorType{TypeList, TypeInspect, TypeStats}
+
+// Setting 수집기 타입별 설정
+// 0 / nil 필드는 상위 설정(defaults → 기본값)을 상속한다.
+type Setting struct {
+	Enabled     *bool `json:"enabled,omitempty"`
+	IntervalSec int   `json:"interval_sec,omitempty"`
+	BufferSize  int   `json:"buffer_size,omitempty"`
+	TimeoutSec  int   `json:"timeout_sec,omitempty"`
+}
+
+// PipelineConfig 수집기 전체 설정 (타입별 기본값 + 호스트별 override)
+/*
+	{
+	  "defaults": {
+	    "list":    {"interval_sec": 30, "buffer_size": 50},
+	    "inspect": {"interval_sec": 60, "timeout_sec": 10},
+	    "stat":    {"interval_sec": 10, "timeout_sec": 3}
+	  },
+	  "hosts": {
+	    "119server": {"stat": {"enabled": false}}
+	  }
+	}
+*/
+type PipelineConfig struct {
+	Defaults map[CollectorType]Setting            `json:"defaults"`
+	Hosts    map[string]map[CollectorType]Setting `json:"hosts,omitempty"`
+}
+
+// builtinSettings 설정이 없을 때 사용하는 타입별 기본값
+var builtinSettings = map[CollectorType]Config{
+	TypeList:    {Enabled: true, IntervalSec: 30, BufferSize: 50, TimeoutSec: 5},
+	TypeInspect: {Enabled: true, IntervalSec: 30, BufferSize: 50, TimeoutSec: 10},
+	TypeStats:   {Enabled: true, IntervalSec: 30, BufferSize: 50, TimeoutSec: 3},
+}
+
+// DefaultPipelineConfig 기본 수집기 설정
+func DefaultPipelineConfig() PipelineConfig {
+	return PipelineConfig{
+		Defaults: map[CollectorType]Setting{},
+		Hosts:    map[string]map[CollectorType]Setting{},
+	}
+}
+
+// ParsePipelineConfig JSON 문자열을 파싱 (빈 문자열이면 기본 설정)
+func ParsePipelineConfig(raw string) (PipelineConfig, error) {
+	cfg := DefaultPipelineConfig()
+	if raw == "" {
+		return cfg, nil
+	}
+
+	if err := json.Unmarshal([]byte(raw), &cfg); err != nil {
+		return DefaultPipelineConfig(), fmt.Errorf("parse pipeline config: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return DefaultPipelineConfig(), err
+	}
+	return cfg, nil
+}
+
+// Validate 알 수 없는 타입, 음수 값 검사
+func (p PipelineConfig) Validate() error {
+	check := func(scope string, settings map[CollectorType]Setting) error {
+		for t, s := range settings {
+			if _, ok := builtinSettings[t]; !ok {
+				return fmt.Errorf("%s: unknown collector type %q", scope, t)
+			}
+			if s.IntervalSec < 0 || s.BufferSize < 0 || s.TimeoutSec < 0 {
+				return fmt.Errorf("%s/%s: negative value not allowed", scope, t)
+			}
+		}
+		return nil
+	}
+
+	if err := check("defaults", p.Defaults); err != nil {
+		return err
+	}
+	for host, settings := range p.Hosts {
+		if err := check("hosts."+host, settings); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Resolve 호스트/타입의 실제 적용 설정 계산 (기본값 → defaults → hosts 순으로 덮어씀)
+func (p PipelineConfig) Resolve(host string, t CollectorType) Config {
+	cfg := builtinSettings[t]
+	cfg.Host = host
+
+	cfg = cfg.apply(p.Defaults[t])
+	if hostSettings, ok := p.Hosts[host]; ok {
+		cfg = cfg.apply(hostSettings[t])
+	}
+	return cfg
+}
+
+// Effective 호스트 목록에 대한 전체 적용 설정
+func (p PipelineConfig) Effective(hosts []string) map[string]map[CollectorType]Config {
+	result := make(map[string]map[CollectorType]Config, len(hosts))
+	for _, host := range hosts {
+		result[host] = make(map[CollectorType]Config, len(AllTypes))
+		for _, t := range AllTypes {
+			result[host][t] = p.Resolve(host, t)
+		}
+	}
+	return result
+}
+
+func (c Config) apply(s Setting) Config {
+	if s.Enabled != nil {
+		c.Enabled = *s.Enabled
+	}
+	if s.IntervalSec > 0 {
+		c.IntervalSec = s.IntervalSec
+	}
+	if s.BufferSize > 0 {
+		c.BufferSize = s.BufferSize
+	}
+	if s.TimeoutSec > 0 {
+		c.TimeoutSec = s.TimeoutSec
+	}
+	return c
+}
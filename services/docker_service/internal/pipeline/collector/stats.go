@@ -19,6 +19,10 @@ type StatsCollector struct {
 	stopCh   chan struct{}
 	stopOnce sync.Once
 	wg       sync.WaitGroup
+
+	nowCh chan struct{} // 즉시 수집 요청
+
+	stats runStats // 실행 통계
 }
 
 // NewStatsCollector StatsCollector 생성
@@ -28,6 +32,7 @@ func NewStatsCollector(client *docker.Client, cfg Config) *StatsCollector {
 		config: cfg,
 		buffer: NewRingBuffer(cfg.BufferSize),
 		stopCh: make(chan struct{}),
+		nowCh:  make(chan struct{}, 1),
 	}
 }
 
@@ -35,6 +40,11 @@ func (c *StatsCollector) Name() string {
 	return "stats-collector"
 }
 
+// Status 수집기 실행 상태
+func (c *StatsCollector) Status() Status {
+	return c.stats.status(c.Name(), c.buffer)
+}
+
 func (c *StatsCollector) Start(ctx context.Context) (<-chan pipeline.Message, error) {
 	c.wg.Add(1)
 	go c.run(ctx)
@@ -50,6 +60,14 @@ func (c *StatsCollector) Stop() error {
 	return nil
 }
 
+// CollectNow 즉시 수집 요청 (이미 대기 중이면 합쳐짐)
+func (c *StatsCollector) CollectNow() {
+	select {
+	case c.nowCh <- struct{}{}:
+	default:
+	}
+}
+
 func (c *StatsCollector) run(ctx context.Context) {
 	defer c.wg.Done()
 
@@ -63,6 +81,8 @@ func (c *StatsCollector) run(ctx context.Context) {
 		select {
 		case <-ticker.C:
 			c.collect(ctx)
+		case <-c.nowCh:
+			c.collect(ctx)
 		case <-c.stopCh:
 			logger.Log.Print(2, "[StatsCollector] stopped")
 			return
@@ -74,8 +94,13 @@ func (c *StatsCollector) run(ctx context.Context) {
 }
 
 func (c *StatsCollector) collect(ctx context.Context) {
+	start := time.Now()
+	items := 0
+	var err error
+	defer func() { c.stats.record(start, items, err) }()
+
 	// 1. 컨테이너 목록 조회
-	containers, err := c.client.ListContainers(ctx)
+	containers, err := c.client.ListTargetContainers(ctx)
 	if err != nil {
 		logger.Log.Error("[StatsCollector] failed to list containers: %v", err)
 		return
@@ -90,8 +115,8 @@ func (c *StatsCollector) collect(ctx context.Context) {
 	var wg sync.WaitGroup
 	resultCh := make(chan pipeline.ContainerStatsInfo, len(containers))
 
-	// 3. 타임아웃 컨텍스트 (3초)
-	childCtx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	// 3. 타임아웃 컨텍스트 (timeout_sec)
+	childCtx, cancel := context.WithTimeout(ctx, c.config.Timeout())
 	defer cancel()
 
 	// 4. 각 컨테이너에 대해 goroutine으로 stats 수집
@@ -135,7 +160,7 @@ func (c *StatsCollector) collect(ctx context.Context) {
 	case <-done:
 		logger.Log.Print(2, "[StatsCollector] all goroutines completed")
 	case <-childCtx.Done():
-		logger.Log.Print(2, "[StatsCollector] timeout(3s)")
+		logger.Log.Print(2, "[StatsCollector] timeout(%v)", c.config.Timeout())
 	}
 
 	// 7. 결과 수집
@@ -159,6 +184,7 @@ func (c *StatsCollector) collect(ctx context.Context) {
 	}
 
 	c.buffer.Send(msg)
+	items = len(statsInfos)
 	logger.Log.Print(2, "[StatsCollector] collected %d stats from %s", len(statsInfos), c.config.Host)
 }
 
@@ -0,0 +1,75 @@
+package collector
+
+import (
+	"sync"
+	"time"
+)
+
+// Status 수집기 실행 상태 (/pipeline/status)
+type Status struct {
+	Host           string        `json:"host"`
+	Type           CollectorType `json:"type"`
+	Name           string        `json:"name"`
+	Running        bool          `json:"running"`
+	LastRun        time.Time     `json:"last_run"`
+	LastDurationMs int64         `json:"last_duration_ms"`
+	LastError      string        `json:"last_error,omitempty"`
+	Items          int           `json:"items"` // 마지막 수집 항목 수
+	Runs           uint64        `json:"runs"`
+	Errors         uint64        `json:"errors"`
+	BufferLen      int           `json:"buffer_len"`
+	BufferCap      int           `json:"buffer_cap"`
+	BufferDropped  uint64        `json:"buffer_dropped"`
+}
+
+// StatusReporter 실행 상태를 제공하는 수집기
+type StatusReporter interface {
+	Status() Status
+}
+
+// runStats 수집 실행 통계
+type runStats struct {
+	mu       sync.Mutex
+	lastRun  time.Time
+	duration time.Duration
+	lastErr  string
+	items    int
+	runs     uint64
+	errors   uint64
+}
+
+// record 1회 수집 결과 기록
+func (s *runStats) record(start time.Time, items int, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.lastRun = start
+	s.duration = time.Since(start)
+	s.items = items
+	s.runs++
+	if err != nil {
+		s.errors++
+		s.lastErr = err.Error()
+	} else {
+		s.lastErr = ""
+	}
+}
+
+// status 실행 통계 + 버퍼 상태
+func (s *runStats) status(name string, buffer *RingBuffer) Status {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return Status{
+		Name:           name,
+		LastRun:        s.lastRun,
+		LastDurationMs: s.duration.Milliseconds(),
+		LastError:      s.lastErr,
+		Items:          s.items,
+		Runs:           s.runs,
+		Errors:         s.errors,
+		BufferLen:      buffer.Len(),
+		BufferCap:      buffer.Cap(),
+		BufferDropped:  buffer.Dropped(),
+	}
+}
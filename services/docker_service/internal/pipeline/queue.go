@@ -0,0 +1,329 @@
+package pipeline
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"docker_service/internal/logger"
+)
+
+// 타입별 큐 정책
+// - event   : FIFO, EventLimit 까지 무손실 (초과 시 가장 오래된 이벤트 제거)
+// - list    : 호스트(+scope)별 최신 1건만 유지 (coalescing)
+// - inspect : 호스트(+scope)별 최신 1건만 유지 (coalescing), 전체 수집이 들어오면 대기 중인 개별 수집은 대체됨
+// - stats   : 호스트별 StatsSampleInterval 간격으로 샘플링, StatsLimit 초과 시 가장 오래된 것 제거
+type queuePolicy int
+
+const (
+	policyFIFO queuePolicy = iota
+	policyLatest
+	policySample
+)
+
+// DefaultStatsSampleInterval 호스트별 stats 전송 최소 간격
+// 수집 주기(기본 30s)보다 짧게 두어 평소에는 모두 전송하고, 즉시 수집/짧은 주기 설정으로 몰리는 메시지만 줄인다.
+// stats 메시지는 호스트 단위(해당 호스트 전체 컨테이너)이므로 호스트 기준으로 샘플링해도 컨테이너가 누락되지 않는다.
+const DefaultStatsSampleInterval = 5 * time.Second
+
+// QueueConfig 우선순위 큐 설정
+type QueueConfig struct {
+	EventLimit          int              `json:"event_limit"`
+	StatsLimit          int              `json:"stats_limit"`
+	StatsSampleInterval time.Duration    `json:"stats_sample_interval"` // 0 : 샘플링 안함
+	Weights             map[DataType]int `json:"weights"`               // 스케줄러 가중치 (클수록 먼저, 자주 전송)
+}
+
+// DefaultQueueConfig 기본 큐 설정
+func DefaultQueueConfig() QueueConfig {
+	return QueueConfig{
+		EventLimit:          10000,
+		StatsLimit:          200,
+		StatsSampleInterval: DefaultStatsSampleInterval,
+		Weights: map[DataType]int{
+			DataTypeEvent:   8,
+			DataTypeInspect: 2,
+			DataTypeList:    2,
+			DataTypeStats:   1,
+		},
+	}
+}
+
+// QueueStats 타입별 큐 통계
+type QueueStats struct {
+	Depth     int    `json:"depth"`     // 현재 대기 중인 메시지 수
+	Enqueued  uint64 `json:"enqueued"`  // 수신한 메시지 수
+	Sent      uint64 `json:"sent"`      // 스케줄러가 내보낸 메시지 수
+	Dropped   uint64 `json:"dropped"`   // 한도 초과로 제거된 메시지 수
+	Coalesced uint64 `json:"coalesced"` // 최신 메시지로 대체된 수
+	Sampled   uint64 `json:"sampled"`   // 샘플링으로 제외된 수
+	Requeued  uint64 `json:"requeued"`  // 전송 실패로 다시 적재된 수
+}
+
+// typedQueue 단일 DataType 큐
+type typedQueue struct {
+	policy queuePolicy
+	limit  int
+	weight int
+	cur    int // smooth weighted round-robin 현재값
+
+	items []Message // FIFO, Sample
+
+	keys   []string           // Latest : 입력 순서 (host + scope)
+	latest map[string]Message // Latest : key별 최신 메시지
+
+	lastAccepted map[string]time.Time // Sample : 호스트별 마지막 수신 시각
+	interval     time.Duration
+
+	stats QueueStats
+}
+
+func (q *typedQueue) len() int {
+	if q.policy == policyLatest {
+		return len(q.keys)
+	}
+	return len(q.items)
+}
+
+func (q *typedQueue) push(msg Message) {
+	q.stats.Enqueued++
+
+	switch q.policy {
+	case policyLatest:
+		key := coalesceKey(msg)
+		if msg.Scope == "" {
+			q.removeScoped(msg.Host)
+		}
+		if _, ok := q.latest[key]; ok {
+			q.stats.Coalesced++
+		} else {
+			q.keys = append(q.keys, key)
+		}
+		q.latest[key] = msg
+		return
+
+	case policySample:
+		if q.interval > 0 {
+			if last, ok := q.lastAccepted[msg.Host]; ok && msg.Timestamp.Sub(last) < q.interval {
+				q.stats.Sampled++
+				return
+			}
+			q.lastAccepted[msg.Host] = msg.Timestamp
+		}
+	}
+
+	if q.limit > 0 && len(q.items) >= q.limit {
+		dropped := q.items[0]
+		q.items = q.items[1:]
+		q.stats.Dropped++
+		logger.Log.Warn("[MultiQueue] queue full, dropped oldest: type=%s host=%s", dropped.Type, dropped.Host)
+	}
+	q.items = append(q.items, msg)
+}
+
+func (q *typedQueue) pop() Message {
+	q.stats.Sent++
+
+	if q.policy == policyLatest {
+		key := q.keys[0]
+		q.keys = q.keys[1:]
+		msg := q.latest[key]
+		delete(q.latest, key)
+		return msg
+	}
+
+	msg := q.items[0]
+	q.items[0] = Message{}
+	q.items = q.items[1:]
+	return msg
+}
+
+// requeue 전송 실패 메시지를 큐 앞쪽에 다시 추가 (sample 미적용)
+// latest 는 같은 key 의 새 메시지가 이미 있으면 버리고, 한도가 찬 경우 다시 적재하지 않는다.
+func (q *typedQueue) requeue(msg Message) {
+	if q.policy == policyLatest {
+		key := coalesceKey(msg)
+		if _, ok := q.latest[key]; ok {
+			q.stats.Coalesced++
+			return
+		}
+		q.stats.Requeued++
+		q.keys = append([]string{key}, q.keys...)
+		q.latest[key] = msg
+		return
+	}
+
+	if q.limit > 0 && len(q.items) >= q.limit {
+		q.stats.Dropped++
+		logger.Log.Warn("[MultiQueue] queue full, requeue dropped: type=%s host=%s", msg.Type, msg.Host)
+		return
+	}
+	q.stats.Requeued++
+	q.items = append([]Message{msg}, q.items...)
+}
+
+func coalesceKey(msg Message) string {
+	return msg.Host + "/" + msg.Scope
+}
+
+// removeScoped 호스트 전체 수집이 들어오면 대기 중인 개별(scope) 메시지 제거
+func (q *typedQueue) removeScoped(host string) {
+	kept := q.keys[:0]
+	for _, key := range q.keys {
+		if msg := q.latest[key]; msg.Host == host && msg.Scope != "" {
+			delete(q.latest, key)
+			q.stats.Coalesced++
+			continue
+		}
+		kept = append(kept, key)
+	}
+	q.keys = kept
+}
+
+// MultiQueue DataType별 큐 + 가중치 스케줄러
+type MultiQueue struct {
+	mu     sync.Mutex
+	cfg    QueueConfig
+	queues map[DataType]*typedQueue
+	order  []DataType // 스케줄링 순서 (동일 가중치일 때)
+	notify chan struct{}
+}
+
+// NewMultiQueue MultiQueue 생성
+func NewMultiQueue(cfg QueueConfig) *MultiQueue {
+	def := DefaultQueueConfig()
+	if cfg.EventLimit <= 0 {
+		cfg.EventLimit = def.EventLimit
+	}
+	if cfg.StatsLimit <= 0 {
+		cfg.StatsLimit = def.StatsLimit
+	}
+	if cfg.Weights == nil {
+		cfg.Weights = def.Weights
+	}
+
+	mq := &MultiQueue{
+		cfg:    cfg,
+		queues: make(map[DataType]*typedQueue),
+		notify: make(chan struct{}, 1),
+	}
+	for _, t := range []DataType{DataTypeEvent, DataTypeInspect, DataTypeList, DataTypeStats} {
+		mq.queueFor(t)
+	}
+	return mq
+}
+
+// queueFor 타입별 큐 반환 (없으면 생성, mu 잠금 상태 또는 생성자에서 호출)
+func (mq *MultiQueue) queueFor(t DataType) *typedQueue {
+	if q, ok := mq.queues[t]; ok {
+		return q
+	}
+
+	q := &typedQueue{policy: policyFIFO, limit: mq.cfg.EventLimit, weight: mq.cfg.Weights[t]}
+	switch t {
+	case DataTypeList, DataTypeInspect:
+		q.policy = policyLatest
+		q.latest = make(map[string]Message)
+	case DataTypeStats:
+		q.policy = policySample
+		q.limit = mq.cfg.StatsLimit
+		q.interval = mq.cfg.StatsSampleInterval
+		q.lastAccepted = make(map[string]time.Time)
+	}
+	if q.weight <= 0 {
+		q.weight = 1
+	}
+
+	mq.queues[t] = q
+	mq.order = append(mq.order, t)
+	return q
+}
+
+// Push 메시지 추가 (블로킹하지 않음)
+func (mq *MultiQueue) Push(msg Message) {
+	mq.mu.Lock()
+	mq.queueFor(msg.Type).push(msg)
+	mq.mu.Unlock()
+
+	select {
+	case mq.notify <- struct{}{}:
+	default:
+	}
+}
+
+// Requeue 전송 실패로 돌려받은 메시지를 원래 순서대로 큐 앞쪽에 다시 추가 (블로킹하지 않음)
+func (mq *MultiQueue) Requeue(msgs []Message) {
+	if len(msgs) == 0 {
+		return
+	}
+	mq.mu.Lock()
+	for i := len(msgs) - 1; i >= 0; i-- {
+		mq.queueFor(msgs[i].Type).requeue(msgs[i])
+	}
+	mq.mu.Unlock()
+
+	select {
+	case mq.notify <- struct{}{}:
+	default:
+	}
+}
+
+// next 가중치 스케줄링으로 다음 메시지 선택 (smooth weighted round-robin)
+func (mq *MultiQueue) next() (Message, bool) {
+	mq.mu.Lock()
+	defer mq.mu.Unlock()
+
+	var best *typedQueue
+	total := 0
+	for _, t := range mq.order {
+		q := mq.queues[t]
+		if q.len() == 0 {
+			continue
+		}
+		q.cur += q.weight
+		total += q.weight
+		if best == nil || q.cur > best.cur {
+			best = q
+		}
+	}
+	if best == nil {
+		return Message{}, false
+	}
+	best.cur -= total
+	return best.pop(), true
+}
+
+// Run 스케줄러 실행 : 큐의 메시지를 out 채널로 전달 (ctx 종료 시 반환)
+func (mq *MultiQueue) Run(ctx context.Context, out chan<- Message) {
+	for {
+		msg, ok := mq.next()
+		if !ok {
+			select {
+			case <-mq.notify:
+				continue
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		select {
+		case out <- msg:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Stats 타입별 큐 통계
+func (mq *MultiQueue) Stats() map[DataType]QueueStats {
+	mq.mu.Lock()
+	defer mq.mu.Unlock()
+
+	result := make(map[DataType]QueueStats, len(mq.queues))
+	for t, q := range mq.queues {
+		st := q.stats
+		st.Depth = q.len()
+		result[t] = st
+	}
+	return result
+}
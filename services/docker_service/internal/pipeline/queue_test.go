@@ -0,0 +1,290 @@
+package pipeline
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+var t0 = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// qop 큐 입력 (requeue 면 Requeue, 아니면 Push)
+type qop struct {
+	requeue bool
+	msg     Message
+}
+
+func push(t DataType, host, scope, tag string, ts time.Time) qop {
+	return qop{msg: Message{Type: t, Host: host, Scope: scope, Timestamp: ts, Data: tag}}
+}
+
+func requeue(t DataType, host, scope, tag string) qop {
+	return qop{requeue: true, msg: Message{Type: t, Host: host, Scope: scope, Timestamp: t0, Data: tag}}
+}
+
+// drain 스케줄러 순서대로 모든 메시지의 tag 반환
+func drain(mq *MultiQueue) []string {
+	var tags []string
+	for {
+		msg, ok := mq.next()
+		if !ok {
+			return tags
+		}
+		tags = append(tags, msg.Data.(string))
+	}
+}
+
+func TestQueuePolicy(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  QueueConfig
+		ops  []qop
+		want []string
+		// Dropped, Coalesced, Sampled, Requeued 만 비교
+		stats QueueStats
+	}{
+		{
+			name: "fifo keeps order",
+			ops: []qop{
+				push(DataTypeEvent, "h1", "", "a", t0),
+				push(DataTypeEvent, "h2", "", "b", t0),
+				push(DataTypeEvent, "h1", "", "c", t0),
+			},
+			want: []string{"a", "b", "c"},
+		},
+		{
+			name: "fifo limit drops oldest",
+			cfg:  QueueConfig{EventLimit: 2},
+			ops: []qop{
+				push(DataTypeEvent, "h1", "", "a", t0),
+				push(DataTypeEvent, "h1", "", "b", t0),
+				push(DataTypeEvent, "h1", "", "c", t0),
+			},
+			want:  []string{"b", "c"},
+			stats: QueueStats{Dropped: 1},
+		},
+		{
+			name: "latest coalesces per host",
+			ops: []qop{
+				push(DataTypeList, "h1", "", "a", t0),
+				push(DataTypeList, "h2", "", "b", t0),
+				push(DataTypeList, "h1", "", "c", t0),
+			},
+			want:  []string{"c", "b"},
+			stats: QueueStats{Coalesced: 1},
+		},
+		{
+			name: "latest keeps scopes apart",
+			ops: []qop{
+				push(DataTypeInspect, "h1", "c1", "a", t0),
+				push(DataTypeInspect, "h1", "c2", "b", t0),
+				push(DataTypeInspect, "h1", "c1", "c", t0),
+			},
+			want:  []string{"c", "b"},
+			stats: QueueStats{Coalesced: 1},
+		},
+		{
+			name: "removeScoped on full collect",
+			ops: []qop{
+				push(DataTypeInspect, "h1", "c1", "a", t0),
+				push(DataTypeInspect, "h2", "c1", "b", t0),
+				push(DataTypeInspect, "h1", "c2", "c", t0),
+				push(DataTypeInspect, "h1", "", "d", t0),
+			},
+			want:  []string{"b", "d"},
+			stats: QueueStats{Coalesced: 2},
+		},
+		{
+			name: "removeScoped keeps pending full collect",
+			ops: []qop{
+				push(DataTypeInspect, "h1", "", "a", t0),
+				push(DataTypeInspect, "h1", "c1", "b", t0),
+				push(DataTypeInspect, "h1", "", "c", t0),
+			},
+			want:  []string{"c"},
+			stats: QueueStats{Coalesced: 2},
+		},
+		{
+			name: "sample per host interval",
+			cfg:  QueueConfig{StatsSampleInterval: 5 * time.Second},
+			ops: []qop{
+				push(DataTypeStats, "h1", "", "a", t0),
+				push(DataTypeStats, "h1", "", "b", t0.Add(time.Second)),
+				push(DataTypeStats, "h2", "", "c", t0.Add(time.Second)),
+				push(DataTypeStats, "h1", "", "d", t0.Add(5*time.Second)),
+				push(DataTypeStats, "h2", "", "e", t0.Add(5*time.Second)),
+			},
+			want:  []string{"a", "c", "d"},
+			stats: QueueStats{Sampled: 2},
+		},
+		{
+			name: "sample disabled with limit",
+			cfg:  QueueConfig{StatsLimit: 2},
+			ops: []qop{
+				push(DataTypeStats, "h1", "", "a", t0),
+				push(DataTypeStats, "h1", "", "b", t0),
+				push(DataTypeStats, "h1", "", "c", t0),
+			},
+			want:  []string{"b", "c"},
+			stats: QueueStats{Dropped: 1},
+		},
+		{
+			name: "requeue fifo to front",
+			ops: []qop{
+				push(DataTypeEvent, "h1", "", "a", t0),
+				push(DataTypeEvent, "h1", "", "b", t0),
+				requeue(DataTypeEvent, "h1", "", "r"),
+			},
+			want:  []string{"r", "a", "b"},
+			stats: QueueStats{Requeued: 1},
+		},
+		{
+			name: "requeue fifo full drops",
+			cfg:  QueueConfig{EventLimit: 2},
+			ops: []qop{
+				push(DataTypeEvent, "h1", "", "a", t0),
+				push(DataTypeEvent, "h1", "", "b", t0),
+				requeue(DataTypeEvent, "h1", "", "r"),
+			},
+			want:  []string{"a", "b"},
+			stats: QueueStats{Dropped: 1},
+		},
+		{
+			name: "requeue stats skips sampling",
+			cfg:  QueueConfig{StatsSampleInterval: 5 * time.Second},
+			ops: []qop{
+				push(DataTypeStats, "h1", "", "a", t0),
+				requeue(DataTypeStats, "h1", "", "r"),
+			},
+			want:  []string{"r", "a"},
+			stats: QueueStats{Requeued: 1},
+		},
+		{
+			name: "requeue latest superseded",
+			ops: []qop{
+				push(DataTypeList, "h1", "", "a", t0),
+				requeue(DataTypeList, "h1", "", "r"),
+			},
+			want:  []string{"a"},
+			stats: QueueStats{Coalesced: 1},
+		},
+		{
+			name: "requeue latest new key to front",
+			ops: []qop{
+				push(DataTypeList, "h1", "", "a", t0),
+				requeue(DataTypeList, "h2", "", "r"),
+			},
+			want:  []string{"r", "a"},
+			stats: QueueStats{Requeued: 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mq := NewMultiQueue(tt.cfg)
+			typ := tt.ops[0].msg.Type
+			for _, op := range tt.ops {
+				if op.requeue {
+					mq.Requeue([]Message{op.msg})
+				} else {
+					mq.Push(op.msg)
+				}
+			}
+
+			if got := drain(mq); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("order = %v, want %v", got, tt.want)
+			}
+			st := mq.Stats()[typ]
+			got := QueueStats{Dropped: st.Dropped, Coalesced: st.Coalesced, Sampled: st.Sampled, Requeued: st.Requeued}
+			if got != tt.stats {
+				t.Fatalf("stats = %+v, want %+v", got, tt.stats)
+			}
+			if st.Depth != 0 || st.Sent != uint64(len(tt.want)) {
+				t.Fatalf("depth=%d sent=%d after drain, want 0/%d", st.Depth, st.Sent, len(tt.want))
+			}
+		})
+	}
+}
+
+func TestMultiQueueRequeueOrder(t *testing.T) {
+	mq := NewMultiQueue(QueueConfig{})
+	mq.Push(Message{Type: DataTypeEvent, Host: "h1", Data: "a"})
+	mq.Requeue([]Message{
+		{Type: DataTypeEvent, Host: "h1", Data: "r1"},
+		{Type: DataTypeEvent, Host: "h1", Data: "r2"},
+		{Type: DataTypeEvent, Host: "h1", Data: "r3"},
+	})
+
+	want := []string{"r1", "r2", "r3", "a"}
+	if got := drain(mq); !reflect.DeepEqual(got, want) {
+		t.Fatalf("order = %v, want %v", got, want)
+	}
+}
+
+func TestMultiQueueWeights(t *testing.T) {
+	all := []DataType{DataTypeEvent, DataTypeInspect, DataTypeList, DataTypeStats}
+
+	tests := []struct {
+		name    string
+		weights map[DataType]int
+		fill    []DataType // 메시지를 채울 타입 (나머지는 빈 큐)
+		rounds  int        // 가중치 합 x rounds 만큼 꺼냄
+		want    map[DataType]int
+	}{
+		{
+			name:   "default 8:2:2:1",
+			fill:   all,
+			rounds: 3,
+			want:   map[DataType]int{DataTypeEvent: 24, DataTypeInspect: 6, DataTypeList: 6, DataTypeStats: 3},
+		},
+		{
+			name:    "custom weights",
+			weights: map[DataType]int{DataTypeEvent: 3, DataTypeInspect: 1, DataTypeList: 1, DataTypeStats: 5},
+			fill:    all,
+			rounds:  2,
+			want:    map[DataType]int{DataTypeEvent: 6, DataTypeInspect: 2, DataTypeList: 2, DataTypeStats: 10},
+		},
+		{
+			name:   "empty queues skipped",
+			fill:   []DataType{DataTypeEvent, DataTypeStats},
+			rounds: 4,
+			want:   map[DataType]int{DataTypeEvent: 32, DataTypeStats: 4},
+		},
+		{
+			name:    "zero weight treated as 1",
+			weights: map[DataType]int{DataTypeEvent: 4},
+			fill:    all,
+			rounds:  2,
+			want:    map[DataType]int{DataTypeEvent: 8, DataTypeInspect: 2, DataTypeList: 2, DataTypeStats: 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mq := NewMultiQueue(QueueConfig{Weights: tt.weights})
+
+			total := 0
+			for _, typ := range tt.fill {
+				total += mq.queues[typ].weight
+			}
+			n := total * tt.rounds
+
+			// latest 큐는 호스트별 1건이므로 호스트를 달리해 n 건 이상 채운다
+			for _, typ := range tt.fill {
+				for i := 0; i < n; i++ {
+					mq.Push(Message{Type: typ, Host: "h" + string(rune('a'+i%26)) + string(rune('a'+i/26)), Data: string(typ)})
+				}
+			}
+
+			got := make(map[DataType]int)
+			for i := 0; i < n; i++ {
+				msg, ok := mq.next()
+				if !ok {
+					t.Fatalf("queue empty after %d pops", i)
+				}
+				got[msg.Type]++
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("pops over %d = %v, want %v", n, got, tt.want)
+			}
+		})
+	}
+}
@@ -16,7 +16,8 @@ const (
 type Message struct {
 	AgentId   int         `json:"agentid"`
 	Type      DataType    `json:"type"`
-	Host      string      `json:"host"` // Docker 호스트명
+	Host      string      `json:"host"`            // Docker 호스트명
+	Scope     string      `json:"scope,omitempty"` // "" : 호스트 전체, container id : 해당 컨테이너만 (이벤트 트리거 수집)
 	Timestamp time.Time   `json:"timestamp"`
 	Data      interface{} `json:"data"`
 }
@@ -0,0 +1,855 @@
+package alert
+
+// 알림 규칙 엔진
+// - EventManager 구독(alert) 으로 container 이벤트를 받아 event/restart 규칙 평가
+// - pipeline stats 샘플(Observe) 로 cpu/memory 규칙 평가
+// - 평가 주기(ALERT_EVAL_INTERVAL) 마다 host ping(host_down), 지속 시간/자동 해제 검사
+// - 알림은 fingerprint(rule/host/container) 로 중복 제거되며 firing/resolved 전환 시에만
+//   채널로 통지하고 EventManager 에 alert 이벤트를 발행한다. (무음 중인 알림은 통지하지 않음)
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"docker_service/internal/container"
+	"docker_service/internal/docker"
+	evt "docker_service/internal/event2"
+	"docker_service/internal/logger"
+	"docker_service/internal/pipeline"
+)
+
+const (
+	defaultEvalInterval = 10 * time.Second
+	statsQueue          = 100             // Observe 대기 메시지 수 (초과 시 버림)
+	storeTimeout        = 5 * time.Second // 저장소 조회/변경 제한 시간
+	pingTimeout         = 3 * time.Second // host_down ping 제한 시간
+	metaRefresh         = time.Minute     // 컨테이너 이름/label 갱신 주기
+	staleAfter          = 5 * time.Minute // cpu/memory 샘플이 없으면 해제 (컨테이너 삭제 등)
+	resolvedRetention   = time.Hour       // 해제된 알림 보관 시간 (GET /alerts)
+	subscriberID        = "alert"
+	subscriberBuffer    = 200
+)
+
+var (
+	ErrNotFound = errors.New("not found")
+)
+
+// StoreError 저장소 오류 (규칙/무음 검증 오류와 구분)
+type StoreError struct {
+	Err error
+}
+
+func (e *StoreError) Error() string { return "alert store: " + e.Err.Error() }
+
+func (e *StoreError) Unwrap() error { return e.Err }
+
+// State 알림 상태
+type State string
+
+const (
+	StateFiring   State = "firing"
+	StateResolved State = "resolved"
+)
+
+// Alert 규칙별/대상별 알림 (fingerprint 로 중복 제거)
+type Alert struct {
+	Fingerprint   string     `json:"fingerprint"` // rule/host/container
+	RuleID        int64      `json:"rule_id"`
+	RuleName      string     `json:"rule_name"`
+	Kind          RuleKind   `json:"kind"`
+	Severity      string     `json:"severity"`
+	Host          string     `json:"host"`
+	ContainerID   string     `json:"container_id,omitempty"`
+	ContainerName string     `json:"container_name,omitempty"`
+	State         State      `json:"state"`
+	Value         float64    `json:"value,omitempty"` // 사용률(%), 재시작 횟수
+	Message       string     `json:"message"`
+	Count         int        `json:"count"` // firing 중 조건 발생 횟수
+	StartsAt      time.Time  `json:"starts_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+	EndsAt        *time.Time `json:"ends_at,omitempty"`
+	Silenced      bool       `json:"silenced"`
+}
+
+// containerMeta 규칙 scope 검사용 컨테이너 정보 (labels 에 compose project 포함)
+type containerMeta struct {
+	Name   string
+	Image  string
+	Labels map[string]string
+}
+
+// Engine 알림 규칙 엔진
+type Engine struct {
+	ctx       context.Context
+	cancel    context.CancelFunc
+	wg        *sync.WaitGroup
+	dockerMng *docker.DockerClientManager
+	evtMgr    *evt.EventManager
+	store     Store
+	notifiers []Notifier
+	sender    *sender
+	interval  time.Duration
+	statsCh   chan pipeline.Message
+	done      chan struct{}
+
+	mu       sync.Mutex
+	rules    map[int64]*compiledRule
+	silences map[int64]Silence
+	alerts   map[string]*Alert
+	pending  map[string]time.Time     // cpu/memory/host_down : 조건 시작 시각
+	seen     map[string]time.Time     // fingerprint -> 마지막 조건 발생 시각
+	restarts map[string][]time.Time   // host/container -> 재시작 시각
+	last     map[string]string        // host/container -> 마지막 액션 (die -> start 재시작 판단)
+	meta     map[string]containerMeta // host/container
+	hostDown map[string]time.Time     // host -> ping 실패 시작 시각
+	metaAt   time.Time
+}
+
+// NewEngine 알림 채널(ALERT_CHANNELS)/평가 주기(ALERT_EVAL_INTERVAL) 설정으로 엔진 생성
+func NewEngine(wg *sync.WaitGroup, ct *container.Container, evtMgr *evt.EventManager, store Store) (*Engine, error) {
+	notifiers, err := ParseChannels(ct.Config.AlertChannels)
+	if err != nil {
+		return nil, err
+	}
+	interval := ct.Config.AlertEvalInterval
+	if interval <= 0 {
+		interval = defaultEvalInterval
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Engine{
+		ctx:       ctx,
+		cancel:    cancel,
+		wg:        wg,
+		dockerMng: ct.DockerMng,
+		evtMgr:    evtMgr,
+		store:     store,
+		notifiers: notifiers,
+		sender:    newSender(),
+		interval:  interval,
+		statsCh:   make(chan pipeline.Message, statsQueue),
+		done:      make(chan struct{}),
+		rules:     make(map[int64]*compiledRule),
+		silences:  make(map[int64]Silence),
+		alerts:    make(map[string]*Alert),
+		pending:   make(map[string]time.Time),
+		seen:      make(map[string]time.Time),
+		restarts:  make(map[string][]time.Time),
+		last:      make(map[string]string),
+		meta:      make(map[string]containerMeta),
+		hostDown:  make(map[string]time.Time),
+	}, nil
+}
+
+// Start 규칙/무음 로드 후 평가 루프 실행 (ctx 종료 시 반환)
+func (e *Engine) Start() error {
+	defer close(e.done)
+
+	if err := e.load(); err != nil {
+		logger.Log.Error("[Alert] load rules error: %v", err)
+	}
+
+	sub := e.evtMgr.Subscribe(subscriberID, subscriberBuffer, func(c evt.ContainerEvent) bool {
+		return c.Type == "container"
+	})
+	defer e.evtMgr.Unsubscribe(subscriberID)
+
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	logger.Log.Print(3, "[Alert] engine started, rules: %d, channels: %d, interval: %v",
+		len(e.rules), len(e.notifiers), e.interval)
+
+	go e.probe() // 시작 시 컨테이너 정보/host 상태 1회 조회
+
+	for {
+		select {
+		case <-e.ctx.Done():
+			return nil
+		case c, ok := <-sub.Events:
+			if !ok {
+				return nil
+			}
+			e.handleEvent(c)
+		case msg := <-e.statsCh:
+			e.handleStats(msg)
+		case <-ticker.C:
+			e.evaluate(time.Now())
+			go e.probe()
+		}
+	}
+}
+
+// Shutdown 평가 루프 종료 및 남은 알림 전송
+func (e *Engine) Shutdown() error {
+	logger.Log.Print(3, "[Alert] shutting down...")
+	defer e.wg.Done()
+
+	e.cancel()
+	<-e.done
+	e.sender.close()
+
+	logger.Log.Print(3, "[Alert] shutdown complete")
+	return nil
+}
+
+// Observe pipeline 수집 메시지 (stats) 수신, blocking 하지 않는다.
+func (e *Engine) Observe(msg pipeline.Message) {
+	if msg.Type != pipeline.DataTypeStats {
+		return
+	}
+	select {
+	case e.statsCh <- msg:
+	default:
+		logger.Log.Warn("[Alert] stats queue full, dropping host=%s", msg.Host)
+	}
+}
+
+func (e *Engine) load() error {
+	ctx, cancel := context.WithTimeout(e.ctx, storeTimeout)
+	defer cancel()
+
+	rules, err := e.store.Rules(ctx)
+	if err != nil {
+		return err
+	}
+	silences, err := e.store.Silences(ctx)
+	if err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, r := range rules {
+		cr, err := r.compile()
+		if err != nil {
+			logger.Log.Error("[Alert] rule id=%d name=%s invalid: %v", r.ID, r.Name, err)
+			continue
+		}
+		e.rules[r.ID] = cr
+	}
+	for _, s := range silences {
+		e.silences[s.ID] = s
+	}
+	return nil
+}
+
+func containerKey(host, id string) string {
+	return host + "/" + shortID(id)
+}
+
+func shortID(id string) string {
+	if len(id) > 12 {
+		return id[:12]
+	}
+	return id
+}
+
+// fingerprint rule/host/container (host_down : rule/host)
+func fingerprint(ruleID int64, host, containerID string) string {
+	if containerID == "" {
+		return fmt.Sprintf("%d/%s", ruleID, host)
+	}
+	return fmt.Sprintf("%d/%s/%s", ruleID, host, shortID(containerID))
+}
+
+// sortedRules id 순 규칙 (평가 순서 고정)
+func (e *Engine) sortedRules() []*compiledRule {
+	rules := make([]*compiledRule, 0, len(e.rules))
+	for _, r := range e.rules {
+		if r.Enabled {
+			rules = append(rules, r)
+		}
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].ID < rules[j].ID })
+	return rules
+}
+
+// handleEvent container 이벤트 -> event/restart 규칙
+func (e *Engine) handleEvent(c evt.ContainerEvent) {
+	now := time.Now()
+	key := containerKey(c.Host, c.ActorID)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	// 이벤트 attrs 는 허용 attribute(name, image, compose label)만 포함하므로
+	// 목록 조회로 받은 전체 label 에 덮어쓴다.
+	m := e.meta[key]
+	labels := make(map[string]string, len(m.Labels)+len(c.Attrs))
+	for k, v := range m.Labels {
+		labels[k] = v
+	}
+	for k, v := range c.Attrs {
+		labels[k] = v
+	}
+	m.Labels = labels
+	if c.ActorName != "" {
+		m.Name = c.ActorName
+	}
+	if img := c.Attrs["image"]; img != "" {
+		m.Image = img
+	}
+	if c.Action == "destroy" {
+		delete(e.meta, key)
+		delete(e.restarts, key)
+		delete(e.last, key)
+	} else {
+		e.meta[key] = m
+	}
+
+	restarted := c.Action == "start" && e.last[key] == "die"
+	if c.Action == "start" || c.Action == "die" {
+		e.last[key] = c.Action
+	}
+
+	for _, r := range e.sortedRules() {
+		if !r.matchContainer(c.Host, m) {
+			continue
+		}
+		switch r.Kind {
+		case KindEvent:
+			if !docker.Contains(r.Actions, c.Action) || !r.cond.Match("", "", c.Attrs) {
+				continue
+			}
+			msg := fmt.Sprintf("container %s %s", c.ActorName, c.Action)
+			if code, ok := c.Attrs["exitCode"]; ok {
+				msg += " (exitCode=" + code + ")"
+			}
+			e.fire(r, c.Host, c.ActorID, c.ActorName, 0, msg, now)
+
+		case KindRestart:
+			if !restarted {
+				continue
+			}
+			n := e.countRestarts(key, r.WindowSec, now, true)
+			if n > r.Count {
+				msg := fmt.Sprintf("container %s restarted %d times in %ds", c.ActorName, n, r.WindowSec)
+				e.fire(r, c.Host, c.ActorID, c.ActorName, float64(n), msg, now)
+			}
+		}
+	}
+	if restarted {
+		e.restarts[key] = append(e.restarts[key], now)
+	}
+}
+
+// countRestarts window 안의 재시작 횟수 (include : 현재 재시작 포함)
+func (e *Engine) countRestarts(key string, windowSec int, now time.Time, include bool) int {
+	from := now.Add(-time.Duration(windowSec) * time.Second)
+	n := 0
+	for _, t := range e.restarts[key] {
+		if t.After(from) {
+			n++
+		}
+	}
+	if include {
+		n++
+	}
+	return n
+}
+
+// handleStats stats 샘플 -> cpu/memory 규칙
+func (e *Engine) handleStats(msg pipeline.Message) {
+	data, ok := msg.Data.(pipeline.ContainerStatsData)
+	if !ok {
+		return
+	}
+	now := time.Now()
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	rules := e.sortedRules()
+	for _, s := range data.Stats {
+		m, ok := e.meta[containerKey(msg.Host, s.ID)]
+		if !ok {
+			m = containerMeta{Name: s.Name}
+		}
+
+		for _, r := range rules {
+			var value float64
+			switch r.Kind {
+			case KindCPU:
+				value = s.CPUPercent
+			case KindMemory:
+				if s.MemoryLimit == 0 {
+					continue // limit 없음
+				}
+				value = s.MemoryPercent
+			default:
+				continue
+			}
+			if !r.matchContainer(msg.Host, m) {
+				continue
+			}
+
+			fp := fingerprint(r.ID, msg.Host, s.ID)
+			if value <= r.Threshold {
+				delete(e.pending, fp)
+				e.resolve(fp, now)
+				continue
+			}
+			e.seen[fp] = now
+			since, ok := e.pending[fp]
+			if !ok {
+				since = now
+				e.pending[fp] = since
+			}
+			if now.Sub(since) >= time.Duration(r.ForSec)*time.Second {
+				text := fmt.Sprintf("container %s %s %.1f%% > %.1f%% for %ds", s.Name, r.Kind, value, r.Threshold, r.ForSec)
+				e.fire(r, msg.Host, s.ID, s.Name, value, text, now)
+			}
+		}
+	}
+}
+
+// probe host ping (host_down), 주기적으로 컨테이너 이름/label 갱신
+func (e *Engine) probe() {
+	hosts := e.dockerMng.GetHostNames()
+	refresh := false
+
+	e.mu.Lock()
+	if time.Since(e.metaAt) >= metaRefresh {
+		e.metaAt = time.Now()
+		refresh = true
+	}
+	e.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for _, host := range hosts {
+		wg.Add(1)
+		go func(host string) {
+			defer wg.Done()
+
+			ctx, cancel := context.WithTimeout(e.ctx, pingTimeout)
+			err := e.dockerMng.Ping(ctx, host)
+			cancel()
+			if e.ctx.Err() != nil {
+				return
+			}
+			e.handleHost(host, err)
+
+			if err != nil || !refresh {
+				return
+			}
+			e.refreshMeta(host)
+		}(host)
+	}
+	wg.Wait()
+}
+
+func (e *Engine) refreshMeta(host string) {
+	cli, err := e.dockerMng.Get(host)
+	if err != nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(e.ctx, storeTimeout)
+	defer cancel()
+	list, err := cli.ListContainers(ctx)
+	if err != nil {
+		logger.Log.Error("[Alert] host=%s list containers error: %v", host, err)
+		return
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, c := range list {
+		e.meta[containerKey(host, c.ID)] = containerMeta{Name: c.Name, Image: c.Image, Labels: c.Labels}
+	}
+}
+
+// handleHost ping 결과 -> host_down 규칙
+func (e *Engine) handleHost(host string, pingErr error) {
+	now := time.Now()
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if pingErr == nil {
+		delete(e.hostDown, host)
+	} else if _, ok := e.hostDown[host]; !ok {
+		e.hostDown[host] = now
+		logger.Log.Warn("[Alert] host=%s ping fail: %v", host, pingErr)
+	}
+
+	for _, r := range e.sortedRules() {
+		if r.Kind != KindHostDown || !r.matchHost(host) {
+			continue
+		}
+		fp := fingerprint(r.ID, host, "")
+		since, down := e.hostDown[host]
+		if !down {
+			e.resolve(fp, now)
+			continue
+		}
+		if now.Sub(since) >= time.Duration(r.ForSec)*time.Second {
+			msg := fmt.Sprintf("host %s docker daemon not responding since %s: %v", host, since.Format(time.RFC3339), pingErr)
+			e.fire(r, host, "", "", now.Sub(since).Seconds(), msg, now)
+		}
+	}
+}
+
+// evaluate 주기 검사 : event 자동 해제, restart window 만료, stats 없는 cpu/memory 해제, 만료 정리
+func (e *Engine) evaluate(now time.Time) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for fp, a := range e.alerts {
+		if a.State == StateResolved {
+			if now.Sub(*a.EndsAt) > resolvedRetention {
+				delete(e.alerts, fp)
+			}
+			continue
+		}
+
+		r, ok := e.rules[a.RuleID]
+		if !ok || !r.Enabled {
+			e.resolve(fp, now) // 규칙 삭제/비활성
+			continue
+		}
+		switch r.Kind {
+		case KindEvent:
+			if now.Sub(e.seen[fp]) >= time.Duration(r.ResolveSec)*time.Second {
+				e.resolve(fp, now)
+			}
+		case KindRestart:
+			if e.countRestarts(containerKey(a.Host, a.ContainerID), r.WindowSec, now, false) <= r.Count {
+				e.resolve(fp, now)
+			}
+		case KindCPU, KindMemory:
+			if now.Sub(e.seen[fp]) >= staleAfter {
+				e.resolve(fp, now)
+			}
+		}
+	}
+
+	// 오래된 재시작 기록/조건 정리
+	for key, list := range e.restarts {
+		var keep []time.Time
+		for _, t := range list {
+			if now.Sub(t) < 24*time.Hour {
+				keep = append(keep, t)
+			}
+		}
+		if len(keep) == 0 {
+			delete(e.restarts, key)
+		} else {
+			e.restarts[key] = keep
+		}
+	}
+	for fp, t := range e.pending {
+		if now.Sub(t) >= staleAfter && now.Sub(e.seen[fp]) >= staleAfter {
+			delete(e.pending, fp)
+		}
+	}
+	for fp, t := range e.seen {
+		if _, ok := e.alerts[fp]; !ok && now.Sub(t) >= staleAfter {
+			delete(e.seen, fp)
+		}
+	}
+	for id, s := range e.silences {
+		if !now.Before(s.EndsAt) {
+			delete(e.silences, id)
+		}
+	}
+}
+
+// fire 알림 발생 (이미 firing 이면 횟수/값만 갱신) - e.mu 보유 상태에서 호출
+func (e *Engine) fire(r *compiledRule, host, containerID, containerName string, value float64, msg string, now time.Time) {
+	fp := fingerprint(r.ID, host, containerID)
+	e.seen[fp] = now
+
+	if a, ok := e.alerts[fp]; ok && a.State == StateFiring {
+		a.Count++
+		a.Value = value
+		a.Message = msg
+		a.UpdatedAt = now
+		return
+	}
+
+	a := &Alert{
+		Fingerprint:   fp,
+		RuleID:        r.ID,
+		RuleName:      r.Name,
+		Kind:          r.Kind,
+		Severity:      r.Severity,
+		Host:          host,
+		ContainerID:   shortID(containerID),
+		ContainerName: containerName,
+		State:         StateFiring,
+		Value:         value,
+		Message:       msg,
+		Count:         1,
+		StartsAt:      now,
+		UpdatedAt:     now,
+	}
+	a.Silenced = e.silenced(a, now)
+	e.alerts[fp] = a
+	e.transition(r.Channels, a)
+}
+
+// resolve firing 알림 해제 - e.mu 보유 상태에서 호출
+func (e *Engine) resolve(fp string, now time.Time) {
+	a, ok := e.alerts[fp]
+	if !ok || a.State != StateFiring {
+		return
+	}
+	a.State = StateResolved
+	a.UpdatedAt = now
+	a.EndsAt = &now
+	delete(e.pending, fp)
+
+	var channels []string
+	if r, ok := e.rules[a.RuleID]; ok {
+		channels = r.Channels
+	}
+	e.transition(channels, a)
+}
+
+func (e *Engine) silenced(a *Alert, now time.Time) bool {
+	for _, s := range e.silences {
+		if s.Active(now) && s.Match(a) {
+			return true
+		}
+	}
+	return false
+}
+
+// transition 상태 전환 통지 : alert 이벤트 발행, 채널 전송 (무음이면 생략)
+func (e *Engine) transition(channels []string, a *Alert) {
+	logger.Log.Print(3, "[Alert] %s silenced=%v %s", a.Summary(), a.Silenced, a.Message)
+
+	action := evt.ActionFiring
+	if a.State == StateResolved {
+		action = evt.ActionResolved
+	}
+	e.evtMgr.Publish(evt.ContainerEvent{
+		Host:      a.Host,
+		Type:      evt.TypeAlert,
+		Action:    action,
+		ActorID:   a.Fingerprint,
+		ActorName: a.RuleName,
+		Timestamp: a.UpdatedAt.Unix(),
+		Attrs: map[string]string{
+			"rule_id":   fmt.Sprint(a.RuleID),
+			"severity":  a.Severity,
+			"container": a.ContainerName,
+			"message":   a.Message,
+			"silenced":  fmt.Sprint(a.Silenced),
+		},
+	})
+
+	if a.Silenced {
+		return
+	}
+	for _, n := range e.notifiers {
+		if len(channels) == 0 || docker.Contains(channels, n.Name()) {
+			e.sender.enqueue(n, *a)
+		}
+	}
+}
+
+// Alerts 알림 목록 (state : "" 전체, firing, resolved), 최근 발생 순
+func (e *Engine) Alerts(state string) []Alert {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	list := make([]Alert, 0, len(e.alerts))
+	for _, a := range e.alerts {
+		if state != "" && string(a.State) != state {
+			continue
+		}
+		list = append(list, *a)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].StartsAt.After(list[j].StartsAt) })
+	return list
+}
+
+// Channels 설정된 알림 채널 이름
+func (e *Engine) Channels() []string {
+	names := make([]string, 0, len(e.notifiers))
+	for _, n := range e.notifiers {
+		names = append(names, n.Name())
+	}
+	return names
+}
+
+// Rules 규칙 목록 (id 순)
+func (e *Engine) Rules() []Rule {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	list := make([]Rule, 0, len(e.rules))
+	for _, r := range e.rules {
+		list = append(list, r.Rule)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	return list
+}
+
+// validateChannels 규칙에 지정된 채널이 설정되어 있는지 확인
+func (e *Engine) validateChannels(r Rule) error {
+	names := e.Channels()
+	var unknown []string
+	for _, c := range r.Channels {
+		if !docker.Contains(names, c) {
+			unknown = append(unknown, c)
+		}
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("unknown channels: %s", strings.Join(unknown, ","))
+	}
+	return nil
+}
+
+// CreateRule 규칙 추가 (검사 후 저장)
+func (e *Engine) CreateRule(ctx context.Context, r Rule) (Rule, error) {
+	if err := r.Validate(); err != nil {
+		return Rule{}, err
+	}
+	if err := e.validateChannels(r); err != nil {
+		return Rule{}, err
+	}
+	cr, err := r.compile()
+	if err != nil {
+		return Rule{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, storeTimeout)
+	defer cancel()
+	id, err := e.store.CreateRule(ctx, r)
+	if err != nil {
+		return Rule{}, &StoreError{err}
+	}
+	cr.ID = id
+
+	e.mu.Lock()
+	e.rules[id] = cr
+	e.mu.Unlock()
+	return cr.Rule, nil
+}
+
+// UpdateRule 규칙 변경 (조건이 바뀌면 기존 알림은 다음 평가 때 재판단)
+func (e *Engine) UpdateRule(ctx context.Context, r Rule) (Rule, error) {
+	e.mu.Lock()
+	_, ok := e.rules[r.ID]
+	e.mu.Unlock()
+	if !ok {
+		return Rule{}, ErrNotFound
+	}
+
+	if err := r.Validate(); err != nil {
+		return Rule{}, err
+	}
+	if err := e.validateChannels(r); err != nil {
+		return Rule{}, err
+	}
+	cr, err := r.compile()
+	if err != nil {
+		return Rule{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, storeTimeout)
+	defer cancel()
+	if err := e.store.UpdateRule(ctx, r); err != nil {
+		return Rule{}, &StoreError{err}
+	}
+
+	e.mu.Lock()
+	e.rules[r.ID] = cr
+	e.mu.Unlock()
+	return cr.Rule, nil
+}
+
+// DeleteRule 규칙 삭제 (firing 알림은 해제)
+func (e *Engine) DeleteRule(ctx context.Context, id int64) error {
+	e.mu.Lock()
+	_, ok := e.rules[id]
+	e.mu.Unlock()
+	if !ok {
+		return ErrNotFound
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, storeTimeout)
+	defer cancel()
+	if err := e.store.DeleteRule(ctx, id); err != nil {
+		return &StoreError{err}
+	}
+
+	now := time.Now()
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for fp, a := range e.alerts {
+		if a.RuleID == id {
+			e.resolve(fp, now)
+		}
+	}
+	delete(e.rules, id)
+	return nil
+}
+
+// Silences 적용 중/예정 무음 목록 (id 순)
+func (e *Engine) Silences() []Silence {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	list := make([]Silence, 0, len(e.silences))
+	for _, s := range e.silences {
+		list = append(list, s)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	return list
+}
+
+// CreateSilence 무음 추가 (이미 firing 중인 일치 알림은 이후 해제 통지도 생략)
+func (e *Engine) CreateSilence(ctx context.Context, s Silence) (Silence, error) {
+	if err := s.Validate(); err != nil {
+		return Silence{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, storeTimeout)
+	defer cancel()
+	id, err := e.store.CreateSilence(ctx, s)
+	if err != nil {
+		return Silence{}, &StoreError{err}
+	}
+	s.ID = id
+
+	now := time.Now()
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.silences[id] = s
+	for _, a := range e.alerts {
+		if a.State == StateFiring && s.Active(now) && s.Match(a) {
+			a.Silenced = true
+		}
+	}
+	return s, nil
+}
+
+// DeleteSilence 무음 삭제
+func (e *Engine) DeleteSilence(ctx context.Context, id int64) error {
+	e.mu.Lock()
+	_, ok := e.silences[id]
+	e.mu.Unlock()
+	if !ok {
+		return ErrNotFound
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, storeTimeout)
+	defer cancel()
+	if err := e.store.DeleteSilence(ctx, id); err != nil {
+		return &StoreError{err}
+	}
+
+	e.mu.Lock()
+	delete(e.silences, id)
+	e.mu.Unlock()
+	return nil
+}
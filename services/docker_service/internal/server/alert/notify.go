@@ -0,0 +1,244 @@
+package alert
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/smtp"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"docker_service/internal/logger"
+)
+
+const (
+	notifyQueue   = 100              // 전송 대기 알림 수 (초과 시 버림)
+	notifyTimeout = 10 * time.Second // 채널별 전송 제한 시간
+	notifyRetry   = 3                // 전송 실패 시 재시도 횟수
+)
+
+// ChannelConfig 알림 채널 설정 (ALERT_CHANNELS)
+/*
+	[
+	  {"name":"ops-hook","type":"webhook","url":"https://example.com/hook","headers":{"Authorization":"Bearer xxx"}},
+	  {"name":"ops-slack","type":"slack","url":"https://hooks.slack.com/services/..."},
+	  {"name":"ops-mail","type":"smtp","smtp":{"addr":"smtp.example.com:587","username":"u","password":"p","from":"agent@example.com","to":["ops@example.com"]}}
+	]
+*/
+type ChannelConfig struct {
+	Name    string            `json:"name"`
+	Type    string            `json:"type"` // webhook, slack, smtp
+	URL     string            `json:"url,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+	SMTP    *SMTPConfig       `json:"smtp,omitempty"`
+}
+
+type SMTPConfig struct {
+	Addr     string   `json:"addr"` // host:port
+	Username string   `json:"username,omitempty"`
+	Password string   `json:"password,omitempty"`
+	From     string   `json:"from"`
+	To       []string `json:"to"`
+}
+
+// Notifier 알림 채널
+type Notifier interface {
+	Name() string
+	Notify(ctx context.Context, a Alert) error
+}
+
+// ParseChannels ALERT_CHANNELS JSON 파싱 (빈 문자열이면 채널 없음)
+func ParseChannels(raw string) ([]Notifier, error) {
+	if strings.TrimSpace(raw) == "" {
+		return nil, nil
+	}
+	var cfgs []ChannelConfig
+	if err := json.Unmarshal([]byte(raw), &cfgs); err != nil {
+		return nil, fmt.Errorf("parse alert channels: %w", err)
+	}
+
+	names := make(map[string]bool)
+	notifiers := make([]Notifier, 0, len(cfgs))
+	for _, c := range cfgs {
+		if c.Name == "" || names[c.Name] {
+			return nil, fmt.Errorf("alert channel name is empty or duplicated: %q", c.Name)
+		}
+		names[c.Name] = true
+
+		switch c.Type {
+		case "webhook", "slack":
+			if c.URL == "" {
+				return nil, fmt.Errorf("alert channel %s: url is required", c.Name)
+			}
+			notifiers = append(notifiers, &webhookNotifier{cfg: c, slack: c.Type == "slack", client: &http.Client{}})
+		case "smtp":
+			if c.SMTP == nil || c.SMTP.Addr == "" || c.SMTP.From == "" || len(c.SMTP.To) == 0 {
+				return nil, fmt.Errorf("alert channel %s: smtp.addr, smtp.from, smtp.to are required", c.Name)
+			}
+			notifiers = append(notifiers, &smtpNotifier{name: c.Name, cfg: *c.SMTP})
+		default:
+			return nil, fmt.Errorf("alert channel %s: invalid type %q", c.Name, c.Type)
+		}
+	}
+	return notifiers, nil
+}
+
+// Summary 알림 한 줄 요약 (slack, 메일 제목)
+func (a Alert) Summary() string {
+	target := a.Host
+	if a.ContainerName != "" {
+		target = a.Host + "/" + a.ContainerName
+	}
+	return fmt.Sprintf("[%s][%s] %s : %s", strings.ToUpper(string(a.State)), a.Severity, a.RuleName, target)
+}
+
+// webhookNotifier generic webhook (Alert JSON) / slack 호환 webhook ({"text": ...})
+type webhookNotifier struct {
+	cfg    ChannelConfig
+	slack  bool
+	client *http.Client
+}
+
+func (n *webhookNotifier) Name() string { return n.cfg.Name }
+
+func (n *webhookNotifier) Notify(ctx context.Context, a Alert) error {
+	var body any = a
+	if n.slack {
+		body = map[string]string{"text": a.Summary() + "\n" + a.Message}
+	}
+	b, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.cfg.URL, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range n.cfg.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// smtpNotifier 메일 (PLAIN 인증, 서버가 지원하면 STARTTLS)
+type smtpNotifier struct {
+	name string
+	cfg  SMTPConfig
+}
+
+func (n *smtpNotifier) Name() string { return n.name }
+
+func (n *smtpNotifier) Notify(ctx context.Context, a Alert) error {
+	host, _, err := net.SplitHostPort(n.cfg.Addr)
+	if err != nil {
+		return err
+	}
+	var auth smtp.Auth
+	if n.cfg.Username != "" {
+		auth = smtp.PlainAuth("", n.cfg.Username, n.cfg.Password, host)
+	}
+
+	detail, _ := json.MarshalIndent(a, "", "  ")
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", n.cfg.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(n.cfg.To, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", a.Summary())
+	fmt.Fprintf(&msg, "Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	fmt.Fprintf(&msg, "%s\r\n\r\n%s\r\n", a.Message, detail)
+
+	// smtp.SendMail 은 context 를 받지 않으므로 제한 시간은 goroutine 으로 처리
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- smtp.SendMail(n.cfg.Addr, auth, n.cfg.From, n.cfg.To, msg.Bytes())
+	}()
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// notification 채널별 전송 요청
+type notification struct {
+	notifier Notifier
+	alert    Alert
+}
+
+// sender 비동기 알림 전송 (평가 루프가 채널 지연에 막히지 않도록)
+type sender struct {
+	queue   chan notification
+	dropped atomic.Uint64
+	sent    atomic.Uint64
+	failed  atomic.Uint64
+
+	closeOnce sync.Once
+	done      chan struct{}
+}
+
+func newSender() *sender {
+	s := &sender{
+		queue: make(chan notification, notifyQueue),
+		done:  make(chan struct{}),
+	}
+	go s.run()
+	return s
+}
+
+func (s *sender) enqueue(n Notifier, a Alert) {
+	select {
+	case s.queue <- notification{notifier: n, alert: a}:
+	default:
+		s.dropped.Add(1)
+		logger.Log.Warn("[Alert] notify queue full, dropped channel=%s alert=%s", n.Name(), a.Fingerprint)
+	}
+}
+
+func (s *sender) run() {
+	defer close(s.done)
+	for n := range s.queue {
+		var err error
+		for i := 0; i < notifyRetry; i++ {
+			if i > 0 {
+				time.Sleep(time.Duration(i) * time.Second)
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), notifyTimeout)
+			err = n.notifier.Notify(ctx, n.alert)
+			cancel()
+			if err == nil {
+				break
+			}
+		}
+		if err != nil {
+			s.failed.Add(1)
+			logger.Log.Error("[Alert] notify channel=%s alert=%s error: %v", n.notifier.Name(), n.alert.Fingerprint, err)
+			continue
+		}
+		s.sent.Add(1)
+		logger.Log.Print(2, "[Alert] notified channel=%s %s", n.notifier.Name(), n.alert.Summary())
+	}
+}
+
+// close 남은 알림 전송 후 종료
+func (s *sender) close() {
+	s.closeOnce.Do(func() {
+		close(s.queue)
+		<-s.done
+	})
+}
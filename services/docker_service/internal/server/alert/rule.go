@@ -0,0 +1,193 @@
+package alert
+
+import (
+	"fmt"
+	"path"
+	"time"
+
+	"docker_service/internal/docker"
+)
+
+// RuleKind 규칙 종류
+type RuleKind string
+
+const (
+	KindEvent    RuleKind = "event"     // 이벤트 조건 (ex: die + exitCode!=0, oom)
+	KindRestart  RuleKind = "restart"   // window_sec 안에 재시작 count 회 초과
+	KindCPU      RuleKind = "cpu"       // CPU 사용률 > threshold(%) 가 for_sec 동안 지속
+	KindMemory   RuleKind = "memory"    // 메모리 사용률(limit 대비) > threshold(%) 가 for_sec 동안 지속
+	KindHostDown RuleKind = "host_down" // docker daemon 응답 없음이 for_sec 동안 지속
+)
+
+const (
+	SeverityInfo     = "info"
+	SeverityWarning  = "warning"
+	SeverityCritical = "critical"
+
+	defaultResolveSec = 300 // event 규칙 : 추가 발생이 없으면 자동 해제
+	defaultWindowSec  = 600 // restart 규칙 집계 구간
+)
+
+// Scope 규칙 적용 대상 (비어있는 항목은 조건 없음)
+type Scope struct {
+	Hosts    []string `json:"hosts,omitempty"`
+	Labels   []string `json:"labels,omitempty"`   // label selector : key, key=value, key!=value, !key
+	Projects []string `json:"projects,omitempty"` // compose project
+}
+
+// Rule 알림 규칙
+/*
+	{"name":"crash","kind":"event","actions":["die"],"conditions":["exitCode!=0"],"severity":"critical"}
+	{"name":"oom","kind":"event","actions":["oom"]}
+	{"name":"flapping","kind":"restart","count":3,"window_sec":600}
+	{"name":"cpu high","kind":"cpu","threshold":90,"for_sec":300,"scope":{"projects":["docker-mng"]}}
+	{"name":"mem high","kind":"memory","threshold":85,"for_sec":120}
+	{"name":"host down","kind":"host_down","for_sec":60,"channels":["ops-slack"]}
+*/
+type Rule struct {
+	ID       int64    `json:"id"`
+	Name     string   `json:"name"`
+	Kind     RuleKind `json:"kind"`
+	Enabled  bool     `json:"enabled"`
+	Severity string   `json:"severity,omitempty"` // info, warning(기본), critical
+	Scope    Scope    `json:"scope"`
+
+	Actions    []string `json:"actions,omitempty"`    // event : 이벤트 액션 (die, oom ...)
+	Conditions []string `json:"conditions,omitempty"` // event : 이벤트 속성 조건 (label selector 형식, ex: exitCode!=0)
+
+	Count     int `json:"count,omitempty"`      // restart : 재시작 횟수 (초과 시 발생)
+	WindowSec int `json:"window_sec,omitempty"` // restart : 집계 구간 (기본 600)
+
+	Threshold float64 `json:"threshold,omitempty"` // cpu, memory : 사용률(%)
+	ForSec    int     `json:"for_sec,omitempty"`   // cpu, memory, host_down : 지속 시간
+
+	ResolveSec int      `json:"resolve_sec,omitempty"` // event : 추가 발생이 없으면 자동 해제 (기본 300)
+	Channels   []string `json:"channels,omitempty"`    // 알림 채널 이름 (비어있으면 전체 채널)
+}
+
+// Validate 규칙 검사 및 기본값 적용
+func (r *Rule) Validate() error {
+	if r.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	switch r.Severity {
+	case "":
+		r.Severity = SeverityWarning
+	case SeverityInfo, SeverityWarning, SeverityCritical:
+	default:
+		return fmt.Errorf("invalid severity %q", r.Severity)
+	}
+	if r.ForSec < 0 || r.WindowSec < 0 || r.ResolveSec < 0 {
+		return fmt.Errorf("durations must not be negative")
+	}
+
+	switch r.Kind {
+	case KindEvent:
+		if len(r.Actions) == 0 {
+			return fmt.Errorf("event rule requires actions")
+		}
+		if r.ResolveSec == 0 {
+			r.ResolveSec = defaultResolveSec
+		}
+	case KindRestart:
+		if r.Count <= 0 {
+			return fmt.Errorf("restart rule requires count > 0")
+		}
+		if r.WindowSec == 0 {
+			r.WindowSec = defaultWindowSec
+		}
+	case KindCPU, KindMemory:
+		if r.Threshold <= 0 {
+			return fmt.Errorf("%s rule requires threshold > 0", r.Kind)
+		}
+	case KindHostDown:
+	default:
+		return fmt.Errorf("invalid kind %q", r.Kind)
+	}
+
+	if _, err := r.compile(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// compiledRule 평가용 규칙 (scope/조건 필터 컴파일)
+type compiledRule struct {
+	Rule
+	scope *docker.ContainerFilter // labels, projects
+	cond  *docker.ContainerFilter // 이벤트 속성 조건
+}
+
+func (r Rule) compile() (*compiledRule, error) {
+	scope, err := docker.NewContainerFilter(docker.FilterRules{
+		IncludeLabels:   r.Scope.Labels,
+		IncludeProjects: r.Scope.Projects,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scope: %w", err)
+	}
+	cond, err := docker.NewContainerFilter(docker.FilterRules{IncludeLabels: r.Conditions})
+	if err != nil {
+		return nil, fmt.Errorf("conditions: %w", err)
+	}
+	return &compiledRule{Rule: r, scope: scope, cond: cond}, nil
+}
+
+// matchHost 호스트 scope
+func (r *compiledRule) matchHost(host string) bool {
+	return len(r.Scope.Hosts) == 0 || docker.Contains(r.Scope.Hosts, host)
+}
+
+// matchContainer 컨테이너 scope (labels 에는 compose project label 포함)
+func (r *compiledRule) matchContainer(host string, c containerMeta) bool {
+	return r.matchHost(host) && r.scope.Match(c.Name, c.Image, c.Labels)
+}
+
+// Silence 알림 무음 (기간 동안 일치하는 알림은 상태만 갱신하고 통지하지 않음)
+type Silence struct {
+	ID        int64     `json:"id"`
+	RuleID    int64     `json:"rule_id,omitempty"`   // 0 : 전체 규칙
+	Host      string    `json:"host,omitempty"`      // "" : 전체 호스트
+	Container string    `json:"container,omitempty"` // 컨테이너 이름 패턴 (*, ?)
+	StartsAt  time.Time `json:"starts_at"`
+	EndsAt    time.Time `json:"ends_at"`
+	Comment   string    `json:"comment,omitempty"`
+	CreatedBy string    `json:"created_by,omitempty"`
+}
+
+// Validate 무음 검사 (starts_at 미지정 시 현재)
+func (s *Silence) Validate() error {
+	if s.StartsAt.IsZero() {
+		s.StartsAt = time.Now()
+	}
+	if !s.EndsAt.After(s.StartsAt) {
+		return fmt.Errorf("ends_at must be after starts_at")
+	}
+	if s.Container != "" {
+		if _, err := path.Match(s.Container, ""); err != nil {
+			return fmt.Errorf("invalid container pattern %q: %w", s.Container, err)
+		}
+	}
+	return nil
+}
+
+// Active 현재 적용 중 여부
+func (s Silence) Active(now time.Time) bool {
+	return !now.Before(s.StartsAt) && now.Before(s.EndsAt)
+}
+
+// Match 알림 일치 여부
+func (s Silence) Match(a *Alert) bool {
+	if s.RuleID != 0 && s.RuleID != a.RuleID {
+		return false
+	}
+	if s.Host != "" && s.Host != a.Host {
+		return false
+	}
+	if s.Container != "" {
+		if ok, _ := path.Match(s.Container, a.ContainerName); !ok {
+			return false
+		}
+	}
+	return true
+}
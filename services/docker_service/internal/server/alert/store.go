@@ -0,0 +1,218 @@
+package alert
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"sync"
+
+	"docker_service/internal/db"
+)
+
+// Store 규칙/무음 저장소
+// - mysql  : alert_rule, alert_silence 테이블 (규칙 조건은 spec JSON)
+// - memory : 재시작 시 사라짐 (DB 미사용 환경)
+type Store interface {
+	Rules(ctx context.Context) ([]Rule, error)
+	CreateRule(ctx context.Context, r Rule) (int64, error)
+	UpdateRule(ctx context.Context, r Rule) error
+	DeleteRule(ctx context.Context, id int64) error
+
+	Silences(ctx context.Context) ([]Silence, error) // 만료되지 않은 무음
+	CreateSilence(ctx context.Context, s Silence) (int64, error)
+	DeleteSilence(ctx context.Context, id int64) error
+}
+
+// MemStore 메모리 저장소
+type MemStore struct {
+	mu       sync.Mutex
+	nextId   int64
+	rules    map[int64]Rule
+	silences map[int64]Silence
+}
+
+func NewMemStore() *MemStore {
+	return &MemStore{rules: make(map[int64]Rule), silences: make(map[int64]Silence)}
+}
+
+func (s *MemStore) Rules(_ context.Context) ([]Rule, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rules := make([]Rule, 0, len(s.rules))
+	for _, r := range s.rules {
+		rules = append(rules, r)
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].ID < rules[j].ID })
+	return rules, nil
+}
+
+func (s *MemStore) CreateRule(_ context.Context, r Rule) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.nextId++
+	r.ID = s.nextId
+	s.rules[r.ID] = r
+	return r.ID, nil
+}
+
+func (s *MemStore) UpdateRule(_ context.Context, r Rule) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.rules[r.ID] = r
+	return nil
+}
+
+func (s *MemStore) DeleteRule(_ context.Context, id int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.rules, id)
+	return nil
+}
+
+func (s *MemStore) Silences(_ context.Context) ([]Silence, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	silences := make([]Silence, 0, len(s.silences))
+	for _, v := range s.silences {
+		silences = append(silences, v)
+	}
+	sort.Slice(silences, func(i, j int) bool { return silences[i].ID < silences[j].ID })
+	return silences, nil
+}
+
+func (s *MemStore) CreateSilence(_ context.Context, v Silence) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.nextId++
+	v.ID = s.nextId
+	s.silences[v.ID] = v
+	return v.ID, nil
+}
+
+func (s *MemStore) DeleteSilence(_ context.Context, id int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.silences, id)
+	return nil
+}
+
+// DbStore MySQL 저장소
+type DbStore struct {
+	dbHnd   db.DbHandler
+	agentId int
+}
+
+func NewDbStore(dbHnd db.DbHandler, agentId int) *DbStore {
+	return &DbStore{dbHnd: dbHnd, agentId: agentId}
+}
+
+// silenceMatchers alert_silence.matchers
+type silenceMatchers struct {
+	RuleID    int64  `json:"rule_id,omitempty"`
+	Host      string `json:"host,omitempty"`
+	Container string `json:"container,omitempty"`
+}
+
+func (s *DbStore) Rules(ctx context.Context) ([]Rule, error) {
+	rows, err := s.dbHnd.ReadAlertRules(ctx, s.agentId)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := make([]Rule, 0, len(rows))
+	for _, row := range rows {
+		var r Rule
+		if err := json.Unmarshal([]byte(row.Spec), &r); err != nil {
+			return nil, err
+		}
+		r.ID, r.Name, r.Enabled = row.Id, row.Name, row.Enabled
+		rules = append(rules, r)
+	}
+	return rules, nil
+}
+
+func (s *DbStore) CreateRule(ctx context.Context, r Rule) (int64, error) {
+	row, err := s.ruleRow(r)
+	if err != nil {
+		return 0, err
+	}
+	return s.dbHnd.CreateAlertRule(ctx, row)
+}
+
+func (s *DbStore) UpdateRule(ctx context.Context, r Rule) error {
+	row, err := s.ruleRow(r)
+	if err != nil {
+		return err
+	}
+	return s.dbHnd.UpdateAlertRule(ctx, row)
+}
+
+func (s *DbStore) ruleRow(r Rule) (db.AlertRule, error) {
+	spec, err := json.Marshal(r)
+	if err != nil {
+		return db.AlertRule{}, err
+	}
+	return db.AlertRule{
+		Id:      r.ID,
+		AgentId: s.agentId,
+		Name:    r.Name,
+		Enabled: r.Enabled,
+		Spec:    string(spec),
+	}, nil
+}
+
+func (s *DbStore) DeleteRule(ctx context.Context, id int64) error {
+	return s.dbHnd.DeleteAlertRule(ctx, s.agentId, id)
+}
+
+func (s *DbStore) Silences(ctx context.Context) ([]Silence, error) {
+	rows, err := s.dbHnd.ReadAlertSilences(ctx, s.agentId)
+	if err != nil {
+		return nil, err
+	}
+
+	silences := make([]Silence, 0, len(rows))
+	for _, row := range rows {
+		var m silenceMatchers
+		if err := json.Unmarshal([]byte(row.Matchers), &m); err != nil {
+			return nil, err
+		}
+		silences = append(silences, Silence{
+			ID:        row.Id,
+			RuleID:    m.RuleID,
+			Host:      m.Host,
+			Container: m.Container,
+			StartsAt:  row.StartsAt,
+			EndsAt:    row.EndsAt,
+			Comment:   row.Comment,
+			CreatedBy: row.CreatedBy,
+		})
+	}
+	return silences, nil
+}
+
+func (s *DbStore) CreateSilence(ctx context.Context, v Silence) (int64, error) {
+	matchers, err := json.Marshal(silenceMatchers{RuleID: v.RuleID, Host: v.Host, Container: v.Container})
+	if err != nil {
+		return 0, err
+	}
+	return s.dbHnd.CreateAlertSilence(ctx, db.AlertSilence{
+		AgentId:   s.agentId,
+		Matchers:  string(matchers),
+		StartsAt:  v.StartsAt,
+		EndsAt:    v.EndsAt,
+		Comment:   v.Comment,
+		CreatedBy: v.CreatedBy,
+	})
+}
+
+func (s *DbStore) DeleteSilence(ctx context.Context, id int64) error {
+	return s.dbHnd.DeleteAlertSilence(ctx, s.agentId, id)
+}
@@ -0,0 +1,17 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// agentUpstream 수집 서버 연결 상태 (연결/스트림 상태, 주소, 마지막 송수신, 재연결 수, 최근 오류)
+func (server *Server) agentUpstream(ctx *gin.Context) {
+	if server.gclient == nil {
+		ctx.JSON(http.StatusServiceUnavailable, ErrorResponse("upstream is not configured"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, SuccessResponse(server.gclient.Status()))
+}
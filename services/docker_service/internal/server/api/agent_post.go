@@ -0,0 +1,75 @@
+package api
+
+import (
+	"net/http"
+
+	"docker_service/internal/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// reconnectUpstream 스트림/연결을 끊고 즉시 다시 연결
+func (server *Server) reconnectUpstream(ctx *gin.Context) {
+	if server.gclient == nil {
+		ctx.JSON(http.StatusServiceUnavailable, ErrorResponse("upstream is not configured"))
+		return
+	}
+
+	server.gclient.Reconnect()
+	ctx.JSON(http.StatusOK, SuccessMessageResponse("reconnect requested", server.gclient.Status()))
+}
+
+// pauseUpstream 데이터 전송 중지 (연결은 유지, 수집 데이터는 pipeline 큐에 쌓인다)
+func (server *Server) pauseUpstream(ctx *gin.Context) {
+	if server.gclient == nil {
+		ctx.JSON(http.StatusServiceUnavailable, ErrorResponse("upstream is not configured"))
+		return
+	}
+
+	server.gclient.Pause()
+	ctx.JSON(http.StatusOK, SuccessMessageResponse("transmission paused", server.gclient.Status()))
+}
+
+// resumeUpstream 데이터 전송 재개
+func (server *Server) resumeUpstream(ctx *gin.Context) {
+	if server.gclient == nil {
+		ctx.JSON(http.StatusServiceUnavailable, ErrorResponse("upstream is not configured"))
+		return
+	}
+
+	server.gclient.Resume()
+	ctx.JSON(http.StatusOK, SuccessMessageResponse("transmission resumed", server.gclient.Status()))
+}
+
+// updateUpstreamAddress 수집 서버 주소 변경 (재시작 없이 재연결)
+func (server *Server) updateUpstreamAddress(ctx *gin.Context) {
+	// 수집 데이터를 다른 서버로 보낼 수 있으므로 관리자만 변경 가능 (변경/거부 모두 audit 로그)
+	username, err := server.adminUser(ctx, "change upstream address")
+	if err != nil {
+		logger.Log.Warn("[AUDIT] upstream address change denied: ip=%s err=%v", ctx.ClientIP(), err)
+		ctx.JSON(http.StatusForbidden, ErrorResponse(err.Error()))
+		return
+	}
+
+	if server.gclient == nil {
+		ctx.JSON(http.StatusServiceUnavailable, ErrorResponse("upstream is not configured"))
+		return
+	}
+
+	var req upstreamAddressRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse(err.Error()))
+		return
+	}
+
+	old := server.gclient.Status().Addr
+	if err := server.gclient.SetAddress(req.Addr); err != nil {
+		logger.Log.Warn("[AUDIT] upstream address change failed: user=%s from=%s to=%s ip=%s err=%v",
+			username, old, req.Addr, ctx.ClientIP(), err)
+		ctx.JSON(http.StatusBadRequest, ErrorResponse(err.Error()))
+		return
+	}
+	logger.Log.Warn("[AUDIT] upstream address changed: user=%s from=%s to=%s ip=%s",
+		username, old, server.gclient.Status().Addr, ctx.ClientIP())
+	ctx.JSON(http.StatusOK, SuccessMessageResponse("upstream address changed", server.gclient.Status()))
+}
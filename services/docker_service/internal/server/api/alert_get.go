@@ -0,0 +1,40 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// alertList 알림 목록 (firing + 최근 1시간 내 해제된 알림, ?state=firing|resolved)
+func (server *Server) alertList(ctx *gin.Context) {
+	if server.alertEng == nil {
+		ctx.JSON(http.StatusServiceUnavailable, ErrorResponse("alert engine is not configured"))
+		return
+	}
+
+	var req alertListRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse(err.Error()))
+		return
+	}
+	ctx.JSON(http.StatusOK, SuccessResponse(server.alertEng.Alerts(req.State)))
+}
+
+// alertRules 알림 규칙 목록
+func (server *Server) alertRules(ctx *gin.Context) {
+	if server.alertEng == nil {
+		ctx.JSON(http.StatusServiceUnavailable, ErrorResponse("alert engine is not configured"))
+		return
+	}
+	ctx.JSON(http.StatusOK, SuccessResponse(server.alertEng.Rules()))
+}
+
+// alertSilences 적용 중/예정 무음 목록
+func (server *Server) alertSilences(ctx *gin.Context) {
+	if server.alertEng == nil {
+		ctx.JSON(http.StatusServiceUnavailable, ErrorResponse("alert engine is not configured"))
+		return
+	}
+	ctx.JSON(http.StatusOK, SuccessResponse(server.alertEng.Silences()))
+}
@@ -0,0 +1,150 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"docker_service/internal/logger"
+	"docker_service/internal/server/alert"
+
+	"github.com/gin-gonic/gin"
+)
+
+// createAlertRule 알림 규칙 추가
+func (server *Server) createAlertRule(ctx *gin.Context) {
+	if server.alertEng == nil {
+		ctx.JSON(http.StatusServiceUnavailable, ErrorResponse("alert engine is not configured"))
+		return
+	}
+
+	var req alertRuleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse(err.Error()))
+		return
+	}
+
+	rule, err := server.alertEng.CreateRule(ctx, req.toRule())
+	if err != nil {
+		server.alertError(ctx, "createAlertRule", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, SuccessMessageResponse("alert rule created", rule))
+}
+
+// updateAlertRule 알림 규칙 변경 (전체 교체)
+func (server *Server) updateAlertRule(ctx *gin.Context) {
+	if server.alertEng == nil {
+		ctx.JSON(http.StatusServiceUnavailable, ErrorResponse("alert engine is not configured"))
+		return
+	}
+
+	var uri requestAlertId
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse(err.Error()))
+		return
+	}
+	var req alertRuleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse(err.Error()))
+		return
+	}
+
+	r := req.toRule()
+	r.ID = uri.Id
+	rule, err := server.alertEng.UpdateRule(ctx, r)
+	if err != nil {
+		server.alertError(ctx, "updateAlertRule", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, SuccessMessageResponse("alert rule updated", rule))
+}
+
+// deleteAlertRule 알림 규칙 삭제 (firing 알림은 해제)
+func (server *Server) deleteAlertRule(ctx *gin.Context) {
+	if server.alertEng == nil {
+		ctx.JSON(http.StatusServiceUnavailable, ErrorResponse("alert engine is not configured"))
+		return
+	}
+
+	var uri requestAlertId
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse(err.Error()))
+		return
+	}
+
+	if err := server.alertEng.DeleteRule(ctx, uri.Id); err != nil {
+		server.alertError(ctx, "deleteAlertRule", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, SuccessMessageResponse("alert rule deleted", uri.Id))
+}
+
+// createAlertSilence 무음 추가
+func (server *Server) createAlertSilence(ctx *gin.Context) {
+	if server.alertEng == nil {
+		ctx.JSON(http.StatusServiceUnavailable, ErrorResponse("alert engine is not configured"))
+		return
+	}
+
+	var req alertSilenceRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse(err.Error()))
+		return
+	}
+
+	s := req.Silence
+	if req.Duration != "" {
+		d, err := time.ParseDuration(req.Duration)
+		if err != nil || d <= 0 {
+			ctx.JSON(http.StatusBadRequest, ErrorResponse(fmt.Sprintf("invalid duration %q", req.Duration)))
+			return
+		}
+		if s.StartsAt.IsZero() {
+			s.StartsAt = time.Now()
+		}
+		s.EndsAt = s.StartsAt.Add(d)
+	}
+
+	silence, err := server.alertEng.CreateSilence(ctx, s)
+	if err != nil {
+		server.alertError(ctx, "createAlertSilence", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, SuccessMessageResponse("alert silence created", silence))
+}
+
+// deleteAlertSilence 무음 삭제 (만료 전 해제)
+func (server *Server) deleteAlertSilence(ctx *gin.Context) {
+	if server.alertEng == nil {
+		ctx.JSON(http.StatusServiceUnavailable, ErrorResponse("alert engine is not configured"))
+		return
+	}
+
+	var uri requestAlertId
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse(err.Error()))
+		return
+	}
+
+	if err := server.alertEng.DeleteSilence(ctx, uri.Id); err != nil {
+		server.alertError(ctx, "deleteAlertSilence", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, SuccessMessageResponse("alert silence deleted", uri.Id))
+}
+
+// alertError 알림 엔진 오류 응답 (없음 404, 저장소 오류 500, 그 외 검증 오류 400)
+func (server *Server) alertError(ctx *gin.Context, name string, err error) {
+	var storeErr *alert.StoreError
+	switch {
+	case errors.Is(err, alert.ErrNotFound):
+		ctx.JSON(http.StatusNotFound, ErrorResponse(err.Error()))
+	case errors.As(err, &storeErr):
+		logger.Log.Error("%s error.. [%v]", name, err)
+		ctx.JSON(http.StatusInternalServerError, ErrorResponse(err.Error()))
+	default:
+		ctx.JSON(http.StatusBadRequest, ErrorResponse(err.Error()))
+	}
+}
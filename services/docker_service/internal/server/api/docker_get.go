@@ -82,8 +82,7 @@ func (server *Server) containerInspect(ctx *gin.Context) {
 		return
 	}
 
-	response := ToContainerInspectResponse(inspect)
-	ctx.JSON(http.StatusOK, SuccessResponse(response))
+	server.inspectResponse(ctx, inspect, "local")
 }
 
 func (server *Server) containerInspect2(ctx *gin.Context) {
@@ -106,8 +105,7 @@ func (server *Server) containerInspect2(ctx *gin.Context) {
 	logger.Log.Print(2, "Image: %s", inspect.Image)
 	logger.Log.Print(2, "Name: %s", inspect.Name)
 
-	response := ToContainerInspectResponse(inspect)
-	ctx.JSON(http.StatusOK, SuccessResponse(response))
+	server.inspectResponse(ctx, inspect, host.HostName)
 }
 
 func (server *Server) statContainer(ctx *gin.Context) {
@@ -0,0 +1,82 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	evt "docker_service/internal/event2"
+
+	"github.com/gin-gonic/gin"
+)
+
+// eventHistory 이벤트 이력 조회 (최신 먼저, cursor 페이지)
+// events 는 live SSE(/events) 와 같은 ContainerEvent JSON
+func (server *Server) eventHistory(ctx *gin.Context) {
+	history := server.eventMgr.History()
+	if history == nil {
+		ctx.JSON(http.StatusServiceUnavailable, ErrorResponse("event history is not configured"))
+		return
+	}
+
+	var req eventHistoryRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse(err.Error()))
+		return
+	}
+
+	since, err := parseEventTime(req.Since)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse("since: "+err.Error()))
+		return
+	}
+	until, err := parseEventTime(req.Until)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse("until: "+err.Error()))
+		return
+	}
+
+	page, err := history.Query(ctx.Request.Context(), evt.HistoryQuery{
+		Host:      req.Host,
+		Type:      req.Type,
+		Action:    req.Action,
+		Container: req.Container,
+		Since:     since,
+		Until:     until,
+		Limit:     req.Limit,
+		Cursor:    req.Cursor,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, SuccessResponse(page))
+}
+
+// eventPolicy 이벤트 수집 정책 조회
+func (server *Server) eventPolicy(ctx *gin.Context) {
+	response := EventPolicyResponse{
+		Settings:  server.dockerMng.EventPolicyConfig(),
+		Effective: server.dockerMng.EffectiveEventRules(),
+	}
+	ctx.JSON(http.StatusOK, SuccessResponse(response))
+}
+
+// parseEventTime unix sec, RFC3339, 또는 기간(현재 - d) -> unix sec ("" : 0)
+func parseEventTime(s string) (int64, error) {
+	if s == "" {
+		return 0, nil
+	}
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return n, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t.Unix(), nil
+	}
+	if d, err := time.ParseDuration(s); err == nil && d > 0 {
+		return time.Now().Add(-d).Unix(), nil
+	}
+	return 0, fmt.Errorf("invalid time %q (unix sec, RFC3339 or duration)", s)
+}
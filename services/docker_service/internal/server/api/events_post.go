@@ -0,0 +1,31 @@
+package api
+
+import (
+	"net/http"
+
+	"docker_service/internal/docker"
+
+	"github.com/gin-gonic/gin"
+)
+
+// updateEventPolicy 이벤트 수집 정책 변경 (재시작 시 EVENT_POLICY 설정으로 복귀)
+// daemon 필터를 반영하기 위해 감시 중인 호스트의 이벤트 스트림을 끊긴 위치부터 다시 연결한다.
+func (server *Server) updateEventPolicy(ctx *gin.Context) {
+	var req docker.EventPolicyConfig
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse(err.Error()))
+		return
+	}
+
+	if err := server.dockerMng.SetEventPolicy(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse(err.Error()))
+		return
+	}
+	server.eventMgr.ReconnectHosts()
+
+	response := EventPolicyResponse{
+		Settings:  server.dockerMng.EventPolicyConfig(),
+		Effective: server.dockerMng.EffectiveEventRules(),
+	}
+	ctx.JSON(http.StatusOK, SuccessResponse(response))
+}
@@ -0,0 +1,77 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"docker_service/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// fleetContainers 전체 호스트 컨테이너 조회 (필터/정렬/cursor 페이지)
+// 호스트별로 동시에 조회하며, 실패한 호스트는 hosts 에 오류로 남기고 나머지 호스트 결과로 응답한다.
+// label 은 inspect 와 같이 마스킹하며, ?raw=true 는 REDACT_ADMIN_USERS 사용자만 원본을 받는다.
+func (server *Server) fleetContainers(ctx *gin.Context) {
+	var req fleetContainersRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse(err.Error()))
+		return
+	}
+
+	raw, err := server.fleetRaw(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusForbidden, ErrorResponse(err.Error()))
+		return
+	}
+
+	var timeout time.Duration
+	if req.Timeout != "" {
+		d, err := time.ParseDuration(req.Timeout)
+		if err != nil || d <= 0 {
+			ctx.JSON(http.StatusBadRequest, ErrorResponse("timeout: invalid duration "+req.Timeout))
+			return
+		}
+		timeout = d
+	}
+
+	sort := req.Sort
+	if sort == "" {
+		sort = service.FleetSortName
+	}
+	desc := req.Order == "desc" || (req.Order == "" && sort != service.FleetSortName)
+
+	page, err := server.service.FleetContainers(ctx.Request.Context(), service.FleetQuery{
+		Hosts:   splitValues(req.Host),
+		States:  splitValues(req.State),
+		Name:    req.Name,
+		Image:   req.Image,
+		Labels:  req.Label,
+		Sort:    sort,
+		Desc:    desc,
+		Stats:   req.Stats,
+		Timeout: timeout,
+		Limit:   req.Limit,
+		Cursor:  req.Cursor,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, SuccessResponse(ToFleetResponse(page, raw)))
+}
+
+// splitValues 반복 지정 및 comma 구분 값 (빈 값 제외)
+func splitValues(values []string) []string {
+	var result []string
+	for _, v := range values {
+		for _, s := range strings.Split(v, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				result = append(result, s)
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,38 @@
+package api
+
+import (
+	"net/http"
+
+	"docker_service/internal/server/heal"
+
+	"github.com/gin-gonic/gin"
+)
+
+// healPolicies 자가 복구 정책 및 실행 현황
+func (server *Server) healPolicies(ctx *gin.Context) {
+	if server.healer == nil {
+		ctx.JSON(http.StatusServiceUnavailable, ErrorResponse("healer is not configured"))
+		return
+	}
+	ctx.JSON(http.StatusOK, SuccessResponse(server.healer.Policies()))
+}
+
+// healAudit 자가 복구 감사 기록 (최신 먼저, ?host=&container=&policy=&limit=)
+func (server *Server) healAudit(ctx *gin.Context) {
+	if server.healer == nil {
+		ctx.JSON(http.StatusServiceUnavailable, ErrorResponse("healer is not configured"))
+		return
+	}
+
+	var req healAuditRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse(err.Error()))
+		return
+	}
+	ctx.JSON(http.StatusOK, SuccessResponse(server.healer.Audit(heal.AuditQuery{
+		Host:      req.Host,
+		Container: req.Container,
+		Policy:    req.Policy,
+		Limit:     req.Limit,
+	})))
+}
@@ -0,0 +1,54 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (server *Server) pipelineConfig(ctx *gin.Context) {
+	if server.pipeSvr == nil {
+		ctx.JSON(http.StatusServiceUnavailable, ErrorResponse("pipeline is not running"))
+		return
+	}
+
+	response := PipelineConfigResponse{
+		Settings:  server.pipeSvr.CollectorSettings(),
+		Effective: server.pipeSvr.EffectiveCollectorConfig(),
+	}
+	ctx.JSON(http.StatusOK, SuccessResponse(response))
+}
+
+func (server *Server) pipelineQueues(ctx *gin.Context) {
+	if server.pipeSvr == nil {
+		ctx.JSON(http.StatusServiceUnavailable, ErrorResponse("pipeline is not running"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, SuccessResponse(server.pipeSvr.QueueStats()))
+}
+
+func (server *Server) pipelineStatus(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, SuccessResponse(server.buildPipelineStatus()))
+}
+
+// buildPipelineStatus 수집기/큐/이벤트 구독자/gRPC 상태 수집
+func (server *Server) buildPipelineStatus() PipelineStatusResponse {
+	resp := PipelineStatusResponse{
+		Timestamp: time.Now(),
+	}
+	if server.pipeSvr != nil {
+		st := server.pipeSvr.Status()
+		resp.Pipeline = &st
+	}
+	if server.gclient != nil {
+		st := server.gclient.Status()
+		resp.Upstream = &st
+	}
+	if server.eventMgr != nil {
+		resp.Subscribers = server.eventMgr.SubscriberStatus()
+		resp.EventHosts = server.eventMgr.WatchedHosts()
+	}
+	return resp
+}
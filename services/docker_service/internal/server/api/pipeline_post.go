@@ -0,0 +1,39 @@
+package api
+
+import (
+	"net/http"
+
+	"docker_service/internal/logger"
+	"docker_service/internal/pipeline/collector"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (server *Server) updatePipelineConfig(ctx *gin.Context) {
+	if server.pipeSvr == nil {
+		ctx.JSON(http.StatusServiceUnavailable, ErrorResponse("pipeline is not running"))
+		return
+	}
+
+	var req collector.PipelineConfig
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse(err.Error()))
+		return
+	}
+	if err := req.Validate(); err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse(err.Error()))
+		return
+	}
+
+	if err := server.pipeSvr.UpdateCollectorSettings(req); err != nil {
+		logger.Log.Error("updatePipelineConfig error.. [%v]", err)
+		ctx.JSON(http.StatusInternalServerError, ErrorResponse(err.Error()))
+		return
+	}
+
+	response := PipelineConfigResponse{
+		Settings:  server.pipeSvr.CollectorSettings(),
+		Effective: server.pipeSvr.EffectiveCollectorConfig(),
+	}
+	ctx.JSON(http.StatusOK, SuccessResponse(response))
+}
@@ -0,0 +1,63 @@
+package api
+
+import (
+	"strconv"
+	"time"
+
+	"docker_service/internal/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	pipelineWsDefaultInterval = 2 * time.Second
+	pipelineWsWriteWait       = 5 * time.Second
+)
+
+// pipelineStatusWs pipeline 상태를 주기적으로 전송 (ops 화면용)
+// ?interval=초 (1~60, 기본 2초)
+func (server *Server) pipelineStatusWs(ctx *gin.Context) {
+	interval := pipelineWsDefaultInterval
+	if v, err := strconv.Atoi(ctx.Query("interval")); err == nil && v >= 1 && v <= 60 {
+		interval = time.Duration(v) * time.Second
+	}
+
+	conn, err := upgrader.Upgrade(ctx.Writer, ctx.Request, nil)
+	if err != nil {
+		logger.Log.Print(2, "[ws/pipeline] ws upgrade error: %v", err)
+		return
+	}
+	defer conn.Close()
+
+	// 클라이언트 종료 감지 (수신 메시지는 무시)
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		conn.SetWriteDeadline(time.Now().Add(pipelineWsWriteWait))
+		if err := conn.WriteJSON(server.buildPipelineStatus()); err != nil {
+			logger.Log.Print(2, "[ws/pipeline] write error: %v", err)
+			return
+		}
+
+		select {
+		case <-ticker.C:
+		case <-closed:
+			return
+		case <-server.ctx.Done():
+			conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseGoingAway, ""))
+			return
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"docker_service/internal/docker"
+	"docker_service/internal/logger"
+	"docker_service/internal/redact"
+
+	"github.com/gin-gonic/gin"
+)
+
+// redactInspect inspect 응답의 민감정보 마스킹
+// ?raw=true 요청은 REDACT_ADMIN_USERS 에 등록된 사용자의 access token 이 있어야 원본을 반환하며 audit 로그를 남긴다.
+func (server *Server) redactInspect(ctx *gin.Context, inspect docker.ContainerInspect, host string) (docker.ContainerInspect, error) {
+	if ctx.Query("raw") != "true" {
+		return redact.Default().Inspect(inspect), nil
+	}
+
+	username, err := server.rawViewer(ctx)
+	if err != nil {
+		logger.Log.Warn("[AUDIT] raw inspect denied: host=%s container=%s ip=%s err=%v",
+			host, inspect.ID, ctx.ClientIP(), err)
+		return inspect, err
+	}
+
+	logger.Log.Warn("[AUDIT] raw inspect viewed: user=%s host=%s container=%s ip=%s",
+		username, host, inspect.ID, ctx.ClientIP())
+	return inspect, nil
+}
+
+// fleetRaw fleet 조회의 label 원본 반환 여부
+// ?raw=true 요청은 redactInspect 와 같이 REDACT_ADMIN_USERS 권한을 확인하고 audit 로그를 남긴다.
+func (server *Server) fleetRaw(ctx *gin.Context) (bool, error) {
+	if ctx.Query("raw") != "true" {
+		return false, nil
+	}
+
+	username, err := server.rawViewer(ctx)
+	if err != nil {
+		logger.Log.Warn("[AUDIT] raw fleet labels denied: query=%s ip=%s err=%v",
+			ctx.Request.URL.RawQuery, ctx.ClientIP(), err)
+		return false, err
+	}
+
+	logger.Log.Warn("[AUDIT] raw fleet labels viewed: user=%s query=%s ip=%s",
+		username, ctx.Request.URL.RawQuery, ctx.ClientIP())
+	return true, nil
+}
+
+// rawViewer 원본 조회 권한이 있는 사용자 확인
+func (server *Server) rawViewer(ctx *gin.Context) (string, error) {
+	return server.adminUser(ctx, "view raw values")
+}
+
+// adminUser access token 의 사용자가 REDACT_ADMIN_USERS 에 등록된 관리자인지 확인 (action : 거부 메시지용)
+func (server *Server) adminUser(ctx *gin.Context, action string) (string, error) {
+	header := ctx.GetHeader("Authorization")
+	fields := strings.Fields(header)
+	if len(fields) != 2 || !strings.EqualFold(fields[0], "bearer") {
+		return "", fmt.Errorf("authorization header required")
+	}
+
+	payload, err := server.tokenMaker.VerifyToken(fields[1])
+	if err != nil {
+		return "", err
+	}
+
+	for _, admin := range strings.Split(server.config.RedactAdminUsers, ",") {
+		if strings.TrimSpace(admin) == payload.Username && payload.Username != "" {
+			return payload.Username, nil
+		}
+	}
+	return "", fmt.Errorf("user %s is not allowed to %s", payload.Username, action)
+}
+
+// inspectResponse 마스킹 적용 후 inspect 응답 전송
+func (server *Server) inspectResponse(ctx *gin.Context, inspect docker.ContainerInspect, host string) {
+	inspect, err := server.redactInspect(ctx, inspect, host)
+	if err != nil {
+		ctx.JSON(http.StatusForbidden, ErrorResponse(err.Error()))
+		return
+	}
+
+	response := ToContainerInspectResponse(inspect)
+	ctx.JSON(http.StatusOK, SuccessResponse(response))
+}
@@ -1,5 +1,7 @@
 package api
 
+import "docker_service/internal/server/alert"
+
 type createUserRequest struct {
 	Username       string `json:"username" binding:"required,alphanum"`
 	HashedPassword string `json:"password" binding:"required,min=4"`
@@ -41,3 +43,72 @@ type requestHostId_ID struct {
 	HostId int    `uri:"hostid" binding:"required"`
 	Id     string `uri:"id" binding:"required"` // container id
 }
+
+// upstreamAddressRequest 수집 서버 주소 변경 (PUT /agent/upstream/address)
+type upstreamAddressRequest struct {
+	Addr string `json:"addr" binding:"required"` // host:port 또는 gRPC target (dns:///host:port)
+}
+
+// eventHistoryRequest 이벤트 이력 조회 (GET /events/history)
+type eventHistoryRequest struct {
+	Host      string `form:"host"`
+	Type      string `form:"type"`
+	Action    string `form:"action"`
+	Container string `form:"container"` // 컨테이너 ID(prefix) 또는 이름
+	Since     string `form:"since"`     // unix sec, RFC3339, 또는 기간 (ex: 1h : 1시간 전부터)
+	Until     string `form:"until"`
+	Limit     int    `form:"limit" binding:"min=0,max=1000"`
+	Cursor    string `form:"cursor"` // 이전 응답의 next_cursor
+}
+
+// requestAlertId 알림 규칙/무음 id (uri)
+type requestAlertId struct {
+	Id int64 `uri:"id" binding:"required,min=1"`
+}
+
+// alertListRequest 알림 조회 (GET /alerts)
+type alertListRequest struct {
+	State string `form:"state" binding:"omitempty,oneof=firing resolved"`
+}
+
+// alertRuleRequest 알림 규칙 추가/변경 (enabled 생략 시 true)
+type alertRuleRequest struct {
+	alert.Rule
+	Enabled *bool `json:"enabled"`
+}
+
+func (req alertRuleRequest) toRule() alert.Rule {
+	r := req.Rule
+	r.Enabled = req.Enabled == nil || *req.Enabled
+	return r
+}
+
+// alertSilenceRequest 무음 추가 (ends_at 대신 duration 지정 가능, ex: 2h)
+type alertSilenceRequest struct {
+	alert.Silence
+	Duration string `json:"duration"`
+}
+
+// healAuditRequest 자가 복구 감사 기록 조회 (GET /heal/audit)
+type healAuditRequest struct {
+	Host      string `form:"host"`
+	Container string `form:"container"` // 컨테이너 ID(prefix) 또는 이름
+	Policy    string `form:"policy"`
+	Limit     int    `form:"limit" binding:"omitempty,min=1,max=500"`
+}
+
+// fleetContainersRequest 전체 호스트 컨테이너 조회 (GET /fleet/containers)
+// host, state 는 반복 지정 또는 comma 구분 (OR), label 은 반복 지정 (AND)
+type fleetContainersRequest struct {
+	Host    []string `form:"host"`
+	State   []string `form:"state"` // running, exited, paused ...
+	Name    string   `form:"name"`  // 이름 부분 문자열
+	Image   string   `form:"image"` // 이미지 부분 문자열
+	Label   []string `form:"label"` // label selector (key, key=value, key!=value, !key)
+	Sort    string   `form:"sort" binding:"omitempty,oneof=name cpu memory uptime"`
+	Order   string   `form:"order" binding:"omitempty,oneof=asc desc"` // 기본 : name 은 asc, 그 외 desc
+	Stats   bool     `form:"stats"`                                    // cpu/memory 포함 (sort=cpu, memory 이면 항상)
+	Timeout string   `form:"timeout"`                                  // 호스트별 deadline (기본 3s, 최대 4s)
+	Limit   int      `form:"limit" binding:"min=0,max=500"`
+	Cursor  string   `form:"cursor"` // 이전 응답의 next_cursor
+}
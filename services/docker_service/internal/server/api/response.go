@@ -8,6 +8,13 @@ import (
 	"docker_service/internal/config"
 	"docker_service/internal/db"
 	"docker_service/internal/docker"
+	evt "docker_service/internal/event2"
+	"docker_service/internal/pipeline/collector"
+	"docker_service/internal/redact"
+	"docker_service/internal/server/pipe"
+	gapi "docker_service/internal/server/rpc_client"
+	"docker_service/internal/server/timeline"
+	"docker_service/internal/service"
 )
 
 // ============================================================================
@@ -133,6 +140,76 @@ func ToContainerListResponse(containers []docker.Container) []ContainerResponse
 	return result
 }
 
+// ============================================================================
+// Fleet Container Response (전체 호스트)
+// ============================================================================
+
+type FleetContainerResponse struct {
+	Host string `json:"host"`
+	ContainerResponse
+	Health    string                  `json:"health,omitempty"`
+	Labels    map[string]string       `json:"labels,omitempty"`
+	StartedAt *time.Time              `json:"started_at,omitempty"` // running 컨테이너만
+	UptimeSec float64                 `json:"uptime_sec"`
+	Stats     *ContainerStatsResponse `json:"stats,omitempty"` // stats 요청 시 (조회 실패 시 생략)
+}
+
+type FleetHostResponse struct {
+	Host       string `json:"host"`
+	OK         bool   `json:"ok"`                // 목록 조회 성공 여부
+	Partial    bool   `json:"partial,omitempty"` // 일부 컨테이너 상세 누락
+	Error      string `json:"error,omitempty"`
+	Containers int    `json:"containers"` // 조건에 일치한 컨테이너 수
+	ElapsedMs  int64  `json:"elapsed_ms"`
+}
+
+type FleetResponse struct {
+	Containers []FleetContainerResponse `json:"containers"`
+	Hosts      []FleetHostResponse      `json:"hosts"`
+	Total      int                      `json:"total"`                 // 조건에 일치한 전체 컨테이너 수
+	NextCursor string                   `json:"next_cursor,omitempty"` // 마지막 페이지면 생략
+}
+
+// ToFleetResponse raw 가 아니면 label 마스킹
+func ToFleetResponse(page service.FleetPage, raw bool) FleetResponse {
+	result := FleetResponse{
+		Containers: make([]FleetContainerResponse, 0, len(page.Containers)),
+		Hosts:      make([]FleetHostResponse, 0, len(page.Hosts)),
+		Total:      page.Total,
+		NextCursor: page.NextCursor,
+	}
+	for _, c := range page.Containers {
+		labels := c.Labels
+		if !raw {
+			labels = redact.Default().Labels(labels)
+		}
+		r := FleetContainerResponse{
+			Host:              c.Host,
+			ContainerResponse: ToContainerResponse(c.Container),
+			Health:            c.Health,
+			Labels:            labels,
+			StartedAt:         c.StartedAt,
+			UptimeSec:         roundFloat(c.UptimeSec, 0),
+		}
+		if c.Stats != nil {
+			stats := ToContainerStatsResponse(*c.Stats)
+			r.Stats = &stats
+		}
+		result.Containers = append(result.Containers, r)
+	}
+	for _, h := range page.Hosts {
+		result.Hosts = append(result.Hosts, FleetHostResponse{
+			Host:       h.Host,
+			OK:         h.OK,
+			Partial:    h.Partial,
+			Error:      h.Error,
+			Containers: h.Containers,
+			ElapsedMs:  h.Elapsed.Milliseconds(),
+		})
+	}
+	return result
+}
+
 // ============================================================================
 // Container Inspect Response
 // ============================================================================
@@ -299,6 +376,47 @@ func ToContainerInspectResponse(c docker.ContainerInspect) ContainerInspectRespo
 	return resp
 }
 
+// ============================================================================
+// Container Timeline Response
+// ============================================================================
+
+type ContainerTimelineResponse struct {
+	timeline.Timeline
+
+	// docker inspect 현재 상태 (조회 실패 시 생략)
+	Current *TimelineCurrentResponse `json:"current,omitempty"`
+}
+
+type TimelineCurrentResponse struct {
+	Status       string `json:"status"`
+	RestartCount int    `json:"restart_count"` // restart policy 에 의한 재시작 횟수
+	ExitCode     int    `json:"exit_code"`
+	OOMKilled    bool   `json:"oom_killed"`
+	Health       string `json:"health,omitempty"`
+	Error        string `json:"error,omitempty"`
+	StartedAt    string `json:"started_at,omitempty"`
+	FinishedAt   string `json:"finished_at,omitempty"`
+}
+
+func ToContainerTimelineResponse(tl timeline.Timeline, inspect *docker.ContainerInspect) ContainerTimelineResponse {
+	resp := ContainerTimelineResponse{Timeline: tl}
+	if inspect == nil || inspect.State == nil {
+		return resp
+	}
+
+	resp.Current = &TimelineCurrentResponse{
+		Status:       inspect.State.Status,
+		RestartCount: inspect.RestartCount,
+		ExitCode:     inspect.State.ExitCode,
+		OOMKilled:    inspect.State.OOMKilled,
+		Health:       inspect.State.Health,
+		Error:        inspect.State.Error,
+		StartedAt:    inspect.State.StartedAt,
+		FinishedAt:   inspect.State.FinishedAt,
+	}
+	return resp
+}
+
 // ============================================================================
 // Container Stats Response
 // ============================================================================
@@ -359,3 +477,26 @@ func roundFloat(val float64, precision int) float64 {
 	ratio := math.Pow(10, float64(precision))
 	return math.Round(val*ratio) / ratio
 }
+
+// ============================================================================
+// Pipeline Config Response
+// ============================================================================
+
+type PipelineConfigResponse struct {
+	Settings  collector.PipelineConfig                                `json:"settings"`  // 요청/설정 파일 원본
+	Effective map[string]map[collector.CollectorType]collector.Config `json:"effective"` // 호스트/타입별 실제 적용값
+}
+
+type EventPolicyResponse struct {
+	Settings  docker.EventPolicyConfig     `json:"settings"`  // 요청/설정 파일 원본
+	Effective map[string]docker.EventRules `json:"effective"` // 호스트별 실제 적용값
+}
+
+// PipelineStatusResponse pipeline 자체 상태 (/pipeline/status, /ws/pipeline)
+type PipelineStatusResponse struct {
+	Timestamp   time.Time              `json:"timestamp"`
+	Pipeline    *pipe.Status           `json:"pipeline"` // aws 모드가 아니면 null
+	Upstream    *gapi.ClientStatus     `json:"upstream"` // aws 모드가 아니면 null
+	Subscribers []evt.SubscriberStatus `json:"event_subscribers"`
+	EventHosts  []string               `json:"event_hosts"`
+}
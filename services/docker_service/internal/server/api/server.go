@@ -16,12 +16,16 @@ import (
 	// "docker_service/internal/event"
 	evt "docker_service/internal/event2"
 	"docker_service/internal/logger"
+	"docker_service/internal/server/alert"
+	"docker_service/internal/server/heal"
+	"docker_service/internal/server/pipe"
+	gapi "docker_service/internal/server/rpc_client"
+	"docker_service/internal/server/timeline"
 	"docker_service/internal/server/ws"
 	"docker_service/internal/service"
 
 	apiserv "docker_service/internal/service/api"
 
-	"github.com/gdygd/goglib"
 	"github.com/gdygd/goglib/token"
 
 	"github.com/gin-gonic/gin"
@@ -47,7 +51,13 @@ type Server struct {
 	dbHnd        db.DbHandler
 	ch_terminate chan bool
 
-	eventMgr *evt.EventManager
+	eventMgr  *evt.EventManager
+	dockerMng *docker.DockerClientManager
+	pipeSvr   *pipe.Server     // aws 모드에서만 설정 (nil 가능)
+	gclient   *gapi.GrpcClient // aws 모드에서만 설정 (nil 가능)
+	alertEng  *alert.Engine
+	healer    *heal.Healer
+	timeline  *timeline.Correlator
 }
 
 func NewServer(wg *sync.WaitGroup, ct *container.Container, eventMgr *evt.EventManager) (*Server, error) {
@@ -70,6 +80,7 @@ func NewServer(wg *sync.WaitGroup, ct *container.Container, eventMgr *evt.EventM
 		hub:        ws.NewHub(ctx),
 		svr_cancel: cancel,
 		eventMgr:   eventMgr,
+		dockerMng:  ct.DockerMng,
 	}
 
 	server.setupRouter()
@@ -83,6 +94,31 @@ func NewServer(wg *sync.WaitGroup, ct *container.Container, eventMgr *evt.EventM
 	return server, nil
 }
 
+// SetPipeServer pipeline 관리 API에서 사용할 Pipe 서버 설정
+func (server *Server) SetPipeServer(pipeSvr *pipe.Server) {
+	server.pipeSvr = pipeSvr
+}
+
+// SetGrpcClient pipeline 상태 API에서 사용할 gRPC 클라이언트 설정
+func (server *Server) SetGrpcClient(gclient *gapi.GrpcClient) {
+	server.gclient = gclient
+}
+
+// SetAlertEngine 알림 API에서 사용할 알림 엔진 설정
+func (server *Server) SetAlertEngine(alertEng *alert.Engine) {
+	server.alertEng = alertEng
+}
+
+// SetHealer 자가 복구 API에서 사용할 실행기 설정
+func (server *Server) SetHealer(healer *heal.Healer) {
+	server.healer = healer
+}
+
+// SetTimeline 컨테이너 timeline API에서 사용할 lifecycle 상관 분석기 설정
+func (server *Server) SetTimeline(timeline *timeline.Correlator) {
+	server.timeline = timeline
+}
+
 func (server *Server) setupRouter() {
 	router := gin.Default()
 	router.RedirectTrailingSlash = true // /path/ → /path 리다이렉트
@@ -118,8 +154,39 @@ func (server *Server) setupRouter() {
 	router.GET("/stat2/:host/:id", server.statContainer2)         // apply tls sdk api
 	router.GET("/stat3/:hostid", server.statContainer3)           // apply tls sdk api - all container stats
 
+	router.GET("/fleet/containers", server.fleetContainers)                  // 전체 호스트 컨테이너 (필터/정렬/cursor 페이지)
+	router.GET("/containers/:hostid/:id/timeline", server.containerTimeline) // 컨테이너 lifecycle timeline (crash loop)
+
+	router.GET("/pipeline/config", server.pipelineConfig)       // 수집기 설정 조회
+	router.PUT("/pipeline/config", server.updatePipelineConfig) // 수집기 설정 변경 (변경된 수집기만 재시작)
+	router.GET("/pipeline/queues", server.pipelineQueues)       // 타입별 전송 큐 통계 (drop 카운터)
+	router.GET("/pipeline/status", server.pipelineStatus)       // 수집기/큐/이벤트 구독자/gRPC 상태
+	router.GET("/ws/pipeline", server.pipelineStatusWs)         // pipeline 상태 주기 전송 (ops 화면용)
+
+	router.GET("/agent/upstream", server.agentUpstream)                 // 수집 서버 연결 상태
+	router.POST("/agent/upstream/reconnect", server.reconnectUpstream)  // 강제 재연결
+	router.POST("/agent/upstream/pause", server.pauseUpstream)          // 데이터 전송 중지
+	router.POST("/agent/upstream/resume", server.resumeUpstream)        // 데이터 전송 재개
+	router.PUT("/agent/upstream/address", server.updateUpstreamAddress) // 수집 서버 주소 변경 (재연결)
+
 	router.GET("/ws", server.wsHandler)
-	router.GET("/events", gin.WrapF(handleSSE()))
+	router.GET("/events", gin.WrapF(handleSSE(server.eventMgr)))
+	router.GET("/events/history", server.eventHistory)     // 이벤트 이력 (cursor 페이지)
+	router.GET("/events/policy", server.eventPolicy)       // 이벤트 수집 정책 조회
+	router.PUT("/events/policy", server.updateEventPolicy) // 이벤트 수집 정책 변경 (daemon 스트림 재연결)
+
+	router.GET("/alerts", server.alertList)                          // 알림 목록 (firing/resolved)
+	router.GET("/alerts/rules", server.alertRules)                   // 알림 규칙 목록
+	router.POST("/alerts/rules", server.createAlertRule)             // 알림 규칙 추가
+	router.PUT("/alerts/rules/:id", server.updateAlertRule)          // 알림 규칙 변경
+	router.DELETE("/alerts/rules/:id", server.deleteAlertRule)       // 알림 규칙 삭제
+	router.GET("/alerts/silences", server.alertSilences)             // 무음 목록
+	router.POST("/alerts/silences", server.createAlertSilence)       // 무음 추가
+	router.DELETE("/alerts/silences/:id", server.deleteAlertSilence) // 무음 삭제
+
+	// self-healing
+	router.GET("/heal/policies", server.healPolicies) // 자가 복구 정책 및 실행 현황
+	router.GET("/heal/audit", server.healAudit)       // 자가 복구 감사 기록
 
 	// build
 	// push
@@ -134,19 +201,6 @@ func (server *Server) setupRouter() {
 	server.router = router
 }
 
-func (server *Server) sseTest() {
-	for {
-		b, _ := json.Marshal(string("hello sse test~"))
-		var evdata goglib.EventData = goglib.EventData{}
-		evdata.Msgtype = "ssetest"
-		evdata.Data = string(b)
-		evdata.Id = "3"
-
-		goglib.SendSSE(evdata)
-		time.Sleep(time.Second * 1)
-	}
-}
-
 func (server *Server) containerEventStream() {
 	// ctx, _ := context.WithTimeout(server.ctx, 5*time.Second)
 	go server.service.EventStream(context.Background(), "localhost")
@@ -305,8 +359,7 @@ func (server *Server) Start() error {
 	// 	}
 	// }
 
-	// 3. SSE에 이벤트 연결
-	go server.bridgeEventsToSSE()
+	// 3. SSE/WebSocket 이벤트는 연결별로 구독 (handleSSE, wsHandler)
 
 	// 4. WebSocket Hub 시작
 	go server.hub.Run() // web socket hub
@@ -314,9 +367,6 @@ func (server *Server) Start() error {
 
 	// go server.containerEventStream() // test
 
-	go ProcessEventMsg() // test
-	// go server.sseTest()  // tests
-
 	if err := server.srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 		logger.Log.Error("listen error. %v", err)
 		return err
@@ -341,52 +391,3 @@ func (server *Server) Shutdown() error {
 	}
 	return nil
 }
-
-// SSE로 이벤트 전달하는 브릿지
-func (server *Server) bridgeEventsToSSE() {
-	sub := server.eventMgr.Subscribe("sse-bridge", 100, nil)
-	defer server.eventMgr.Unsubscribe("sse-bridge")
-
-	for {
-		select {
-		case <-server.ctx.Done():
-			logger.Log.Print(2, "[bridgeEventsToSSE] context cancelled, stopping")
-			return
-		case evt, ok := <-sub.Events:
-			logger.Log.Print(2, "evt received..")
-
-			if !ok {
-				logger.Log.Print(2, "[bridgeEventsToSSE] channel closed, stopping")
-				return
-			}
-			data, _ := json.Marshal(evt)
-
-			goglib.SendSSE(goglib.EventData{
-				Msgtype: "container-event",
-				Data:    string(data),
-			})
-
-			logger.Log.Print(2, "evt send..")
-		}
-	}
-}
-
-func (server *Server) bridgeEventsToWebSocket() {
-	sub := server.eventMgr.Subscribe("ws-bridge", 100, nil)
-	defer server.eventMgr.Unsubscribe("ws-bridge")
-
-	for {
-		select {
-		case <-server.ctx.Done():
-			logger.Log.Print(2, "[bridgeEventsToWebSocket] context cancelled, stopping")
-			return
-		case evt, ok := <-sub.Events:
-			if !ok {
-				logger.Log.Print(2, "[bridgeEventsToWebSocket] channel closed, stopping")
-				return
-			}
-			data, _ := json.Marshal(evt)
-			server.hub.Broadcast(data)
-		}
-	}
-}
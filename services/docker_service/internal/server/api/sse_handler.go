@@ -1,88 +1,186 @@
 package api
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
+	"sync/atomic"
 	"time"
 
+	evt "docker_service/internal/event2"
 	"docker_service/internal/logger"
 
 	"github.com/gdygd/goglib"
 )
 
-func sendSse(data goglib.EventData) {
-	logger.Log.Print(1, "Active sse session : %v", ActivesseSessionList)
-	for _, actSession := range ActivesseSessionList {
-		CheckSSEMsgChannel(actSession.Key)
+/*
+이벤트 SSE (/events)
 
-		SseMsgChan[actSession.Key] <- data
-	}
-}
+  - 연결마다 EventManager 구독 (세션 수 제한 없음, 필터는 query)
+  - 이벤트마다 id (EventManager 단조 증가 ID) 를 붙이고, 재연결 시 Last-Event-ID 이후 이벤트를
+    EventManager 의 최근 이벤트(ReplaySize)에서 재전송한다.
+  - 재전송 범위를 벗어난 경우 stream-gap 이벤트를 보내며, 클라이언트는 /events/history 로 다시 맞춘다.
+  - 주기적으로 comment heartbeat 를 보내 proxy idle timeout 을 막는다.
+  - 버퍼가 넘치거나(drop) 쓰기가 sseWriteWait 안에 끝나지 않는 느린 클라이언트는 연결을 끊는다.
+    (브라우저 EventSource 는 Last-Event-ID 로 재연결하므로 backlog 범위 안에서는 누락 없이 이어진다)
+*/
 
-// ------------------------------------------------------------------------------
-// processEventMsg
-// ------------------------------------------------------------------------------
-func ProcessEventMsg() {
-	for {
-		select {
-		case event := <-goglib.ChEvent:
-			logger.Log.Print(1, "Get Event message [%s]", event.Msgtype)
-
-			if len(event.Msgtype) > 0 {
-				msg := &event
-				sendSse(*msg)
-			} else {
-				logger.Log.Error("undefined sse..[%s](%d)", event.Msgtype, event.Id)
-			}
-		}
-	}
+const (
+	sseBufferSize = 256              // 연결별 이벤트 버퍼
+	sseHeartbeat  = 15 * time.Second // comment heartbeat 주기
+	sseWriteWait  = 10 * time.Second // 1회 쓰기 제한 시간
+	sseRetry      = 3000             // 클라이언트 재연결 대기 (ms)
+
+	sseEventType = "container-event"
+	sseGapType   = "stream-gap"
+)
+
+// sseSeq SSE 구독자 ID 순번
+var (
+	sseSeq    atomic.Uint64
+	sseActive atomic.Int64
+)
+
+// sseStreamGap 재전송할 수 없는 구간 알림 (stream-gap data)
+type sseStreamGap struct {
+	LastEventID uint64 `json:"last_event_id"`
+	Reason      string `json:"reason"`
 }
 
-func handleSSE() http.HandlerFunc {
+// handleSSE 이벤트 SSE (연결별 EventManager 구독)
+// 필터 : /events?host=..&type=..&action=start,die&container=web-*&project=..
+// 재연결 : Last-Event-ID 헤더 (또는 ?last_event_id=)
+func handleSSE(eventMgr *evt.EventManager) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		// SSE는 장시간 연결이므로 WriteTimeout 해제
-		rc := http.NewResponseController(w)
-		rc.SetWriteDeadline(time.Time{}) // 타임아웃 없음
-
-		// get sse session key
-		sessionKey := GetSSeSessionKey()
-		defer func() {
-			logger.Log.Print(2, "Close sse.. [%d]", sessionKey)
-			ClearSSeSessionKey(sessionKey)
-		}()
-
-		if sessionKey == 0 {
-			// invalid key...
-			logger.Log.Error("Access handleSSE invalid key.. [%d]", sessionKey)
-			<-r.Context().Done()
+		filter, err := evt.ParseEventFilterQuery(r.URL.Query())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
 			return
 		}
 
+		lastID, resume, err := parseLastEventID(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		// 연결별 구독 (필터가 없으면 전체 이벤트)
+		subID := fmt.Sprintf("sse-%d", sseSeq.Add(1))
+		var (
+			sub      *evt.Subscriber
+			backlog  []evt.ContainerEvent
+			complete = true
+		)
+		if resume {
+			sub, backlog, complete = eventMgr.SubscribeSince(subID, sseBufferSize, filter.Func(), lastID)
+		} else {
+			sub = eventMgr.Subscribe(subID, sseBufferSize, filter.Func())
+		}
+		defer eventMgr.Unsubscribe(subID)
+
+		active := sseActive.Add(1)
+		defer sseActive.Add(-1)
+		logger.Log.Print(2, "[sse] %s connected (active=%d, last-event-id=%d, replay=%d)", subID, active, lastID, len(backlog))
+
 		// prepare the header
 		w.Header().Set("Content-Type", "text/event-stream")
 		w.Header().Set("Cache-Control", "no-cache")
 		w.Header().Set("Connection", "keep-alive")
 		w.Header().Set("Access-Control-Allow-Origin", "*")
+		w.Header().Set("X-Accel-Buffering", "no") // nginx 버퍼링 해제
+
+		// 쓰기마다 deadline 설정 (서버 WriteTimeout 대신)
+		rc := http.NewResponseController(w)
+		write := func(b []byte) error {
+			rc.SetWriteDeadline(time.Now().Add(sseWriteWait))
+			if _, err := w.Write(b); err != nil {
+				return err
+			}
+			return rc.Flush()
+		}
+
+		if err := write([]byte("retry: " + strconv.Itoa(sseRetry) + "\n\n")); err != nil {
+			return
+		}
+
+		// 재연결 : 누락 구간 재전송 (재전송 범위를 벗어나면 stream-gap)
+		var sent uint64 = lastID
+		if !complete {
+			data, _ := json.Marshal(sseStreamGap{LastEventID: lastID, Reason: "events after last_event_id are not available"})
+			gap := goglib.EventData{Msgtype: sseGapType, Data: string(data)}
+			if err := write(gap.PrepareMessage()); err != nil {
+				return
+			}
+		}
+		for _, e := range backlog {
+			if err := write(sseEventMessage(e)); err != nil {
+				return
+			}
+			sent = e.ID
+		}
 
-		// prepare the flusher
-		flusher, _ := w.(http.Flusher)
+		heartbeat := time.NewTicker(sseHeartbeat)
+		defer heartbeat.Stop()
 
-		// trap the request under loop forever
 		for {
 			select {
-
 			case <-r.Context().Done():
+				logger.Log.Print(2, "[sse] %s closed", subID)
 				return
-			default:
-				sseMsg, ok := PopSSEMsgChannel(sessionKey)
-				if ok {
-					btData := sseMsg.PrepareMessage()
-					fmt.Fprintf(w, "%s\n", btData)
 
-					flusher.Flush()
+			case <-heartbeat.C:
+				if err := write([]byte(": ping\n\n")); err != nil {
+					logger.Log.Print(2, "[sse] %s heartbeat error: %v", subID, err)
+					return
+				}
+
+			case e, ok := <-sub.Events:
+				if !ok {
+					return // EventManager 종료
+				}
+				if e.ID <= sent {
+					continue // backlog 로 이미 보낸 이벤트
+				}
+				if err := write(sseEventMessage(e)); err != nil {
+					logger.Log.Warn("[sse] %s write error, disconnecting: %v", subID, err)
+					return
+				}
+				sent = e.ID
+
+				// 느린 클라이언트 : 버퍼가 넘쳤으면 끊고 Last-Event-ID 재연결로 복구하게 한다
+				if dropped := sub.Dropped(); dropped > 0 {
+					logger.Log.Warn("[sse] %s slow consumer, dropped=%d, disconnecting", subID, dropped)
+					return
 				}
 			}
-			time.Sleep(time.Millisecond * 5)
 		}
 	}
 }
+
+// sseEventMessage 이벤트 -> SSE 메시지 (id, event, data)
+func sseEventMessage(e evt.ContainerEvent) []byte {
+	data, _ := json.Marshal(e)
+	msg := goglib.EventData{
+		Msgtype: sseEventType,
+		Data:    string(data),
+		Id:      strconv.FormatUint(e.ID, 10),
+	}
+	return msg.PrepareMessage()
+}
+
+// parseLastEventID Last-Event-ID 헤더 또는 last_event_id query (없으면 resume=false)
+func parseLastEventID(r *http.Request) (id uint64, resume bool, err error) {
+	s := r.Header.Get("Last-Event-ID")
+	if s == "" {
+		s = r.URL.Query().Get("last_event_id")
+	}
+	if s == "" {
+		return 0, false, nil
+	}
+	id, err = strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid Last-Event-ID %q", s)
+	}
+	return id, true, nil
+}
@@ -0,0 +1,63 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+
+	"docker_service/internal/docker"
+	"docker_service/internal/server/timeline"
+
+	"github.com/gin-gonic/gin"
+)
+
+// containerTimeline 컨테이너 lifecycle timeline (id : 컨테이너 ID(prefix) 또는 이름)
+// agent 기동 이후 이벤트가 없는 컨테이너는 docker inspect 현재 상태만 반환한다.
+func (server *Server) containerTimeline(ctx *gin.Context) {
+	if server.timeline == nil {
+		ctx.JSON(http.StatusServiceUnavailable, ErrorResponse("timeline is not configured"))
+		return
+	}
+
+	var req requestHostId_ID
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse(err.Error()))
+		return
+	}
+
+	host, err := server.service.ReadHostInfo(ctx, req.HostId)
+	if err != nil || host.HostName == "" {
+		ctx.JSON(http.StatusNotFound, ErrorResponse("host not found"))
+		return
+	}
+
+	tl, ok := server.timeline.Get(host.HostName, req.Id)
+	id := req.Id
+	if ok {
+		id = tl.ContainerID
+	}
+
+	var current *docker.ContainerInspect
+	if inspect, err := server.service.InspectContainer2(ctx, id, host.HostName); err == nil {
+		current = &inspect
+	}
+
+	if !ok {
+		if current == nil {
+			ctx.JSON(http.StatusNotFound, ErrorResponse("container not found"))
+			return
+		}
+		tl = timeline.Timeline{
+			Host:        host.HostName,
+			ContainerID: current.ID,
+			Name:        strings.TrimPrefix(current.Name, "/"),
+			Image:       current.Image,
+			Window:      server.timeline.Window().String(),
+			Runs:        []timeline.Run{},
+			Events:      []timeline.Entry{},
+		}
+		if current.State != nil {
+			tl.State = current.State.Status
+		}
+	}
+	ctx.JSON(http.StatusOK, SuccessResponse(ToContainerTimelineResponse(tl, current)))
+}
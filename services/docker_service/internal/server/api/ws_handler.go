@@ -1,8 +1,12 @@
 package api
 
 import (
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"sync/atomic"
 
+	evt "docker_service/internal/event2"
 	"docker_service/internal/logger"
 	"docker_service/internal/server/ws"
 
@@ -19,7 +23,28 @@ var upgrader = websocket.Upgrader{
 	},
 }
 
+// wsSeq WebSocket 이벤트 구독자 ID 순번
+var wsSeq atomic.Uint64
+
+// wsControlMessage 클라이언트 <-> 서버 제어 메시지
+//
+//	client : {"type":"subscribe","filter":{"hosts":["119server"],"actions":["start","die"]}}
+//	server : {"type":"subscribed","filter":{...}} / {"type":"error","error":"..."}
+type wsControlMessage struct {
+	Type   string           `json:"type"`
+	Filter *evt.EventFilter `json:"filter,omitempty"`
+	Error  string           `json:"error,omitempty"`
+}
+
+// wsHandler 이벤트 WebSocket (클라이언트별 EventManager 구독)
+// 초기 필터는 query (/ws?host=..&type=..&action=..&container=..&project=..), 이후 subscribe 메시지로 변경
 func (server *Server) wsHandler(ctx *gin.Context) {
+	filter, err := evt.ParseEventFilterQuery(ctx.Request.URL.Query())
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse(err.Error()))
+		return
+	}
+
 	conn, err := upgrader.Upgrade(ctx.Writer, ctx.Request, nil)
 	if err != nil {
 		logger.Log.Print(2, "[ws] ws upgrade error: %v", err)
@@ -28,16 +53,58 @@ func (server *Server) wsHandler(ctx *gin.Context) {
 
 	logger.Log.Print(2, "request client..")
 
+	subID := fmt.Sprintf("ws-%d", wsSeq.Add(1))
+	sub := server.eventMgr.Subscribe(subID, 100, filter.Func())
+
 	client := &ws.Client{
 		Hub:  server.hub,
 		Conn: conn,
 		Send: make(chan []byte, 256),
 	}
+	client.OnMessage = func(msg []byte) { server.handleWsControl(client, subID, msg) }
+	client.OnClose = func() { server.eventMgr.Unsubscribe(subID) }
 
 	server.hub.Register <- client
 
 	go client.WsRead()
 	go client.WsWrite()
+	go server.forwardEventsToWs(client, sub)
+}
+
+// forwardEventsToWs 구독 이벤트를 클라이언트로 전달 (구독 해제 시 종료)
+func (server *Server) forwardEventsToWs(client *ws.Client, sub *evt.Subscriber) {
+	for e := range sub.Events {
+		data, _ := json.Marshal(e)
+		server.hub.SendTo(client, data)
+	}
+}
+
+// handleWsControl subscribe 메시지 처리 (필터 변경)
+func (server *Server) handleWsControl(client *ws.Client, subID string, msg []byte) {
+	var req wsControlMessage
+	res := wsControlMessage{Type: "error"}
+
+	switch err := json.Unmarshal(msg, &req); {
+	case err != nil:
+		res.Error = "invalid message: " + err.Error()
+	case req.Type != "subscribe":
+		res.Error = fmt.Sprintf("unknown message type %q", req.Type)
+	default:
+		filter := evt.EventFilter{}
+		if req.Filter != nil {
+			filter = *req.Filter
+		}
+		if err := filter.Validate(); err != nil {
+			res.Error = err.Error()
+			break
+		}
+		server.eventMgr.SetFilter(subID, filter.Func())
+		res = wsControlMessage{Type: "subscribed", Filter: &filter}
+		logger.Log.Print(2, "[ws] %s filter changed: %+v", subID, filter)
+	}
+
+	data, _ := json.Marshal(res)
+	server.hub.SendTo(client, data)
 }
 
 // func (server *Server) wsHandler(c *gin.Context) {
@@ -0,0 +1,300 @@
+package command
+
+// 원격 명령 실행기
+// - gRPC DataStream 으로 수신한 ServerMessage 를 databus(server_command) 에서 구독
+// - agent 별 허용 목록(COMMAND_ALLOWLIST) 에 포함된 명령만 대상 호스트에서 실행
+// - 실행 결과는 command_id 와 함께 ReportCommand 로 서버에 보고
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"docker_service/internal/container"
+	"docker_service/internal/docker"
+	"docker_service/internal/logger"
+	"docker_service/internal/pipeline/collector"
+	"docker_service/internal/server/pipe"
+	gapi "docker_service/internal/server/rpc_client"
+	"docker_service/pb"
+
+	"github.com/gdygd/goglib/databus"
+)
+
+const (
+	commandTimeout = 30 * time.Second // 컨테이너 명령 타임아웃
+	pullTimeout    = 10 * time.Minute // 이미지 pull 타임아웃
+	maxConcurrent  = 4                // 동시 실행 명령 수
+)
+
+// errBusy 동시 실행 수 초과로 거부 (databus 구독 루프를 막지 않도록 대기하지 않는다)
+var errBusy = errors.New("agent busy: too many commands in progress")
+
+// DefaultAllowlist COMMAND_ALLOWLIST 미설정 시 허용 명령 (삭제/이미지 pull 은 명시적으로 허용해야 함)
+var DefaultAllowlist = []pb.CommandType{
+	pb.CommandType_START_CONTAINER,
+	pb.CommandType_STOP_CONTAINER,
+	pb.CommandType_RESTART_CONTAINER,
+	pb.CommandType_PAUSE_CONTAINER,
+	pb.CommandType_UNPAUSE_CONTAINER,
+	pb.CommandType_COLLECT_NOW,
+}
+
+// ParseAllowlist comma 구분 명령 목록 파싱 (대소문자 무시, "*" : 전체, "" : 기본값)
+func ParseAllowlist(s string) (map[pb.CommandType]bool, error) {
+	allow := make(map[pb.CommandType]bool)
+
+	s = strings.TrimSpace(s)
+	if s == "" {
+		for _, t := range DefaultAllowlist {
+			allow[t] = true
+		}
+		return allow, nil
+	}
+
+	for _, name := range strings.Split(s, ",") {
+		name = strings.ToUpper(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		if name == "*" {
+			for v := range pb.CommandType_name {
+				if pb.CommandType(v) != pb.CommandType_ACK {
+					allow[pb.CommandType(v)] = true
+				}
+			}
+			continue
+		}
+		v, ok := pb.CommandType_value[name]
+		if !ok || pb.CommandType(v) == pb.CommandType_ACK {
+			return nil, fmt.Errorf("unknown command: %s", name)
+		}
+		allow[pb.CommandType(v)] = true
+	}
+	return allow, nil
+}
+
+// Executor 원격 명령 실행기
+type Executor struct {
+	ctx       context.Context
+	cancel    context.CancelFunc
+	wg        *sync.WaitGroup
+	bus       *databus.DataBus
+	dockerMng *docker.DockerClientManager
+
+	gclient *gapi.GrpcClient // 결과 보고
+	pipesvr *pipe.Server     // COLLECT_NOW
+
+	allow map[pb.CommandType]bool
+	sem   chan struct{}
+	jobs  sync.WaitGroup
+}
+
+// NewExecutor Executor 생성
+func NewExecutor(wg *sync.WaitGroup, ct *container.Container, gclient *gapi.GrpcClient, pipesvr *pipe.Server) (*Executor, error) {
+	allow, err := ParseAllowlist(ct.Config.CommandAllowlist)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Executor{
+		ctx:       ctx,
+		cancel:    cancel,
+		wg:        wg,
+		bus:       ct.Bus,
+		dockerMng: ct.DockerMng,
+		gclient:   gclient,
+		pipesvr:   pipesvr,
+		allow:     allow,
+		sem:       make(chan struct{}, maxConcurrent),
+	}, nil
+}
+
+// Allowed 허용 명령 목록 (Register 시 서버에 보고)
+func (e *Executor) Allowed() []pb.CommandType {
+	cmds := make([]pb.CommandType, 0, len(e.allow))
+	for t := range e.allow {
+		cmds = append(cmds, t)
+	}
+	sort.Slice(cmds, func(i, j int) bool { return cmds[i] < cmds[j] })
+	return cmds
+}
+
+// Start server_command 구독 시작 (ctx 종료 시 반환)
+func (e *Executor) Start() error {
+	ch := e.bus.Subscribe(gapi.TopicServerCommand)
+	defer e.bus.Unsubscribe(gapi.TopicServerCommand, ch)
+
+	logger.Log.Print(3, "[CommandExecutor] started, allow: %v", e.Allowed())
+
+	for {
+		select {
+		case <-e.ctx.Done():
+			return nil
+		case m, ok := <-ch:
+			if !ok {
+				return nil
+			}
+			msg, ok := m.Data.(*pb.ServerMessage)
+			if !ok || msg.Command == pb.CommandType_ACK {
+				continue
+			}
+			e.dispatch(msg)
+		}
+	}
+}
+
+// Shutdown 구독 종료 및 실행 중인 명령 대기
+func (e *Executor) Shutdown() error {
+	logger.Log.Print(3, "[CommandExecutor] shutting down...")
+	defer e.wg.Done()
+
+	e.cancel()
+	e.jobs.Wait()
+
+	logger.Log.Print(3, "[CommandExecutor] shutdown complete")
+	return nil
+}
+
+// dispatch 명령 실행 (동시 실행 수 제한)
+// databus 는 구독자가 느리면 메시지를 버리므로 blocking 하지 않는다. 실행 슬롯이 없으면 즉시 errBusy 로 보고한다.
+func (e *Executor) dispatch(msg *pb.ServerMessage) {
+	if !e.allow[msg.Command] {
+		logger.Log.Warn("[CommandExecutor] command not allowed: id=%s cmd=%s host=%s target=%s",
+			msg.CommandId, msg.Command, msg.Host, msg.TargetContainer)
+		e.reportAsync(msg, fmt.Errorf("command not allowed: %s", msg.Command))
+		return
+	}
+
+	select {
+	case e.sem <- struct{}{}:
+	default:
+		logger.Log.Warn("[CommandExecutor] busy (%d running), rejected: id=%s cmd=%s host=%s target=%s",
+			maxConcurrent, msg.CommandId, msg.Command, msg.Host, msg.TargetContainer)
+		e.reportAsync(msg, errBusy)
+		return
+	}
+
+	e.jobs.Add(1)
+	go func() {
+		defer e.jobs.Done()
+		defer func() { <-e.sem }()
+
+		err := e.execute(msg)
+		if err != nil {
+			logger.Log.Error("[CommandExecutor] id=%s cmd=%s host=%s target=%s fail: %v",
+				msg.CommandId, msg.Command, msg.Host, msg.TargetContainer, err)
+		} else {
+			logger.Log.Print(3, "[CommandExecutor] id=%s cmd=%s host=%s target=%s done",
+				msg.CommandId, msg.Command, msg.Host, msg.TargetContainer)
+		}
+		e.report(msg, err)
+	}()
+}
+
+// execute 대상 호스트에서 명령 실행
+func (e *Executor) execute(msg *pb.ServerMessage) error {
+	if msg.Command == pb.CommandType_COLLECT_NOW {
+		return e.collectNow(msg)
+	}
+
+	cli, err := e.dockerMng.Get(msg.Host)
+	if err != nil {
+		return err
+	}
+
+	timeout := commandTimeout
+	if msg.Command == pb.CommandType_PULL_IMAGE {
+		timeout = pullTimeout
+	}
+	ctx, cancel := context.WithTimeout(e.ctx, timeout)
+	defer cancel()
+
+	if msg.Command == pb.CommandType_PULL_IMAGE {
+		image := msg.Args["image"]
+		if image == "" {
+			return fmt.Errorf("args.image is required")
+		}
+		return cli.PullImage(ctx, image)
+	}
+
+	id := msg.TargetContainer
+	if id == "" {
+		return fmt.Errorf("target_container is required")
+	}
+
+	switch msg.Command {
+	case pb.CommandType_START_CONTAINER:
+		_, err = cli.StartContainer(ctx, id)
+	case pb.CommandType_STOP_CONTAINER:
+		_, err = cli.StopContainer(ctx, id)
+	case pb.CommandType_RESTART_CONTAINER:
+		_, err = cli.RestartContainer(ctx, id)
+	case pb.CommandType_PAUSE_CONTAINER:
+		_, err = cli.PauseContainer(ctx, id)
+	case pb.CommandType_UNPAUSE_CONTAINER:
+		_, err = cli.UnpauseContainer(ctx, id)
+	case pb.CommandType_REMOVE_CONTAINER:
+		_, err = cli.RemoveContainer(ctx, id, msg.Args["force"] == "true")
+	default:
+		err = fmt.Errorf("unsupported command: %s", msg.Command)
+	}
+	return err
+}
+
+// collectNow 수집기 즉시 수집 (args.types : list,inspect,stat)
+func (e *Executor) collectNow(msg *pb.ServerMessage) error {
+	if e.pipesvr == nil {
+		return fmt.Errorf("pipeline is not running")
+	}
+
+	var types []collector.CollectorType
+	for _, t := range strings.Split(msg.Args["types"], ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			types = append(types, collector.CollectorType(t))
+		}
+	}
+
+	n, err := e.pipesvr.CollectNow(msg.Host, types)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return fmt.Errorf("no running collector for host %s (types=%v)", msg.Host, types)
+	}
+	return nil
+}
+
+// reportAsync 실행 전 거부 결과를 구독 루프 밖에서 보고
+func (e *Executor) reportAsync(msg *pb.ServerMessage, err error) {
+	e.jobs.Add(1)
+	go func() {
+		defer e.jobs.Done()
+		e.report(msg, err)
+	}()
+}
+
+// report 실행 결과 보고
+func (e *Executor) report(msg *pb.ServerMessage, err error) {
+	result := &pb.CommandResult{
+		CommandId:       msg.CommandId,
+		Command:         msg.Command,
+		Host:            msg.Host,
+		TargetContainer: msg.TargetContainer,
+		Success:         err == nil,
+		Agentid:         int32(e.gclient.AgentID()),
+		Timestamp:       time.Now().UnixMilli(),
+	}
+	if err != nil {
+		result.Error = err.Error()
+	}
+
+	if _, rerr := e.gclient.ReportCommand(result); rerr != nil {
+		logger.Log.Error("[CommandExecutor] report fail: id=%s err=%v", msg.CommandId, rerr)
+	}
+}
@@ -0,0 +1,579 @@
+package heal
+
+// 자가 복구 (container 이벤트 기반 Auto-restart 트리거)
+// - EventManager 구독(heal) 으로 die / kill / health_status / start / destroy 이벤트 수신
+// - trigger(crash, unhealthy) 가 window 안에 threshold 회 발생하면 정책 동작(restart, stop) 실행
+//   restart 는 시도마다 backoff 가 2배로 늘고 max_attempts 를 넘으면 포기(give_up)한다.
+// - 실행 직전 inspect 로 상태를 다시 확인하며 (이미 재시작됨/정상화됨 -> skip),
+//   dry-run 정책은 동작하지 않고 기록만 남긴다.
+// - 모든 동작은 감사 기록(GET /heal/audit) 과 heal 이벤트(GET /events, 이벤트 이력) 로 남는다.
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"docker_service/internal/container"
+	"docker_service/internal/docker"
+	evt "docker_service/internal/event2"
+	"docker_service/internal/logger"
+)
+
+const (
+	actionTimeout    = 30 * time.Second // inspect/restart/stop 제한 시간
+	killGrace        = 30 * time.Second // kill 후 이 시간 안의 die 는 요청에 의한 종료 (crash 아님)
+	auditSize        = 500              // 감사 기록 보관 건수
+	subscriberID     = "heal"
+	subscriberBuffer = 200
+)
+
+// Result 동작 결과
+type Result string
+
+const (
+	ResultDone    Result = "done"
+	ResultDryRun  Result = "dry_run"
+	ResultSkipped Result = "skipped" // 실행 직전 상태 확인 결과 불필요
+	ResultFailed  Result = "failed"
+	ResultGaveUp  Result = "gave_up" // max_attempts 초과
+)
+
+// AuditEntry 감사 기록
+type AuditEntry struct {
+	Time          time.Time `json:"time"`
+	Policy        string    `json:"policy"`
+	Trigger       Trigger   `json:"trigger"`
+	Action        Action    `json:"action"`
+	Host          string    `json:"host"`
+	ContainerID   string    `json:"container_id"`
+	ContainerName string    `json:"container_name"`
+	Result        Result    `json:"result"`
+	Attempt       int       `json:"attempt,omitempty"` // restart : window 안 시도 순번
+	Delay         string    `json:"delay,omitempty"`   // restart : backoff 대기
+	Reason        string    `json:"reason,omitempty"`  // skipped/failed 사유
+}
+
+// AuditQuery 감사 기록 조회 조건 (빈 값 : 조건 없음)
+type AuditQuery struct {
+	Host      string
+	Container string // 컨테이너 ID(prefix) 또는 이름
+	Policy    string
+	Limit     int
+}
+
+// PolicyStatus 정책 및 실행 현황
+type PolicyStatus struct {
+	Policy
+	DryRunForced bool `json:"dry_run_forced"` // HEAL_DRY_RUN
+	Pending      int  `json:"pending"`        // 대기 중인 restart
+	Done         int  `json:"done"`
+	Failed       int  `json:"failed"`
+	GaveUp       int  `json:"gave_up"`
+}
+
+// targetState 정책/컨테이너별 상태
+type targetState struct {
+	policy   string
+	host     string
+	id       string
+	name     string
+	triggers []time.Time
+	attempts []time.Time
+	pending  *time.Timer
+	gaveUp   bool
+}
+
+// job 실행 요청
+type job struct {
+	policy  *compiledPolicy
+	state   *targetState
+	attempt int
+	delay   time.Duration
+}
+
+// Healer 자가 복구 실행기
+type Healer struct {
+	ctx       context.Context
+	cancel    context.CancelFunc
+	wg        *sync.WaitGroup
+	dockerMng *docker.DockerClientManager
+	evtMgr    *evt.EventManager
+	policies  []*compiledPolicy
+	dryRun    bool // 전체 dry-run (HEAL_DRY_RUN)
+	jobs      sync.WaitGroup
+
+	needLabels bool                         // label 조건이 있는 정책 존재
+	labels     map[string]map[string]string // host/container -> 전체 label (loop goroutine 전용)
+
+	mu     sync.Mutex
+	states map[string]*targetState // policy/host/container
+	kills  map[string]time.Time    // host/container -> 마지막 kill
+	counts map[string]map[Result]int
+	audit  []AuditEntry // ring buffer
+	next   int
+	full   bool
+}
+
+// NewHealer 정책(HEAL_POLICIES)/dry-run(HEAL_DRY_RUN) 설정으로 생성
+func NewHealer(wg *sync.WaitGroup, ct *container.Container, evtMgr *evt.EventManager) (*Healer, error) {
+	policies, err := ParsePolicies(ct.Config.HealPolicies)
+	if err != nil {
+		return nil, err
+	}
+
+	h := &Healer{
+		wg:        wg,
+		dockerMng: ct.DockerMng,
+		evtMgr:    evtMgr,
+		dryRun:    ct.Config.HealDryRun,
+		states:    make(map[string]*targetState),
+		kills:     make(map[string]time.Time),
+		counts:    make(map[string]map[Result]int),
+		audit:     make([]AuditEntry, auditSize),
+		labels:    make(map[string]map[string]string),
+	}
+	h.ctx, h.cancel = context.WithCancel(context.Background())
+	for _, p := range policies {
+		cp, err := p.compile()
+		if err != nil {
+			return nil, err
+		}
+		h.policies = append(h.policies, cp)
+		h.counts[p.Name] = make(map[Result]int)
+		if len(p.Scope.Labels) > 0 {
+			h.needLabels = true
+		}
+	}
+	return h, nil
+}
+
+// Start 이벤트 구독 시작 (ctx 종료 시 반환)
+func (h *Healer) Start() error {
+	if len(h.policies) == 0 {
+		logger.Log.Print(3, "[Heal] no policies, disabled")
+		<-h.ctx.Done()
+		return nil
+	}
+
+	sub := h.evtMgr.Subscribe(subscriberID, subscriberBuffer, func(e evt.ContainerEvent) bool {
+		if e.Type != "container" {
+			return false
+		}
+		switch e.Action {
+		case "die", "kill", "start", "destroy", docker.ActionHealthStatus:
+			return true
+		}
+		return false
+	})
+	defer h.evtMgr.Unsubscribe(subscriberID)
+
+	logger.Log.Print(3, "[Heal] started, policies: %d, dry-run: %v", len(h.policies), h.dryRun)
+
+	for {
+		select {
+		case <-h.ctx.Done():
+			return nil
+		case e, ok := <-sub.Events:
+			if !ok {
+				return nil
+			}
+			h.handleEvent(e)
+		}
+	}
+}
+
+// Shutdown 대기 중인 restart 취소 및 실행 중인 동작 대기
+func (h *Healer) Shutdown() error {
+	logger.Log.Print(3, "[Heal] shutting down...")
+	defer h.wg.Done()
+
+	h.cancel()
+	h.mu.Lock()
+	for _, st := range h.states {
+		if st.pending != nil {
+			st.pending.Stop()
+			st.pending = nil
+		}
+	}
+	h.mu.Unlock()
+	h.jobs.Wait()
+
+	logger.Log.Print(3, "[Heal] shutdown complete")
+	return nil
+}
+
+func containerKey(host, id string) string {
+	return host + "/" + shortID(id)
+}
+
+func shortID(id string) string {
+	if len(id) > 12 {
+		return id[:12]
+	}
+	return id
+}
+
+// prune window 이전 시각 제거
+func prune(list []time.Time, window time.Duration, now time.Time) []time.Time {
+	n := 0
+	for _, t := range list {
+		if now.Sub(t) < window {
+			list[n] = t
+			n++
+		}
+	}
+	return list[:n]
+}
+
+// trigger 이벤트 -> trigger ("" : 해당 없음)
+func (h *Healer) trigger(e evt.ContainerEvent, key string, now time.Time) Trigger {
+	switch e.Action {
+	case "die":
+		code, ok := e.Attrs["exitCode"]
+		if !ok || code == "0" {
+			return ""
+		}
+		if t, ok := h.kills[key]; ok && now.Sub(t) < killGrace {
+			return "" // docker stop / kill 요청에 의한 종료
+		}
+		return TriggerCrash
+	case docker.ActionHealthStatus:
+		if e.Attrs[docker.AttrHealthStatus] == "unhealthy" {
+			return TriggerUnhealthy
+		}
+	}
+	return ""
+}
+
+// containerLabels scope 검사용 label
+// 이벤트 attrs 는 허용 attribute(compose label 포함)만 있으므로 label 조건이 있는 정책이 있으면
+// inspect 로 전체 label 을 조회해 캐시한다. (destroy 시 제거)
+func (h *Healer) containerLabels(e evt.ContainerEvent, key string) map[string]string {
+	if !h.needLabels {
+		return e.Attrs
+	}
+	if labels, ok := h.labels[key]; ok {
+		return labels
+	}
+
+	cli, err := h.dockerMng.Get(e.Host)
+	if err != nil {
+		return e.Attrs
+	}
+	ctx, cancel := context.WithTimeout(h.ctx, actionTimeout)
+	defer cancel()
+	res, err := cli.InspectContainer(ctx, e.ActorID)
+	if err != nil {
+		logger.Log.Error("[Heal] %s/%s inspect error: %v", e.Host, e.ActorName, err)
+		return e.Attrs
+	}
+	ins := docker.ConvertInspectResult(res)
+	if ins.Config == nil {
+		return e.Attrs
+	}
+	h.labels[key] = ins.Config.Labels
+	return ins.Config.Labels
+}
+
+func (h *Healer) handleEvent(e evt.ContainerEvent) {
+	now := time.Now()
+	key := containerKey(e.Host, e.ActorID)
+
+	var labels map[string]string
+	switch e.Action {
+	case "destroy":
+		delete(h.labels, key)
+	case "die", docker.ActionHealthStatus:
+		labels = h.containerLabels(e, key)
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	switch e.Action {
+	case "kill":
+		h.kills[key] = now
+		return
+	case "destroy":
+		delete(h.kills, key)
+		for _, p := range h.policies {
+			sk := p.Name + "/" + key
+			if st, ok := h.states[sk]; ok && st.pending != nil {
+				st.pending.Stop()
+			}
+			delete(h.states, sk)
+		}
+		return
+	case "start":
+		// 재시작됨 (docker restart policy, 사용자) -> 대기 중인 crash restart 취소
+		h.cancelPending(key, TriggerCrash)
+		return
+	}
+	if e.Action == docker.ActionHealthStatus && e.Attrs[docker.AttrHealthStatus] == "healthy" {
+		h.cancelPending(key, TriggerUnhealthy)
+		return
+	}
+
+	trig := h.trigger(e, key, now)
+	if trig == "" {
+		return
+	}
+
+	for _, p := range h.policies {
+		if p.Trigger != trig || !p.match(e.Host, e.ActorName, e.Attrs["image"], labels) {
+			continue
+		}
+
+		sk := p.Name + "/" + key
+		st, ok := h.states[sk]
+		if !ok {
+			st = &targetState{policy: p.Name, host: e.Host, id: e.ActorID}
+			h.states[sk] = st
+		}
+		st.name = e.ActorName
+
+		window := time.Duration(p.WindowSec) * time.Second
+		st.triggers = append(prune(st.triggers, window, now), now)
+		if len(st.triggers) < p.Threshold || st.pending != nil {
+			continue
+		}
+
+		if p.Action == ActionStop {
+			st.triggers = nil
+			h.run(job{policy: p, state: st})
+			continue
+		}
+
+		// restart : window 안 시도 횟수 확인, backoff 후 실행
+		st.attempts = prune(st.attempts, window, now)
+		if len(st.attempts) >= p.MaxAttempts {
+			if !st.gaveUp {
+				st.gaveUp = true
+				h.record(p, st, ResultGaveUp, len(st.attempts), 0,
+					fmt.Sprintf("max_attempts %d reached in %ds", p.MaxAttempts, p.WindowSec))
+			}
+			continue
+		}
+		st.gaveUp = false
+		st.triggers = nil
+
+		delay := time.Duration(p.BackoffSec) * time.Second << len(st.attempts)
+		if max := time.Duration(p.MaxBackoffSec) * time.Second; delay > max || delay <= 0 {
+			delay = max
+		}
+		st.attempts = append(st.attempts, now)
+
+		j := job{policy: p, state: st, attempt: len(st.attempts), delay: delay}
+		var timer *time.Timer
+		timer = time.AfterFunc(delay, func() {
+			h.mu.Lock()
+			defer h.mu.Unlock()
+			if st.pending != timer { // 취소됨
+				return
+			}
+			st.pending = nil
+			h.run(j)
+		})
+		st.pending = timer
+		logger.Log.Print(2, "[Heal] policy=%s %s/%s restart scheduled in %v (attempt %d/%d)",
+			p.Name, e.Host, e.ActorName, delay, j.attempt, p.MaxAttempts)
+	}
+}
+
+// cancelPending 컨테이너의 대기 중인 restart 취소 - h.mu 보유 상태에서 호출
+func (h *Healer) cancelPending(key string, trig Trigger) {
+	for _, p := range h.policies {
+		if p.Trigger != trig {
+			continue
+		}
+		st, ok := h.states[p.Name+"/"+key]
+		if !ok || st.pending == nil {
+			continue
+		}
+		st.pending.Stop()
+		st.pending = nil
+		h.record(p, st, ResultSkipped, len(st.attempts), 0, "recovered before restart")
+		// 실행하지 않은 시도는 max_attempts 에서 제외
+		if n := len(st.attempts); n > 0 {
+			st.attempts = st.attempts[:n-1]
+		}
+	}
+}
+
+// run 동작 실행 goroutine 시작 - h.mu 보유 상태에서 호출
+func (h *Healer) run(j job) {
+	if h.ctx.Err() != nil {
+		return
+	}
+	h.jobs.Add(1)
+	go func() {
+		defer h.jobs.Done()
+		result, reason := h.execute(j)
+
+		h.mu.Lock()
+		h.record(j.policy, j.state, result, j.attempt, j.delay, reason)
+		h.mu.Unlock()
+	}()
+}
+
+// execute 상태 재확인 후 동작 (dry-run 이면 확인만)
+func (h *Healer) execute(j job) (Result, string) {
+	p, st := j.policy, j.state
+
+	cli, err := h.dockerMng.Get(st.host)
+	if err != nil {
+		return ResultFailed, err.Error()
+	}
+	ctx, cancel := context.WithTimeout(h.ctx, actionTimeout)
+	defer cancel()
+
+	res, err := cli.InspectContainer(ctx, st.id)
+	if err != nil {
+		return ResultFailed, "inspect: " + err.Error()
+	}
+	ins := docker.ConvertInspectResult(res)
+	if ins.State == nil {
+		return ResultFailed, "inspect: no state"
+	}
+	if ins.Config != nil && !p.match(st.host, ins.Name, ins.Image, ins.Config.Labels) {
+		return ResultSkipped, "out of scope (labels)"
+	}
+
+	switch {
+	case p.Action == ActionStop && !ins.State.Running && !ins.State.Restarting:
+		return ResultSkipped, "not running"
+	case p.Action == ActionRestart && p.Trigger == TriggerCrash && ins.State.Running:
+		return ResultSkipped, "already running"
+	case p.Action == ActionRestart && p.Trigger == TriggerUnhealthy && ins.State.Health != "unhealthy":
+		return ResultSkipped, "health is " + ins.State.Health
+	}
+
+	if p.DryRun || h.dryRun {
+		return ResultDryRun, ""
+	}
+
+	if p.Action == ActionStop {
+		_, err = cli.StopContainer(ctx, st.id)
+	} else {
+		_, err = cli.RestartContainer(ctx, st.id)
+	}
+	if err != nil {
+		return ResultFailed, err.Error()
+	}
+	return ResultDone, ""
+}
+
+// record 감사 기록, 로그, heal 이벤트 발행 - h.mu 보유 상태에서 호출
+func (h *Healer) record(p *compiledPolicy, st *targetState, result Result, attempt int, delay time.Duration, reason string) {
+	a := AuditEntry{
+		Time:          time.Now(),
+		Policy:        p.Name,
+		Trigger:       p.Trigger,
+		Action:        p.Action,
+		Host:          st.host,
+		ContainerID:   shortID(st.id),
+		ContainerName: st.name,
+		Result:        result,
+		Attempt:       attempt,
+		Reason:        reason,
+	}
+	if delay > 0 {
+		a.Delay = delay.String()
+	}
+
+	h.audit[h.next] = a
+	h.next = (h.next + 1) % len(h.audit)
+	if h.next == 0 {
+		h.full = true
+	}
+	h.counts[p.Name][result]++
+
+	if result == ResultFailed || result == ResultGaveUp {
+		logger.Log.Warn("[Heal] policy=%s %s %s/%s %s: %s", p.Name, p.Action, st.host, st.name, result, reason)
+	} else {
+		logger.Log.Print(3, "[Heal] policy=%s %s %s/%s %s %s", p.Name, p.Action, st.host, st.name, result, reason)
+	}
+
+	attrs := map[string]string{
+		"policy":  p.Name,
+		"trigger": string(p.Trigger),
+		"result":  string(result),
+		"name":    st.name,
+	}
+	if attempt > 0 {
+		attrs["attempt"] = strconv.Itoa(attempt)
+	}
+	if reason != "" {
+		attrs["reason"] = reason
+	}
+	action := string(p.Action)
+	if result == ResultGaveUp {
+		action = string(ResultGaveUp)
+	}
+	h.evtMgr.Publish(evt.ContainerEvent{
+		Host:      st.host,
+		Type:      evt.TypeHeal,
+		Action:    action,
+		ActorID:   st.id,
+		ActorName: st.name,
+		Timestamp: a.Time.Unix(),
+		Attrs:     attrs,
+	})
+}
+
+// Audit 감사 기록 (최신 먼저)
+func (h *Healer) Audit(q AuditQuery) []AuditEntry {
+	if q.Limit <= 0 || q.Limit > auditSize {
+		q.Limit = auditSize
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	n := h.next
+	if h.full {
+		n = len(h.audit)
+	}
+	list := make([]AuditEntry, 0, min(n, q.Limit))
+	for i := 1; i <= n && len(list) < q.Limit; i++ {
+		a := h.audit[(h.next-i+len(h.audit))%len(h.audit)]
+		if q.Host != "" && a.Host != q.Host {
+			continue
+		}
+		if q.Policy != "" && a.Policy != q.Policy {
+			continue
+		}
+		if q.Container != "" && a.ContainerName != q.Container && !strings.HasPrefix(a.ContainerID, q.Container) {
+			continue
+		}
+		list = append(list, a)
+	}
+	return list
+}
+
+// Policies 정책 및 실행 현황
+func (h *Healer) Policies() []PolicyStatus {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	list := make([]PolicyStatus, 0, len(h.policies))
+	for _, p := range h.policies {
+		s := PolicyStatus{
+			Policy:       p.Policy,
+			DryRunForced: h.dryRun,
+			Done:         h.counts[p.Name][ResultDone],
+			Failed:       h.counts[p.Name][ResultFailed],
+			GaveUp:       h.counts[p.Name][ResultGaveUp],
+		}
+		for _, st := range h.states {
+			if st.policy == p.Name && st.pending != nil {
+				s.Pending++
+			}
+		}
+		list = append(list, s)
+	}
+	return list
+}
@@ -0,0 +1,149 @@
+package heal
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"docker_service/internal/docker"
+)
+
+// Trigger 정책 발동 조건
+type Trigger string
+
+const (
+	TriggerUnhealthy Trigger = "unhealthy" // health_status: unhealthy
+	TriggerCrash     Trigger = "crash"     // die + exitCode!=0 (stop/kill 요청에 의한 종료 제외)
+)
+
+// Action 정책 동작
+type Action string
+
+const (
+	ActionRestart Action = "restart"
+	ActionStop    Action = "stop"
+)
+
+const (
+	defaultWindowSec     = 600 // 발생/시도 횟수 집계 구간
+	defaultMaxAttempts   = 5   // restart : window 안 최대 시도 횟수
+	defaultBackoffSec    = 5   // restart : 첫 시도 대기 (시도마다 2배)
+	defaultMaxBackoffSec = 300
+)
+
+// Scope 정책 적용 대상 (비어있는 항목은 조건 없음)
+type Scope struct {
+	Hosts    []string `json:"hosts,omitempty"`
+	Labels   []string `json:"labels,omitempty"`   // label selector : key, key=value, key!=value, !key
+	Projects []string `json:"projects,omitempty"` // compose project
+}
+
+// Policy 자가 복구 정책 (HEAL_POLICIES)
+/*
+	[
+	  {"name":"unhealthy-restart","trigger":"unhealthy","action":"restart"},
+	  {"name":"crash-restart","trigger":"crash","action":"restart","max_attempts":5,"backoff_sec":5,"scope":{"labels":["heal=on"]}},
+	  {"name":"crashloop-stop","trigger":"crash","action":"stop","threshold":5,"window_sec":300,"dry_run":true}
+	]
+*/
+type Policy struct {
+	Name    string  `json:"name"`
+	Trigger Trigger `json:"trigger"`
+	Action  Action  `json:"action"`
+	Scope   Scope   `json:"scope"`
+
+	Threshold int `json:"threshold,omitempty"`  // window 안에 trigger 가 threshold 회 발생하면 동작 (기본 1)
+	WindowSec int `json:"window_sec,omitempty"` // 발생/시도 횟수 집계 구간 (기본 600)
+
+	MaxAttempts   int `json:"max_attempts,omitempty"`    // restart : window 안 최대 시도 횟수 (기본 5, 초과 시 포기)
+	BackoffSec    int `json:"backoff_sec,omitempty"`     // restart : 첫 시도 대기 (기본 5, 시도마다 2배)
+	MaxBackoffSec int `json:"max_backoff_sec,omitempty"` // restart : 최대 대기 (기본 300)
+
+	DryRun bool `json:"dry_run,omitempty"` // 동작하지 않고 감사 기록만 남김
+}
+
+// ParsePolicies HEAL_POLICIES JSON 파싱 (빈 문자열이면 정책 없음)
+func ParsePolicies(raw string) ([]Policy, error) {
+	if strings.TrimSpace(raw) == "" {
+		return nil, nil
+	}
+	var policies []Policy
+	if err := json.Unmarshal([]byte(raw), &policies); err != nil {
+		return nil, fmt.Errorf("parse heal policies: %w", err)
+	}
+
+	names := make(map[string]bool)
+	for i := range policies {
+		p := &policies[i]
+		if p.Name == "" || names[p.Name] {
+			return nil, fmt.Errorf("heal policy name is empty or duplicated: %q", p.Name)
+		}
+		names[p.Name] = true
+		if err := p.validate(); err != nil {
+			return nil, fmt.Errorf("heal policy %s: %w", p.Name, err)
+		}
+	}
+	return policies, nil
+}
+
+// validate 검사 및 기본값 적용
+func (p *Policy) validate() error {
+	switch p.Trigger {
+	case TriggerUnhealthy, TriggerCrash:
+	default:
+		return fmt.Errorf("invalid trigger %q", p.Trigger)
+	}
+	switch p.Action {
+	case ActionRestart, ActionStop:
+	default:
+		return fmt.Errorf("invalid action %q", p.Action)
+	}
+	if p.Threshold < 0 || p.WindowSec < 0 || p.MaxAttempts < 0 || p.BackoffSec < 0 || p.MaxBackoffSec < 0 {
+		return fmt.Errorf("values must not be negative")
+	}
+
+	if p.Threshold == 0 {
+		p.Threshold = 1
+	}
+	if p.WindowSec == 0 {
+		p.WindowSec = defaultWindowSec
+	}
+	if p.Action == ActionRestart {
+		if p.MaxAttempts == 0 {
+			p.MaxAttempts = defaultMaxAttempts
+		}
+		if p.BackoffSec == 0 {
+			p.BackoffSec = defaultBackoffSec
+		}
+		if p.MaxBackoffSec == 0 {
+			p.MaxBackoffSec = defaultMaxBackoffSec
+		}
+	}
+	_, err := p.compile()
+	return err
+}
+
+// compiledPolicy scope 필터 컴파일
+type compiledPolicy struct {
+	Policy
+	scope *docker.ContainerFilter
+}
+
+func (p Policy) compile() (*compiledPolicy, error) {
+	scope, err := docker.NewContainerFilter(docker.FilterRules{
+		IncludeLabels:   p.Scope.Labels,
+		IncludeProjects: p.Scope.Projects,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scope: %w", err)
+	}
+	return &compiledPolicy{Policy: p, scope: scope}, nil
+}
+
+// match 호스트/컨테이너 scope
+func (p *compiledPolicy) match(host, name, image string, labels map[string]string) bool {
+	if len(p.Scope.Hosts) > 0 && !docker.Contains(p.Scope.Hosts, host) {
+		return false
+	}
+	return p.scope.Match(name, image, labels)
+}
@@ -0,0 +1,247 @@
+// Package mockserver in-process ContainerService (loadtest -mock/-chaos-check, go test 공용)
+package mockserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"docker_service/pb"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// Target is the dial target used with the in-process listener.
+const Target = "passthrough:///loadtest-mock"
+
+// typeNames names the received counters (indexed by pb.DataType).
+var typeNames = [...]string{"list", "inspect", "stats", "event"}
+
+// Server is an in-process ContainerService that accepts everything and
+// acknowledges DataStream messages immediately. Clients reach it through an
+// in-memory bufconn listener, so runs need no network and measure the client
+// side (serialisation, interceptors, flow control) in isolation.
+// ServeTCP additionally exposes it on a TCP port (e.g. behind a chaos proxy).
+type Server struct {
+	pb.UnimplementedContainerServiceServer
+
+	lis     *bufconn.Listener
+	gs      *grpc.Server
+	latency time.Duration // simulated per-call processing time
+
+	tcpMu sync.Mutex
+	tcp   *grpc.Server // nil unless ServeTCP
+
+	marks sync.Map // event Attrs["n"] markers seen (chaos-check)
+
+	received [len(typeNames)]atomic.Int64 // AgentMessages per data type
+	streams  atomic.Int64
+	regs     atomic.Int64
+	hbs      atomic.Int64
+}
+
+// Stats is the server-side view, used to cross-check client metrics.
+type Stats struct {
+	Received   map[string]int64 `json:"received"`
+	Total      int64            `json:"total"`
+	Streams    int64            `json:"streams"`
+	Registers  int64            `json:"registers"`
+	Heartbeats int64            `json:"heartbeats"`
+}
+
+// New starts the mock on an in-memory listener (latency : simulated per-call processing time).
+func New(latency time.Duration) *Server {
+	s := &Server{
+		lis:     bufconn.Listen(4 << 20),
+		gs:      grpc.NewServer(),
+		latency: latency,
+	}
+	pb.RegisterContainerServiceServer(s.gs, s)
+	go s.gs.Serve(s.lis)
+	return s
+}
+
+// Dialer returns the dial function for gapi.WithDialer.
+func (s *Server) Dialer() func(context.Context, string) (net.Conn, error) {
+	return func(ctx context.Context, _ string) (net.Conn, error) {
+		return s.lis.DialContext(ctx)
+	}
+}
+
+// ServeTCP serves the same mock on a TCP address ("127.0.0.1:0" picks a port)
+// and returns the bound address. Client keepalive pings down to 1s are allowed.
+func (s *Server) ServeTCP(addr string) (string, error) {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return "", err
+	}
+	gs := grpc.NewServer(grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+		MinTime:             time.Second,
+		PermitWithoutStream: true,
+	}))
+	pb.RegisterContainerServiceServer(gs, s)
+
+	s.tcpMu.Lock()
+	s.tcp = gs
+	s.tcpMu.Unlock()
+	go gs.Serve(lis)
+	return lis.Addr().String(), nil
+}
+
+// StopTCP stops the TCP server only (simulates a collector outage); call
+// ServeTCP with the same address to bring it back.
+func (s *Server) StopTCP() {
+	s.tcpMu.Lock()
+	gs := s.tcp
+	s.tcp = nil
+	s.tcpMu.Unlock()
+	if gs != nil {
+		gs.Stop()
+	}
+}
+
+func (s *Server) Stop() {
+	s.StopTCP()
+	s.gs.Stop()
+}
+
+// HasMark reports whether an event carrying Attrs["n"] == n was received.
+func (s *Server) HasMark(n string) bool {
+	_, ok := s.marks.Load(n)
+	return ok
+}
+
+func (s *Server) Stats() Stats {
+	st := Stats{
+		Received:   make(map[string]int64),
+		Streams:    s.streams.Load(),
+		Registers:  s.regs.Load(),
+		Heartbeats: s.hbs.Load(),
+	}
+	for i := range typeNames {
+		if n := s.received[i].Load(); n > 0 {
+			st.Received[typeNames[i]] = n
+			st.Total += n
+		}
+	}
+	return st
+}
+
+// String prints the mock server cross-check line.
+func (s Stats) String() string {
+	keys := make([]string, 0, len(s.Received))
+	for k := range s.Received {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	out := fmt.Sprintf("[MOCK SERVER] received=%d streams=%d registers=%d heartbeats=%d", s.Total, s.Streams, s.Registers, s.Heartbeats)
+	for _, k := range keys {
+		out += fmt.Sprintf(" %s=%d", k, s.Received[k])
+	}
+	return out + "\n"
+}
+
+func (s *Server) count(msg *pb.AgentMessage) {
+	if t := int(msg.GetType()); t >= 0 && t < len(typeNames) {
+		s.received[t].Add(1)
+	}
+	if msg.GetType() == pb.DataType_CONTAINER_EVENT {
+		if n := msg.GetEventData().GetAttrs()["n"]; n != "" {
+			s.marks.Store(n, struct{}{})
+		}
+	}
+}
+
+func (s *Server) work() {
+	if s.latency > 0 {
+		time.Sleep(s.latency)
+	}
+}
+
+func (s *Server) handle(_ context.Context, req *pb.AgentMessage) (*pb.ServerMessage, error) {
+	s.work()
+	s.count(req)
+	return &pb.ServerMessage{Command: pb.CommandType_ACK}, nil
+}
+
+func (s *Server) ContainerState(ctx context.Context, req *pb.AgentMessage) (*pb.ServerMessage, error) {
+	return s.handle(ctx, req)
+}
+
+func (s *Server) ContainerInfo(ctx context.Context, req *pb.AgentMessage) (*pb.ServerMessage, error) {
+	return s.handle(ctx, req)
+}
+
+func (s *Server) ContainerInspect(ctx context.Context, req *pb.AgentMessage) (*pb.ServerMessage, error) {
+	return s.handle(ctx, req)
+}
+
+func (s *Server) ContainerStats(ctx context.Context, req *pb.AgentMessage) (*pb.ServerMessage, error) {
+	return s.handle(ctx, req)
+}
+
+func (s *Server) ContainerEvent(ctx context.Context, req *pb.AgentMessage) (*pb.ServerMessage, error) {
+	return s.handle(ctx, req)
+}
+
+func (s *Server) ContainerBatch(_ context.Context, req *pb.AgentMessageBatch) (*pb.ServerMessage, error) {
+	s.work()
+	for _, m := range req.GetMessages() {
+		s.count(m)
+	}
+	return &pb.ServerMessage{Command: pb.CommandType_ACK}, nil
+}
+
+func (s *Server) DataStream(stream pb.ContainerService_DataStreamServer) error {
+	s.streams.Add(1)
+	for {
+		msg, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		s.work()
+		s.count(msg)
+		if msg.GetSeq() > 0 {
+			if err := stream.Send(&pb.ServerMessage{Command: pb.CommandType_ACK, AckSeq: msg.GetSeq()}); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (s *Server) ConnMessage(stream pb.ContainerService_ConnMessageServer) error {
+	for {
+		msg, err := stream.Recv()
+		if err != nil {
+			return nil
+		}
+		if err := stream.Send(msg); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *Server) Register(_ context.Context, req *pb.RegisterRequest) (*pb.RegisterResponse, error) {
+	s.regs.Add(1)
+	return &pb.RegisterResponse{Success: true, Agentid: req.GetAgentid()}, nil
+}
+
+func (s *Server) Heartbeat(context.Context, *pb.HeartbeatRequest) (*pb.HeartbeatResponse, error) {
+	s.hbs.Add(1)
+	return &pb.HeartbeatResponse{Success: true, ServerTime: time.Now().UnixMilli()}, nil
+}
+
+func (s *Server) ReportCommand(context.Context, *pb.CommandResult) (*pb.ServerMessage, error) {
+	return &pb.ServerMessage{Command: pb.CommandType_ACK}, nil
+}
@@ -25,19 +25,24 @@ type Server struct {
 	// sendCh  chan<- pipeline.Message // write only
 	sendCh   chan pipeline.Message
 	eventMgr *evt.EventManager
+	queue    *pipeline.MultiQueue // 타입별 우선순위 큐 (sendCh 앞단)
+	pumpDone chan struct{}
+	observer func(pipeline.Message) // 수집 메시지 관찰자 (알림 엔진 stats 입력), nil : 없음
 }
 
 // Config Pipeline 서버 설정
 type Config struct {
-	IntervalSec int // 수집 주기 (초)
-	BufferSize  int // 채널 버퍼 크기
+	BufferSize int                      // 수집기 통합 채널 버퍼 크기
+	Collectors collector.PipelineConfig // 수집기 타입/호스트별 설정
+	Queue      pipeline.QueueConfig     // 전송 큐 설정
 }
 
 // DefaultConfig 기본 설정
 func DefaultConfig() Config {
 	return Config{
-		IntervalSec: 30,
-		BufferSize:  50,
+		BufferSize: 100,
+		Collectors: collector.DefaultPipelineConfig(),
+		Queue:      pipeline.DefaultQueueConfig(),
 	}
 }
 
@@ -52,20 +57,13 @@ func NewServer(wg *sync.WaitGroup,
 	ctx, cancel := context.WithCancel(context.Background())
 
 	// Manager 생성
-	manager := collector.NewManager(dockerMng, 100)
+	manager := collector.NewManager(dockerMng, cfg.BufferSize)
 
-	// Collector 설정
-	collectorCfg := collector.Config{
-		IntervalSec: cfg.IntervalSec,
-		BufferSize:  cfg.BufferSize,
-	}
-
-	// 모든 호스트에 Collector 등록
-	if err := manager.RegisterAllHosts(
-		[]collector.CollectorType{collector.TypeList, collector.TypeInspect, collector.TypeStats},
-		collectorCfg,
-	); err != nil {
+	// 모든 호스트에 Collector 등록 (타입/호스트별 설정 적용)
+	if err := manager.RegisterWithSettings(cfg.Collectors); err != nil {
 		logger.Log.Error("[PipeServer] collector registration fail: %v", err)
+		cancel()
+		return nil, err
 	}
 
 	return &Server{
@@ -77,11 +75,19 @@ func NewServer(wg *sync.WaitGroup,
 		config2:  config,
 		sendCh:   pipeCh,
 		eventMgr: eventMgr,
+		queue:    pipeline.NewMultiQueue(cfg.Queue),
+		pumpDone: make(chan struct{}),
 	}, nil
 }
 
 // Start Pipeline 서버 시작
 func (s *Server) Start() error {
+	// 큐 스케줄러 -> sendCh
+	go func() {
+		defer close(s.pumpDone)
+		s.queue.Run(s.ctx, s.sendCh)
+	}()
+
 	outCh, err := s.manager.Start(s.ctx)
 	if err != nil {
 		logger.Log.Error("[PipeServer] start fail: %v", err)
@@ -106,16 +112,75 @@ func (s *Server) Shutdown() error {
 
 	s.cancel()
 	s.manager.Stop()
+	<-s.pumpDone // sendCh close 전에 스케줄러 종료 대기
 
 	logger.Log.Print(3, "[PipeServer] shutdown complete")
 	return nil
 }
 
+// SetObserver 수집 메시지 관찰자 등록 (Start 전에 호출, fn 은 blocking 하지 않아야 함)
+func (s *Server) SetObserver(fn func(pipeline.Message)) {
+	s.observer = fn
+}
+
 // GetCollectorCount 등록된 Collector 수 반환
 func (s *Server) GetCollectorCount() int {
 	return s.manager.GetCollectorCount()
 }
 
+// CollectorSettings 현재 수집기 설정 반환
+func (s *Server) CollectorSettings() collector.PipelineConfig {
+	return s.manager.Settings()
+}
+
+// EffectiveCollectorConfig 호스트/타입별 실제 적용 설정 반환
+func (s *Server) EffectiveCollectorConfig() map[string]map[collector.CollectorType]collector.Config {
+	return s.manager.EffectiveConfig()
+}
+
+// UpdateCollectorSettings 수집기 설정 변경 (변경된 수집기만 재시작)
+func (s *Server) UpdateCollectorSettings(settings collector.PipelineConfig) error {
+	if err := s.manager.Reconfigure(settings); err != nil {
+		logger.Log.Error("[PipeServer] collector reconfigure fail: %v", err)
+		return err
+	}
+	logger.Log.Print(3, "[PipeServer] collector settings updated, collectors: %d", s.manager.GetCollectorCount())
+	return nil
+}
+
+// CollectNow 호스트 수집기에 즉시 수집 요청 (types 생략 시 전체)
+func (s *Server) CollectNow(host string, types []collector.CollectorType) (int, error) {
+	return s.manager.CollectNow(host, types)
+}
+
+// QueueStats 타입별 전송 큐 통계 (drop/coalesce/sample 카운터 포함)
+func (s *Server) QueueStats() map[pipeline.DataType]pipeline.QueueStats {
+	return s.queue.Stats()
+}
+
+// Requeue gRPC 전송 실패 메시지를 전송 큐에 다시 적재
+func (s *Server) Requeue(msgs []pipeline.Message) {
+	s.queue.Requeue(msgs)
+}
+
+// Status pipeline 실행 상태
+type Status struct {
+	Collectors []collector.Status                        `json:"collectors"`
+	SendChLen  int                                       `json:"send_ch_len"`
+	SendChCap  int                                       `json:"send_ch_cap"`
+	Queues     map[pipeline.DataType]pipeline.QueueStats `json:"queues"`
+}
+
+// Status 수집기/전송 큐 상태
+func (s *Server) Status() Status {
+	return Status{
+		Collectors: s.manager.Status(),
+		SendChLen:  len(s.sendCh),
+		SendChCap:  cap(s.sendCh),
+		Queues:     s.queue.Stats(),
+	}
+}
+
 // processMessages 수집된 메시지 처리
 func (s *Server) processMessages(outCh <-chan pipeline.Message) {
 	for msg := range outCh {
@@ -133,34 +198,12 @@ func (s *Server) handleMessage(msg pipeline.Message) {
 	logger.Log.Print(3, "[PipeServer] agentid : %d type=%s host=%s timestamp=%v",
 		msg.AgentId, msg.Type, msg.Host, msg.Timestamp)
 
-	// switch msg.Type {
-	// case pipeline.DataTypeList:
-	// 	s.handleListMessage(msg)
-	// case pipeline.DataTypeInspect:
-	// 	s.handleInspectMessage(msg)
-	// }
-	select {
-	case s.sendCh <- msg:
-		logger.Log.Print(2, "collect msg : [%s]", msg.Type)
-	case <-s.ctx.Done():
-	default:
-		logger.Log.Warn("[PipeServer] sendCh full, drop msg")
-		// 버퍼 full - 오래된 데이터 제거 후 추가
-		select {
-		case dropped := <-s.sendCh:
-			logger.Log.Print(1, "[PipeServer] sendCh full, dropped old message: type=%s host=%s",
-				dropped.Type, dropped.Host)
-		default:
-
-		}
-
-		// 새 메시지 추가
-		select {
-		case s.sendCh <- msg:
-		default:
-			logger.Log.Error("[PipeServer] failed to send message after drop")
-		}
+	if s.observer != nil {
+		s.observer(msg)
 	}
+
+	// 타입별 큐에 적재 (event 무손실, list/inspect 최신값 유지, stats 샘플링)
+	s.queue.Push(msg)
 }
 
 // handleListMessage Container List 메시지 처리
@@ -206,7 +249,11 @@ func (s *Server) handleInspectMessage(msg pipeline.Message) {
 // Pipe로 이벤트 전달하는 브릿지
 func (server *Server) bridgeEventsToPipe() {
 	logger.Log.Print(2, "bridgeEventsToPipe start..")
-	sub := server.eventMgr.Subscribe("pipe-bridge", 100, nil)
+	// agent 자체 이벤트(upstream up/down 등)는 수집 서버로 보내지 않는다 (stream_gap 은 전송)
+	// 수집 서버로 넘길 이벤트이므로 버퍼가 가득 차도 버리지 않는 spill 구독
+	sub := server.eventMgr.SubscribeSpill("pipe-bridge", 100, func(e event2.ContainerEvent) bool {
+		return e.IsUpstreamEvent()
+	})
 	defer server.eventMgr.Unsubscribe("pipe-bridge")
 
 	for {
@@ -232,6 +279,16 @@ func (server *Server) bridgeEventsToPipe() {
 			// convert  pipeline.Message
 			msg := ToContainerEventPipeMessage(evt)
 			server.handleMessage(msg)
+
+			// 상태 변경 이벤트 -> 해당 컨테이너 재inspect / 호스트 list 갱신
+			server.manager.HandleEvent(evt.Host, evt.Type, evt.Action, evt.ActorID)
+
+			// 끊긴 구간의 이벤트를 알 수 없으므로 목록/상세를 다시 수집해 재동기화
+			if evt.Type == event2.TypeStream && evt.Action == event2.ActionStreamGap {
+				if _, err := server.manager.CollectNow(evt.Host, []collector.CollectorType{collector.TypeList, collector.TypeInspect}); err != nil {
+					logger.Log.Warn("[pipe] %s resync after stream gap failed: %v", evt.Host, err)
+				}
+			}
 		}
 	}
 }
@@ -0,0 +1,89 @@
+package gapi
+
+import (
+	"sort"
+	"sync"
+
+	"docker_service/pb"
+)
+
+// maxInflight ACK 대기 메시지 최대 수 (초과 시 unary로 전송)
+const maxInflight = 1000
+
+// inflightWindow DataStream으로 전송 후 ACK를 기다리는 메시지 목록
+// 서버는 ServerMessage{command: ACK, ack_seq: N} 으로 N 이하의 메시지를 누적 확인한다.
+// N 은 서버가 연속으로 수신한 마지막 seq 이므로 스트림에는 seq 순서대로 보내야 한다. (openStream, sendStream)
+type inflightWindow struct {
+	mu          sync.Mutex
+	nextSeq     uint64
+	msgs        map[uint64]*pb.AgentMessage
+	acked       uint64
+	retransmits uint64
+}
+
+func newInflightWindow() *inflightWindow {
+	return &inflightWindow{msgs: make(map[uint64]*pb.AgentMessage)}
+}
+
+// add 순번을 부여하고 ACK 대기 목록에 추가 (가득 차면 false)
+func (w *inflightWindow) add(m *pb.AgentMessage) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if len(w.msgs) >= maxInflight {
+		return false
+	}
+	w.nextSeq++
+	m.Seq = w.nextSeq
+	w.msgs[m.Seq] = m
+	return true
+}
+
+// ack seq 이하 메시지 확인 처리, 확인된 수 반환
+func (w *inflightWindow) ack(seq uint64) int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	n := 0
+	for s := range w.msgs {
+		if s <= seq {
+			delete(w.msgs, s)
+			n++
+		}
+	}
+	w.acked += uint64(n)
+	return n
+}
+
+// remove 개별 메시지 제거 (unary 전송 완료 등)
+func (w *inflightWindow) remove(seq uint64) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	delete(w.msgs, seq)
+}
+
+// pending ACK 대기 메시지 (seq 순)
+func (w *inflightWindow) pending() []*pb.AgentMessage {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	list := make([]*pb.AgentMessage, 0, len(w.msgs))
+	for _, m := range w.msgs {
+		list = append(list, m)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Seq < list[j].Seq })
+	return list
+}
+
+func (w *inflightWindow) addRetransmits(n int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.retransmits += uint64(n)
+}
+
+// counters (inflight, acked, retransmits)
+func (w *inflightWindow) counters() (int, uint64, uint64) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return len(w.msgs), w.acked, w.retransmits
+}
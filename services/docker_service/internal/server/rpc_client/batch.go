@@ -0,0 +1,122 @@
+package gapi
+
+import (
+	"context"
+	"time"
+
+	"docker_service/internal/logger"
+	"docker_service/internal/pipeline"
+	"docker_service/pb"
+)
+
+const (
+	defaultBatchFlush = 500 * time.Millisecond
+	batchRetryDelay   = 3 * time.Second // 전송 실패 후 다음 배치까지 대기 (재적재 메시지 반복 실패 방지)
+)
+
+// txBatchRoutine: pipeCh 메시지를 모아서 ContainerBatch RPC로 전송
+// batchSize 개가 쌓이거나 batchFlush 주기가 지나면 flush 한다.
+// 전송에 실패한 배치는 pipeline 큐에 다시 적재하고 batchRetryDelay 동안 pipeCh 를 읽지 않는다.
+func (c *GrpcClient) txBatchRoutine(ctx context.Context) {
+	flushInterval := c.batchFlush
+	if flushInterval <= 0 {
+		flushInterval = defaultBatchFlush
+	}
+
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]*pb.AgentMessage, 0, c.batchSize)
+	msgs := make([]pipeline.Message, 0, c.batchSize) // 재적재용 원본
+	var hold <-chan time.Time                        // nil : 전송 가능
+
+	flush := func() {
+		if err := c.flushBatch(batch); err != nil {
+			c.requeueBatch(msgs)
+			hold = time.After(batchRetryDelay)
+		}
+		batch = make([]*pb.AgentMessage, 0, c.batchSize)
+		msgs = make([]pipeline.Message, 0, c.batchSize)
+	}
+
+	for {
+		// pause 중이거나 전송 실패 후 대기 중에는 pipeCh 를 읽지 않는다 (pipeline 큐에 쌓임)
+		in := c.pipeCh
+		paused, wake := c.pauseState()
+		if paused || hold != nil {
+			in = nil
+		}
+
+		select {
+		case <-ctx.Done():
+			logger.Log.Print(1, "[txBatchRoutine] exiting (pending %d)", len(batch))
+			c.requeueBatch(msgs)
+			c.closeSend()
+			return
+
+		case <-wake:
+			continue
+
+		case <-hold:
+			hold = nil
+
+		case msg, ok := <-in:
+			if !ok {
+				logger.Log.Warn("[txBatchRoutine] pipeCh closed, initiating shutdown")
+				if err := c.flushBatch(batch); err != nil {
+					c.stats.recordDrops(len(batch))
+					logger.Log.Error("[txBatchRoutine] %d messages dropped on shutdown", len(batch))
+				}
+				c.cancel()
+				return
+			}
+
+			pbMsg, err := c.toAgentMessage(msg)
+			if err != nil {
+				c.stats.recordConvertError(err)
+				logger.Log.Error("[txBatchRoutine] convert failed: %v", err)
+				continue
+			}
+			batch = append(batch, pbMsg)
+			msgs = append(msgs, msg)
+
+			if len(batch) >= c.batchSize {
+				flush()
+			}
+
+		case <-ticker.C:
+			if len(batch) > 0 {
+				flush()
+			}
+		}
+	}
+}
+
+// flushBatch: 모인 메시지를 한 번의 RPC로 전송
+func (c *GrpcClient) flushBatch(batch []*pb.AgentMessage) error {
+	if len(batch) == 0 {
+		return nil
+	}
+
+	_, err := c.ContainerBatch(&pb.AgentMessageBatch{Messages: batch})
+	c.stats.recordSend(len(batch), err)
+	if err != nil {
+		logger.Log.Error("[flushBatch] ContainerBatch error (%d msgs): %v", len(batch), err)
+	}
+	return err
+}
+
+// requeueBatch: 전송하지 못한 메시지를 pipeline 큐 앞쪽에 다시 적재
+// pipeline 서버가 없으면 버리고 dropped 로 집계한다. (큐 한도 초과분은 큐 통계의 dropped)
+func (c *GrpcClient) requeueBatch(msgs []pipeline.Message) {
+	if len(msgs) == 0 {
+		return
+	}
+	if c.pipesvr == nil {
+		c.stats.recordDrops(len(msgs))
+		logger.Log.Error("[txBatchRoutine] %d messages dropped (no pipeline queue)", len(msgs))
+		return
+	}
+	c.pipesvr.Requeue(msgs)
+	logger.Log.Warn("[txBatchRoutine] %d messages requeued", len(msgs))
+}
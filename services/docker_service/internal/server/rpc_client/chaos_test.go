@@ -0,0 +1,207 @@
+package gapi
+
+import (
+	"runtime"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gdygd/goglib/databus"
+
+	"docker_service/internal/chaos"
+	"docker_service/internal/container"
+	"docker_service/internal/pipeline"
+	"docker_service/internal/server/mockserver"
+)
+
+// chaosHarness GrpcClient -> chaos.Proxy -> mockserver(TCP)
+type chaosHarness struct {
+	t       *testing.T
+	mock    *mockserver.Server
+	srvAddr string
+	proxy   *chaos.Proxy
+	bus     *databus.DataBus
+	client  *GrpcClient
+	wg      sync.WaitGroup
+	pipeCh  chan pipeline.Message
+	sent    int // 전송한 이벤트 수 (marker 1..sent)
+}
+
+func newChaosHarness(t *testing.T) *chaosHarness {
+	t.Helper()
+	h := &chaosHarness{
+		t:      t,
+		mock:   mockserver.New(0),
+		bus:    databus.NewDataBus(),
+		pipeCh: make(chan pipeline.Message, 100),
+	}
+	addr, err := h.mock.ServeTCP("127.0.0.1:0")
+	if err != nil {
+		h.mock.Stop()
+		t.Fatal(err)
+	}
+	h.srvAddr = addr
+
+	h.proxy, err = chaos.NewProxy("127.0.0.1:0", addr, chaos.Config{})
+	if err != nil {
+		h.mock.Stop()
+		t.Fatal(err)
+	}
+
+	h.wg.Add(1)
+	h.client, err = NewClient(&h.wg, &container.Container{Bus: h.bus}, h.pipeCh, h.proxy.Addr(), "chaos-test",
+		WithKeepalive(time.Second, time.Second))
+	if err != nil {
+		h.proxy.Close()
+		h.mock.Stop()
+		h.bus.ShutDown()
+		t.Fatal(err)
+	}
+	go h.client.Start()
+	return h
+}
+
+// close 클라이언트 종료 대기 후 프록시/서버 종료
+func (h *chaosHarness) close() {
+	h.client.Shutdown()
+	h.wg.Wait()
+	h.proxy.Close()
+	h.mock.Stop()
+	h.bus.ShutDown()
+}
+
+// sendEvent marker 가 붙은 이벤트 1건 전송
+func (h *chaosHarness) sendEvent() {
+	h.sent++
+	n := strconv.Itoa(h.sent)
+	h.pipeCh <- pipeline.Message{
+		AgentId:   1,
+		Type:      pipeline.DataTypeEvent,
+		Host:      "chaos-host",
+		Timestamp: time.Now(),
+		Data: pipeline.ContainerEvent{
+			Host:      "chaos-host",
+			Type:      "container",
+			Action:    "start",
+			ActorID:   "chaos-" + n,
+			Timestamp: time.Now().UnixNano(),
+			Attrs:     map[string]string{"n": n},
+		},
+	}
+}
+
+// missing 서버에 도착하지 않은 marker 수
+func (h *chaosHarness) missing() int {
+	n := 0
+	for i := 1; i <= h.sent; i++ {
+		if !h.mock.HasMark(strconv.Itoa(i)) {
+			n++
+		}
+	}
+	return n
+}
+
+// waitFor cond 가 true 가 될 때까지 대기, 걸린 시간 반환
+func waitFor(timeout time.Duration, cond func() bool) (time.Duration, bool) {
+	start := time.Now()
+	for time.Since(start) < timeout {
+		if cond() {
+			return time.Since(start), true
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	return time.Since(start), cond()
+}
+
+func TestClientChaosRecovery(t *testing.T) {
+	if testing.Short() {
+		t.Skip("chaos recovery takes several seconds per case")
+	}
+	base := runtime.NumGoroutine()
+
+	tests := []struct {
+		name    string
+		cfg     chaos.Config
+		hold    time.Duration // 장애 유지 시간 (이 동안 이벤트 전송)
+		recover time.Duration // 장애 해제 후 재연결 제한 시간
+		inject  func(h *chaosHarness)
+		restore func(h *chaosHarness) error
+	}{
+		{name: "latency+jitter", cfg: chaos.Config{Latency: 100 * time.Millisecond, Jitter: 50 * time.Millisecond},
+			hold: time.Second, recover: 5 * time.Second},
+		{name: "drop 20%", cfg: chaos.Config{DropRate: 0.2}, hold: time.Second, recover: 5 * time.Second},
+		{name: "periodic reset", cfg: chaos.Config{ResetEvery: 700 * time.Millisecond}, hold: 2 * time.Second, recover: 15 * time.Second},
+		{name: "reset all", hold: time.Second, recover: 15 * time.Second,
+			inject: func(h *chaosHarness) { h.proxy.ResetAll() }},
+		{name: "half-open", cfg: chaos.Config{HalfOpen: true}, hold: 3 * time.Second, recover: 30 * time.Second},
+		{name: "server restart", hold: 2 * time.Second, recover: 30 * time.Second,
+			inject: func(h *chaosHarness) { h.mock.StopTCP() },
+			restore: func(h *chaosHarness) error {
+				_, err := h.mock.ServeTCP(h.srvAddr)
+				return err
+			}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newChaosHarness(t)
+			defer h.close()
+
+			if _, ok := waitFor(10*time.Second, func() bool { return h.client.Status().StreamActive }); !ok {
+				t.Fatal("stream not established before fault")
+			}
+			h.sendEvent()
+
+			h.proxy.SetConfig(tt.cfg)
+			if tt.inject != nil {
+				tt.inject(h)
+			}
+			for deadline := time.Now().Add(tt.hold); time.Now().Before(deadline); {
+				h.sendEvent()
+				time.Sleep(100 * time.Millisecond)
+			}
+			h.proxy.SetConfig(chaos.Config{})
+			if tt.restore != nil {
+				if err := tt.restore(h); err != nil {
+					t.Fatalf("restore: %v", err)
+				}
+			}
+
+			// 장애 해제 후 보낸 이벤트가 스트림으로 도착하면 복구로 판단
+			h.sendEvent()
+			marker := strconv.Itoa(h.sent)
+			took, ok := waitFor(tt.recover, func() bool {
+				return h.client.Status().StreamActive && h.mock.HasMark(marker)
+			})
+			if !ok {
+				t.Fatalf("not recovered within %v", tt.recover)
+			}
+			t.Logf("recovered in %v, events=%d resets=%d retransmits=%d",
+				took.Round(time.Millisecond), h.sent, h.client.Status().StreamResets, h.client.Status().Retransmits)
+
+			// ACK 대기 없이 모든 이벤트가 서버에 도착 (중복 허용)
+			if _, ok := waitFor(30*time.Second, func() bool {
+				return h.missing() == 0 && h.client.Status().Inflight == 0
+			}); !ok {
+				t.Fatalf("data loss: %d/%d events missing, inflight=%d", h.missing(), h.sent, h.client.Status().Inflight)
+			}
+			st := h.client.Status()
+			if st.Dropped > 0 {
+				t.Fatalf("client dropped %d messages", st.Dropped)
+			}
+			if received := h.mock.Stats().Received["event"]; int64(st.Acked) > received {
+				t.Fatalf("acked %d > received %d", st.Acked, received)
+			}
+		})
+	}
+
+	// 모든 클라이언트/프록시/서버 종료 후 goroutine 수가 시작 전 수준으로 복귀
+	var now int
+	if _, ok := waitFor(10*time.Second, func() bool {
+		now = runtime.NumGoroutine()
+		return now <= base+2
+	}); !ok {
+		buf := make([]byte, 1<<20)
+		t.Fatalf("goroutine leak: before=%d after=%d\n%s", base, now, buf[:runtime.Stack(buf, true)])
+	}
+}
@@ -4,18 +4,27 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"docker_service/internal/container"
 	"docker_service/internal/logger"
 	"docker_service/internal/pipeline"
+	"docker_service/internal/server/pipe"
 	"docker_service/pb"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
+	_ "google.golang.org/grpc/encoding/gzip" // gzip compressor 등록
+	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 // ClientOption is a functional option for GrpcClient.
@@ -28,6 +37,61 @@ func WithUnaryInterceptor(i grpc.UnaryClientInterceptor) ClientOption {
 	}
 }
 
+// WithStreamInterceptor attaches an additional stream interceptor (e.g. for DataStream metrics).
+func WithStreamInterceptor(i grpc.StreamClientInterceptor) ClientOption {
+	return func(c *GrpcClient) {
+		c.extraStreamInterceptor = i
+	}
+}
+
+// WithUnaryOnly sends every data type with unary RPCs instead of DataStream.
+// The stream is still opened to receive server commands.
+func WithUnaryOnly() ClientOption {
+	return func(c *GrpcClient) {
+		c.unaryOnly = true
+	}
+}
+
+// WithDialer overrides how connections are made (e.g. in-process bufconn listener).
+func WithDialer(dialer func(context.Context, string) (net.Conn, error)) ClientOption {
+	return func(c *GrpcClient) {
+		c.dialer = dialer
+	}
+}
+
+// WithKeepalive sends HTTP/2 pings every interval and drops the connection when
+// no ack arrives within timeout (0 = interval), so half-open connections are
+// detected. The server's keepalive enforcement policy must allow the interval.
+func WithKeepalive(interval, timeout time.Duration) ClientOption {
+	return func(c *GrpcClient) {
+		if timeout <= 0 {
+			timeout = interval
+		}
+		c.keepalive = &keepalive.ClientParameters{
+			Time:                interval,
+			Timeout:             timeout,
+			PermitWithoutStream: true,
+		}
+	}
+}
+
+// WithBatch enables batching: messages are flushed via ContainerBatch when
+// maxSize messages are queued or flushInterval elapses. maxSize <= 1 disables batching.
+func WithBatch(maxSize int, flushInterval time.Duration) ClientOption {
+	return func(c *GrpcClient) {
+		c.batchSize = maxSize
+		c.batchFlush = flushInterval
+	}
+}
+
+// WithCompression sets the compressor (e.g. "gzip") used for calls on the connection.
+// If the server rejects it (codes.Unimplemented), the client falls back to identity.
+func WithCompression(name string) ClientOption {
+	return func(c *GrpcClient) {
+		c.compression = name
+	}
+}
+
 type GrpcClient struct {
 	wg     *sync.WaitGroup
 	conn   *grpc.ClientConn
@@ -41,6 +105,42 @@ type GrpcClient struct {
 	ct               *container.Container
 	pipeCh           <-chan pipeline.Message
 	extraInterceptor grpc.UnaryClientInterceptor
+
+	extraStreamInterceptor grpc.StreamClientInterceptor
+	dialer                 func(context.Context, string) (net.Conn, error) // nil : 기본 TCP
+	unaryOnly              bool                                            // DataStream 으로 데이터 전송 안 함
+	keepalive              *keepalive.ClientParameters                     // nil : keepalive ping 미사용
+
+	batchSize   int           // 배치 최대 메시지 수 (<= 1 : 배치 미사용)
+	batchFlush  time.Duration // 배치 flush 주기
+	compression string        // 압축 방식 ("" : 미사용, "gzip")
+	compressOff atomic.Bool   // 서버가 압축을 거부해 identity 로 전환됨
+
+	tlsCfg   *TLSConfig    // nil : 평문 연결
+	reloader *certReloader // TLS 인증서 재로딩
+
+	stats sendStats // 전송 통계
+
+	inflight *inflightWindow // DataStream ACK 대기 메시지
+	sendMu   sync.Mutex      // stream.Send 직렬화 (tx / 재전송)
+
+	pipesvr  *pipe.Server     // Register(지원 타입, 서버 설정 반영), Heartbeat(큐 적체량)
+	commands []pb.CommandType // Register 시 보고할 허용 원격 명령
+	reg      registration     // Register/Heartbeat 상태
+
+	cred credentialState // Enroll 로 발급받은 자격 증명 (상태 파일)
+
+	upstream upstreamState // pause/재연결 제어, 연결 이력
+}
+
+// registration Register/Heartbeat 상태
+type registration struct {
+	mu                sync.Mutex
+	registered        bool
+	registeredAt      time.Time
+	lastHeartbeat     time.Time
+	heartbeatInterval time.Duration // 서버 지정 주기 (0 : 기본값)
+	appliedSettings   string        // 마지막으로 적용한 서버 수집기 설정
 }
 
 func NewClient(wg *sync.WaitGroup, ct *container.Container, pipeCh <-chan pipeline.Message, addr string, agentKey string, opts ...ClientOption) (*GrpcClient, error) {
@@ -53,11 +153,26 @@ func NewClient(wg *sync.WaitGroup, ct *container.Container, pipeCh <-chan pipeli
 		pipeCh:   pipeCh,
 		addr:     addr,
 		agentKey: agentKey,
+		inflight: newInflightWindow(),
 	}
 	for _, opt := range opts {
 		opt(c)
 	}
 
+	if c.tlsCfg != nil {
+		reloader, err := newCertReloader(*c.tlsCfg, c.addr)
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+		c.reloader = reloader
+	}
+
+	if err := c.loadCredential(); err != nil {
+		cancel()
+		return nil, err
+	}
+
 	if err := c.connect(); err != nil {
 		cancel()
 		return nil, err
@@ -66,21 +181,48 @@ func NewClient(wg *sync.WaitGroup, ct *container.Container, pipeCh <-chan pipeli
 	return c, nil
 }
 
+// SetPipeServer Register/Heartbeat 에 사용할 pipeline 서버 연결 (Start 전에 호출)
+func (c *GrpcClient) SetPipeServer(p *pipe.Server) {
+	c.pipesvr = p
+}
+
+// SetCommands Register 시 보고할 허용 원격 명령 설정 (Start 전에 호출)
+func (c *GrpcClient) SetCommands(cmds []pb.CommandType) {
+	c.commands = cmds
+}
+
 func (c *GrpcClient) connect() error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	unaryInterceptors := []grpc.UnaryClientInterceptor{c.agentKeyUnaryInterceptor()}
+	unaryInterceptors := []grpc.UnaryClientInterceptor{c.compressionUnaryInterceptor(), c.agentKeyUnaryInterceptor()}
 	if c.extraInterceptor != nil {
 		unaryInterceptors = append(unaryInterceptors, c.extraInterceptor)
 	}
 
-	conn, err := grpc.NewClient(
-		c.addr,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	creds := insecure.NewCredentials()
+	if c.reloader != nil {
+		creds = credentials.NewTLS(c.reloader.tlsConfig())
+	}
+
+	streamInterceptors := []grpc.StreamClientInterceptor{c.compressionStreamInterceptor(), c.agentKeyStreamInterceptor()}
+	if c.extraStreamInterceptor != nil {
+		streamInterceptors = append(streamInterceptors, c.extraStreamInterceptor)
+	}
+
+	dialOpts := []grpc.DialOption{
+		grpc.WithTransportCredentials(creds),
 		grpc.WithChainUnaryInterceptor(unaryInterceptors...),
-		grpc.WithStreamInterceptor(c.agentKeyStreamInterceptor()),
-	)
+		grpc.WithChainStreamInterceptor(streamInterceptors...),
+	}
+	if c.dialer != nil {
+		dialOpts = append(dialOpts, grpc.WithContextDialer(c.dialer))
+	}
+	if c.keepalive != nil {
+		dialOpts = append(dialOpts, grpc.WithKeepaliveParams(*c.keepalive))
+	}
+
+	conn, err := grpc.NewClient(c.addr, dialOpts...)
 	if err != nil {
 		return fmt.Errorf("grpc.NewClient(%s): %w", c.addr, err)
 	}
@@ -89,19 +231,73 @@ func (c *GrpcClient) connect() error {
 		_ = c.conn.Close()
 	}
 	c.conn = conn
+	c.compressOff.Store(false) // 새 연결 (서버 교체/업그레이드 가능) : 압축 재시도
 	return nil
 }
 
+// compressing 압축 사용 여부 (설정되어 있고 서버가 거부하지 않은 경우)
+func (c *GrpcClient) compressing() bool {
+	return c.compression != "" && !c.compressOff.Load()
+}
+
+// compressionRejected 압축을 사용한 호출이 서버의 압축 해제기 부재로 실패하면 identity 로 전환 (gzip 미등록 서버)
+// 다른 Unimplemented (미구현 RPC 등) 는 압축과 무관하므로 전환하지 않는다.
+// 이후 호출과 새 스트림은 압축하지 않으며, 새 연결(connect) 에서 다시 압축을 시도한다.
+func (c *GrpcClient) compressionRejected(compressed bool, err error) bool {
+	if !compressed || !decompressorMissing(err) {
+		return false
+	}
+	if c.compressOff.CompareAndSwap(false, true) {
+		logger.Log.Warn("[GrpcClient] server rejected %s compression, falling back to identity: %v", c.compression, err)
+		c.stats.recordError(fmt.Errorf("compression %s rejected: %w", c.compression, err))
+	}
+	return true
+}
+
+// decompressorMissing 서버에 해당 grpc-encoding 의 압축 해제기가 없다는 거부인지
+func decompressorMissing(err error) bool {
+	st, ok := status.FromError(err)
+	return ok && st.Code() == codes.Unimplemented && strings.Contains(st.Message(), "Decompressor is not installed")
+}
+
+func (c *GrpcClient) compressionUnaryInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if !c.compressing() {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+		err := invoker(ctx, method, req, reply, cc, append(opts, grpc.UseCompressor(c.compression))...)
+		if c.compressionRejected(true, err) {
+			return invoker(ctx, method, req, reply, cc, opts...) // 압축 없이 한 번 재시도
+		}
+		return err
+	}
+}
+
+// compressionStreamInterceptor 스트림 생성 시점의 압축 설정 적용
+// 스트림 거부는 Recv 오류로 확인되므로 rxRoutine 에서 compressionRejected 처리 후 재연결한다.
+func (c *GrpcClient) compressionStreamInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		if c.compressing() {
+			opts = append(opts, grpc.UseCompressor(c.compression))
+		}
+		return streamer(ctx, desc, cc, method, opts...)
+	}
+}
+
 func (c *GrpcClient) agentKeyUnaryInterceptor() grpc.UnaryClientInterceptor {
 	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
-		ctx = metadata.AppendToOutgoingContext(ctx, "agent-key", c.agentKey)
+		if key := c.currentKey(); key != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, "agent-key", key)
+		}
 		return invoker(ctx, method, req, reply, cc, opts...)
 	}
 }
 
 func (c *GrpcClient) agentKeyStreamInterceptor() grpc.StreamClientInterceptor {
 	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
-		ctx = metadata.AppendToOutgoingContext(ctx, "agent-key", c.agentKey)
+		if key := c.currentKey(); key != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, "agent-key", key)
+		}
 		return streamer(ctx, desc, cc, method, opts...)
 	}
 }
@@ -124,9 +320,28 @@ func (c *GrpcClient) createStream() error {
 }
 
 func (c *GrpcClient) resetStream() {
+	c.resetStreamIf(nil)
+}
+
+// resetStreamIf 현재 스트림이 s 일 때만 해제 (nil : 무조건)
+// 이미 새 스트림으로 교체된 경우 이전 스트림의 오류로 새 스트림을 끊지 않는다.
+func (c *GrpcClient) resetStreamIf(s pb.ContainerService_DataStreamClient) {
 	c.mu.Lock()
-	defer c.mu.Unlock()
+	if s != nil && c.stream != s {
+		c.mu.Unlock()
+		return
+	}
+	hadStream := c.stream != nil
+	if hadStream {
+		c.stats.recordStreamReset()
+	}
 	c.stream = nil
+	addr := c.addr
+	c.mu.Unlock()
+
+	if hadStream {
+		c.streamDown(addr, c.stats.lastErrorText())
+	}
 }
 
 func (c *GrpcClient) getStream() pb.ContainerService_DataStreamClient {
@@ -156,11 +371,12 @@ func (c *GrpcClient) Start() {
 	defer txrxCancel()
 
 	var stateMu sync.Mutex
-	var txRunning, rxRunning, connRunning bool
+	var txRunning, rxRunning, connRunning, hbRunning bool
 
 	txDone := make(chan struct{}, 1)
 	rxDone := make(chan struct{}, 1)
 	connDone := make(chan struct{}, 1)
+	hbDone := make(chan struct{}, 1)
 
 	launch := func(running *bool, done chan struct{}, name string, fn func(context.Context)) {
 		stateMu.Lock()
@@ -185,6 +401,7 @@ func (c *GrpcClient) Start() {
 	launch(&connRunning, connDone, "manageConnect", c.manageConnect)
 	launch(&txRunning, txDone, "txRoutine", c.txRoutine)
 	launch(&rxRunning, rxDone, "rxRoutine", c.rxRoutine)
+	launch(&hbRunning, hbDone, "heartbeatRoutine", c.heartbeatRoutine)
 
 	for {
 		select {
@@ -210,6 +427,12 @@ func (c *GrpcClient) Start() {
 			if c.ctx.Err() == nil {
 				launch(&connRunning, connDone, "manageConnect", c.manageConnect)
 			}
+
+		case <-hbDone:
+			logger.Log.Warn("heartbeatRoutine exited")
+			if c.ctx.Err() == nil {
+				launch(&hbRunning, hbDone, "heartbeatRoutine", c.heartbeatRoutine)
+			}
 		}
 	}
 
@@ -218,7 +441,7 @@ WAIT:
 	go func() {
 		for {
 			stateMu.Lock()
-			done := !txRunning && !rxRunning && !connRunning
+			done := !txRunning && !rxRunning && !connRunning && !hbRunning
 			stateMu.Unlock()
 			if done {
 				close(stopped)
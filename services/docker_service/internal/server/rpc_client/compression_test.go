@@ -0,0 +1,38 @@
+package gapi
+
+import (
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCompressionRejected(t *testing.T) {
+	missing := status.Error(codes.Unimplemented, `grpc: Decompressor is not installed for grpc-encoding "gzip"`)
+
+	tests := []struct {
+		name       string
+		compressed bool
+		err        error
+		want       bool
+	}{
+		{"decompressor missing", true, missing, true},
+		{"not compressed", false, missing, false},
+		{"unknown method", true, status.Error(codes.Unimplemented, "unknown method ContainerBatch for service pb.ContainerService"), false},
+		{"other code", true, status.Error(codes.Internal, `grpc: Decompressor is not installed for grpc-encoding "gzip"`), false},
+		{"plain error", true, errors.New("Decompressor is not installed"), false},
+		{"nil", true, nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &GrpcClient{compression: "gzip"}
+			if got := c.compressionRejected(tt.compressed, tt.err); got != tt.want {
+				t.Fatalf("compressionRejected = %v, want %v", got, tt.want)
+			}
+			if c.compressing() == tt.want {
+				t.Fatalf("compressing = %v after rejected=%v", c.compressing(), tt.want)
+			}
+		})
+	}
+}
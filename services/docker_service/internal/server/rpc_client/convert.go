@@ -14,6 +14,7 @@ func ConvertToAgentMessage(msg pipeline.Message, agentKey string) (*pb.AgentMess
 		AgentKey:  agentKey,
 		Type:      convertDataType(msg.Type),
 		Host:      msg.Host,
+		Scope:     msg.Scope,
 		Timestamp: msg.Timestamp.UnixMilli(),
 	}
 
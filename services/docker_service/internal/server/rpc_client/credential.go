@@ -0,0 +1,284 @@
+package gapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"docker_service/internal/logger"
+	"docker_service/internal/pipeline"
+	"docker_service/internal/version"
+	"docker_service/pb"
+)
+
+// Credential 서버에서 발급받은 agent 자격 증명 (상태 파일에 보관)
+type Credential struct {
+	AgentId    int       `json:"agentid"`
+	AgentKey   string    `json:"agent_key"`
+	Server     string    `json:"server"`
+	EnrolledAt time.Time `json:"enrolled_at"`
+	RotatedAt  time.Time `json:"rotated_at,omitempty"`
+}
+
+// WithCredential 상태 파일의 자격 증명을 사용한다.
+// 파일이 없으면 bootstrapToken 으로 Enroll 하여 발급받은 자격 증명을 파일에 저장한다.
+// 파일이 바뀌면(ex: -rotate-key) 다음 호출부터 새 자격 증명을 사용한다.
+func WithCredential(stateFile, bootstrapToken string) ClientOption {
+	return func(c *GrpcClient) {
+		c.cred.file = stateFile
+		c.cred.bootstrapToken = bootstrapToken
+	}
+}
+
+// credentialCheckInterval 상태 파일 변경 확인 최소 간격
+const credentialCheckInterval = 2 * time.Second
+
+// credentialState 현재 사용 중인 agent 자격 증명
+type credentialState struct {
+	file           string // "" : 정적 agent key 사용
+	bootstrapToken string
+
+	mu        sync.Mutex
+	agentId   int // 0 : config AGENT_ID 사용
+	agentKey  string
+	enrolled  bool
+	modTime   time.Time
+	lastCheck time.Time
+}
+
+// LoadCredential 상태 파일 읽기 (파일이 없으면 os.ErrNotExist)
+func LoadCredential(path string) (*Credential, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if fi.Mode().Perm()&0077 != 0 {
+		logger.Log.Warn("[credential] state file %s is accessible by others (%v), should be 0600", path, fi.Mode().Perm())
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var cred Credential
+	if err := json.Unmarshal(data, &cred); err != nil {
+		return nil, fmt.Errorf("credential: parse %s: %w", path, err)
+	}
+	if cred.AgentId <= 0 || cred.AgentKey == "" {
+		return nil, fmt.Errorf("credential: invalid state file %s", path)
+	}
+	return &cred, nil
+}
+
+// SaveCredential 상태 파일 저장 (0600, 임시 파일 작성 후 rename)
+func SaveCredential(path string, cred *Credential) error {
+	data, err := json.MarshalIndent(cred, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("credential: %w", err)
+	}
+	tmp, err := os.CreateTemp(dir, ".agent-state-*")
+	if err != nil {
+		return fmt.Errorf("credential: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := tmp.Chmod(0600); err != nil {
+		tmp.Close()
+		return fmt.Errorf("credential: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("credential: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("credential: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("credential: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("credential: %w", err)
+	}
+	return nil
+}
+
+// loadCredential 상태 파일이 있으면 자격 증명 적용 (NewClient 시 호출)
+func (c *GrpcClient) loadCredential() error {
+	if c.cred.file == "" {
+		return nil
+	}
+	cred, err := LoadCredential(c.cred.file)
+	if errors.Is(err, os.ErrNotExist) {
+		if c.cred.bootstrapToken == "" {
+			logger.Log.Warn("[credential] no state file (%s) and no bootstrap token, using static agent key", c.cred.file)
+		}
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	fi, _ := os.Stat(c.cred.file)
+	c.setCredential(cred, fi)
+	logger.Log.Print(2, "[credential] loaded agent=%d from %s", cred.AgentId, c.cred.file)
+	return nil
+}
+
+func (c *GrpcClient) setCredential(cred *Credential, fi os.FileInfo) {
+	c.cred.mu.Lock()
+	defer c.cred.mu.Unlock()
+	c.cred.agentId = cred.AgentId
+	c.cred.agentKey = cred.AgentKey
+	c.cred.enrolled = true
+	if fi != nil {
+		c.cred.modTime = fi.ModTime()
+	}
+	c.cred.lastCheck = time.Now()
+}
+
+// maybeReloadCredential 상태 파일이 바뀌었으면 다시 읽기 (다른 프로세스의 key 교체 반영)
+func (c *GrpcClient) maybeReloadCredential() {
+	if c.cred.file == "" {
+		return
+	}
+	c.cred.mu.Lock()
+	if time.Since(c.cred.lastCheck) < credentialCheckInterval {
+		c.cred.mu.Unlock()
+		return
+	}
+	c.cred.lastCheck = time.Now()
+	modTime := c.cred.modTime
+	c.cred.mu.Unlock()
+
+	fi, err := os.Stat(c.cred.file)
+	if err != nil || fi.ModTime().Equal(modTime) {
+		return
+	}
+	cred, err := LoadCredential(c.cred.file)
+	if err != nil {
+		logger.Log.Error("[credential] reload fail, keep previous: %v", err)
+		return
+	}
+	c.setCredential(cred, fi)
+	logger.Log.Print(2, "[credential] reloaded agent=%d", cred.AgentId)
+}
+
+// currentKey 요청에 사용할 agent key
+func (c *GrpcClient) currentKey() string {
+	c.maybeReloadCredential()
+	c.cred.mu.Lock()
+	defer c.cred.mu.Unlock()
+	if c.cred.enrolled {
+		return c.cred.agentKey
+	}
+	return c.agentKey
+}
+
+// AgentID 발급받은 agent ID (미등록이면 config AGENT_ID)
+func (c *GrpcClient) AgentID() int {
+	c.cred.mu.Lock()
+	defer c.cred.mu.Unlock()
+	if c.cred.enrolled {
+		return c.cred.agentId
+	}
+	if c.ct.Config != nil {
+		return c.ct.Config.AgentId
+	}
+	return 0
+}
+
+func (c *GrpcClient) needEnroll() bool {
+	c.cred.mu.Lock()
+	defer c.cred.mu.Unlock()
+	return !c.cred.enrolled && c.cred.file != "" && c.cred.bootstrapToken != ""
+}
+
+// enroll bootstrap token 으로 agent ID 와 agent key 를 발급받아 상태 파일에 저장
+func (c *GrpcClient) enroll() error {
+	req := &pb.EnrollRequest{
+		BootstrapToken: c.cred.bootstrapToken,
+		Version:        version.Version,
+	}
+	req.AgentName, _ = os.Hostname()
+
+	resp, err := c.Enroll(req)
+	if err != nil {
+		return err
+	}
+	if !resp.Success || resp.Agentid <= 0 || resp.AgentKey == "" {
+		return fmt.Errorf("enroll rejected: %s", resp.Message)
+	}
+
+	cred := &Credential{
+		AgentId:    int(resp.Agentid),
+		AgentKey:   resp.AgentKey,
+		Server:     c.getAddr(),
+		EnrolledAt: time.Now(),
+	}
+	if err := SaveCredential(c.cred.file, cred); err != nil {
+		return err
+	}
+	fi, _ := os.Stat(c.cred.file)
+	c.setCredential(cred, fi)
+
+	logger.Log.Print(2, "[credential] enrolled agent=%d, saved to %s", cred.AgentId, c.cred.file)
+	return nil
+}
+
+// RotateAgentKey 현재 key 로 인증하여 새 key 를 발급받고 상태 파일에 저장
+// 서버는 새 key 발급과 동시에 이전 key 를 무효화한다.
+func (c *GrpcClient) RotateAgentKey() (*Credential, error) {
+	if c.cred.file == "" {
+		return nil, errors.New("credential state file is not configured")
+	}
+	cred, err := LoadCredential(c.cred.file)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.RotateKey(&pb.RotateKeyRequest{Agentid: int32(cred.AgentId)})
+	if err != nil {
+		return nil, err
+	}
+	if !resp.Success || resp.AgentKey == "" {
+		return nil, fmt.Errorf("rotate rejected: %s", resp.Message)
+	}
+
+	cred.AgentKey = resp.AgentKey
+	cred.RotatedAt = time.Now()
+	if err := SaveCredential(c.cred.file, cred); err != nil {
+		// 서버에서는 이미 교체됨 : 현재 프로세스는 새 key 로 계속 동작
+		c.setCredential(cred, nil)
+		return nil, fmt.Errorf("key rotated on server but saving state file failed: %w", err)
+	}
+	fi, _ := os.Stat(c.cred.file)
+	c.setCredential(cred, fi)
+
+	logger.Log.Print(2, "[credential] agent=%d key rotated", cred.AgentId)
+	return cred, nil
+}
+
+// toAgentMessage pb 변환 후 현재 agent ID 적용 (key 는 metadata 로만 전송, 메시지 본문에 넣지 않음)
+// 미등록이면 메시지의 agent ID (config AGENT_ID) 를 그대로 사용한다.
+func (c *GrpcClient) toAgentMessage(msg pipeline.Message) (*pb.AgentMessage, error) {
+	pbMsg, err := ConvertToAgentMessage(msg, "")
+	if err != nil {
+		return nil, err
+	}
+	c.cred.mu.Lock()
+	if c.cred.enrolled {
+		pbMsg.Agentid = int32(c.cred.agentId)
+	}
+	c.cred.mu.Unlock()
+	return pbMsg, nil
+}
@@ -0,0 +1,224 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"docker_service/internal/logger"
+	"docker_service/internal/pipeline/collector"
+	"docker_service/internal/version"
+	"docker_service/pb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const defaultHeartbeatInterval = 30 * time.Second
+
+// collector 타입 -> 전송 데이터 타입
+var collectorDataTypes = map[collector.CollectorType]pb.DataType{
+	collector.TypeList:    pb.DataType_CONTAINER_LIST,
+	collector.TypeInspect: pb.DataType_CONTAINER_INSPECT,
+	collector.TypeStats:   pb.DataType_CONTAINER_STATS,
+}
+
+// register: 연결(재연결) 시 agent 정보 등록 및 서버 설정 반영
+// 서버가 Register 를 지원하지 않으면(Unimplemented) 등록 없이 진행하고, 거부하면 오류를 반환한다. (스트림 생성 안 함)
+// agent key 는 metadata(agent-key) 로만 전송한다.
+func (c *GrpcClient) register() error {
+	req := c.buildRegisterRequest()
+	resp, err := c.Register(req)
+	if err != nil {
+		if status.Code(err) == codes.Unimplemented {
+			logger.Log.Warn("[register] server does not support Register, skip")
+			return nil
+		}
+		return err
+	}
+	c.upstream.markRecv()
+	if !resp.Success {
+		c.reg.mu.Lock()
+		c.reg.registered = false
+		c.reg.mu.Unlock()
+		return fmt.Errorf("rejected by server: %s", resp.Message)
+	}
+
+	c.reg.mu.Lock()
+	c.reg.registered = true
+	c.reg.registeredAt = time.Now()
+	if resp.HeartbeatIntervalSec > 0 {
+		c.reg.heartbeatInterval = time.Duration(resp.HeartbeatIntervalSec) * time.Second
+	}
+	c.reg.mu.Unlock()
+
+	logger.Log.Print(2, "[register] agent=%d version=%s hosts=%d types=%v commands=%v",
+		req.Agentid, req.Version, len(req.Hosts), req.DataTypes, req.Commands)
+
+	c.applyServerSettings(resp.CollectorSettings)
+	return nil
+}
+
+// buildRegisterRequest: agent ID, 버전, 호스트 목록, 지원 데이터 타입/명령
+func (c *GrpcClient) buildRegisterRequest() *pb.RegisterRequest {
+	req := &pb.RegisterRequest{
+		Agentid:   int32(c.AgentID()),
+		Version:   version.Version,
+		DataTypes: c.supportedDataTypes(),
+		Commands:  c.commands,
+		Timestamp: time.Now().UnixMilli(),
+	}
+	req.AgentName, _ = os.Hostname()
+
+	if c.ct.DockerMng != nil {
+		names := c.ct.DockerMng.GetHostNames()
+		sort.Strings(names)
+		for _, name := range names {
+			cli, err := c.ct.DockerMng.Get(name)
+			if err != nil {
+				continue
+			}
+			req.Hosts = append(req.Hosts, &pb.HostInfo{
+				Name: name,
+				Addr: cli.Addr(),
+				Mode: int32(cli.Mode()),
+			})
+		}
+	}
+	return req
+}
+
+// supportedDataTypes: 활성화된 수집기 기준 전송 데이터 타입 (event 는 항상 포함)
+func (c *GrpcClient) supportedDataTypes() []pb.DataType {
+	if c.pipesvr == nil {
+		return []pb.DataType{
+			pb.DataType_CONTAINER_LIST,
+			pb.DataType_CONTAINER_INSPECT,
+			pb.DataType_CONTAINER_STATS,
+			pb.DataType_CONTAINER_EVENT,
+		}
+	}
+
+	enabled := make(map[pb.DataType]bool)
+	for _, types := range c.pipesvr.EffectiveCollectorConfig() {
+		for t, cfg := range types {
+			if dt, ok := collectorDataTypes[t]; ok && cfg.Enabled {
+				enabled[dt] = true
+			}
+		}
+	}
+	enabled[pb.DataType_CONTAINER_EVENT] = true
+
+	result := make([]pb.DataType, 0, len(enabled))
+	for dt := range enabled {
+		result = append(result, dt)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i] < result[j] })
+	return result
+}
+
+// applyServerSettings: 서버가 내려준 수집기 설정 반영 (직전 적용 값과 같으면 무시)
+func (c *GrpcClient) applyServerSettings(raw string) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" || c.pipesvr == nil {
+		return
+	}
+
+	c.reg.mu.Lock()
+	same := raw == c.reg.appliedSettings
+	c.reg.mu.Unlock()
+	if same {
+		return
+	}
+
+	settings, err := collector.ParsePipelineConfig(raw)
+	if err != nil {
+		logger.Log.Error("[register] invalid collector settings from server: %v", err)
+		return
+	}
+	if err := c.pipesvr.UpdateCollectorSettings(settings); err != nil {
+		logger.Log.Error("[register] apply collector settings fail: %v", err)
+		return
+	}
+
+	c.reg.mu.Lock()
+	c.reg.appliedSettings = raw
+	c.reg.mu.Unlock()
+	logger.Log.Print(3, "[register] collector settings applied from server")
+}
+
+// heartbeatRoutine: 주기적으로 큐 적체량과 상태 보고
+// 주기는 Register 응답으로 바뀔 수 있으므로 1초마다 확인한다.
+func (c *GrpcClient) heartbeatRoutine(ctx context.Context) {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	last := time.Now()
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Log.Print(1, "[heartbeatRoutine] exiting")
+			return
+		case <-ticker.C:
+		}
+		if time.Since(last) < c.heartbeatInterval() {
+			continue
+		}
+		last = time.Now()
+
+		req := c.buildHeartbeat()
+		if _, err := c.Heartbeat(req); err != nil {
+			if status.Code(err) != codes.Unimplemented {
+				logger.Log.Warn("[heartbeatRoutine] heartbeat fail: %v", err)
+			}
+			continue
+		}
+
+		c.upstream.markRecv()
+		c.reg.mu.Lock()
+		c.reg.lastHeartbeat = time.Now()
+		c.reg.mu.Unlock()
+	}
+}
+
+func (c *GrpcClient) heartbeatInterval() time.Duration {
+	c.reg.mu.Lock()
+	defer c.reg.mu.Unlock()
+	if c.reg.heartbeatInterval > 0 {
+		return c.reg.heartbeatInterval
+	}
+	return defaultHeartbeatInterval
+}
+
+// buildHeartbeat: 전송 큐 적체량, ACK 대기 수, 상태(ok/degraded)
+func (c *GrpcClient) buildHeartbeat() *pb.HeartbeatRequest {
+	st := c.Status()
+	req := &pb.HeartbeatRequest{
+		Agentid:    int32(c.AgentID()),
+		Timestamp:  time.Now().UnixMilli(),
+		Health:     "ok",
+		QueueDepth: make(map[string]int32),
+		Inflight:   int32(st.Inflight),
+		LastError:  st.LastError,
+	}
+	if !st.StreamActive {
+		req.Health = "degraded"
+	}
+
+	if c.pipesvr != nil {
+		ps := c.pipesvr.Status()
+		req.SendChLen = int32(ps.SendChLen)
+		for t, q := range ps.Queues {
+			req.QueueDepth[string(t)] = int32(q.Depth)
+		}
+		for _, cs := range ps.Collectors {
+			if cs.LastError != "" {
+				req.Health = "degraded"
+			}
+		}
+	}
+	return req
+}
@@ -0,0 +1,170 @@
+package gapi
+
+import (
+	"sync"
+	"time"
+)
+
+// ClientStatus gRPC 클라이언트 상태 (/pipeline/status)
+type ClientStatus struct {
+	Addr          string    `json:"addr"`
+	ConnState     string    `json:"conn_state"`
+	StreamActive  bool      `json:"stream_active"`
+	LastSuccess   time.Time `json:"last_success"`
+	Sent          uint64    `json:"sent"`           // 전송 성공 메시지 수
+	SendErrors    uint64    `json:"send_errors"`    // RPC 실패 수
+	ConvertErrors uint64    `json:"convert_errors"` // pb 변환 실패 수
+	StreamResets  uint64    `json:"stream_resets"`
+	Inflight      int       `json:"inflight"`    // DataStream ACK 대기 메시지 수
+	Acked         uint64    `json:"acked"`       // ACK 받은 메시지 수
+	Retransmits   uint64    `json:"retransmits"` // 스트림 재생성 후 재전송 수
+	Dropped       uint64    `json:"dropped"`     // 전송하지 못하고 버린 메시지 수 (ACK 대기 목록 초과 + unary 실패 + 재적재 불가 배치)
+	LastError     string    `json:"last_error,omitempty"`
+	LastErrorAt   time.Time `json:"last_error_at,omitempty"`
+	LastRecv      time.Time `json:"last_recv"`  // 서버로부터 마지막 수신 (ACK, 명령, Register/Heartbeat 응답)
+	Reconnects    uint64    `json:"reconnects"` // 첫 연결 이후 스트림 재생성 수
+	UpSince       time.Time `json:"up_since,omitempty"`
+	DownSince     time.Time `json:"down_since,omitempty"`
+	Paused        bool      `json:"paused"`     // 운영자가 데이터 전송을 중지함
+	Registered    bool      `json:"registered"` // Register 성공 여부
+	RegisteredAt  time.Time `json:"registered_at,omitempty"`
+	LastHeartbeat time.Time `json:"last_heartbeat,omitempty"`
+	AgentId       int       `json:"agentid"`
+	Enrolled      bool      `json:"enrolled"` // 상태 파일의 자격 증명 사용 여부
+	TLS           bool      `json:"tls"`
+	CertReloads   uint64    `json:"cert_reloads,omitempty"` // TLS 인증서 재로딩 횟수
+	Compression   string    `json:"compression,omitempty"`  // 적용 중인 압축 (서버 거부 시 identity)
+
+	RecentErrors []UpstreamError `json:"recent_errors"` // 최근 오류 (최대 10개)
+}
+
+// sendStats 전송 결과 통계
+type sendStats struct {
+	mu            sync.Mutex
+	lastSuccess   time.Time
+	sent          uint64
+	sendErrors    uint64
+	convertErrors uint64
+	streamResets  uint64
+	dropped       uint64
+	lastError     string
+	lastErrorAt   time.Time
+	recent        []UpstreamError // 최근 오류 (maxRecentErrors 개)
+}
+
+// addError 마지막/최근 오류 기록 (mu 보유 상태에서 호출)
+func (s *sendStats) addError(err error) {
+	s.lastError = err.Error()
+	s.lastErrorAt = time.Now()
+	s.recent = append(s.recent, UpstreamError{At: s.lastErrorAt, Error: s.lastError})
+	if len(s.recent) > maxRecentErrors {
+		s.recent = s.recent[len(s.recent)-maxRecentErrors:]
+	}
+}
+
+// recordError 전송 외 오류 기록 (연결, 등록, 스트림 생성 실패)
+func (s *sendStats) recordError(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.addError(err)
+}
+
+func (s *sendStats) lastErrorText() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lastError
+}
+
+// recordSend 전송 결과 기록 (n : 메시지 수)
+func (s *sendStats) recordSend(n int, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err != nil {
+		s.sendErrors++
+		s.addError(err)
+		return
+	}
+	s.sent += uint64(n)
+	s.lastSuccess = time.Now()
+}
+
+func (s *sendStats) recordConvertError(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.convertErrors++
+	s.addError(err)
+}
+
+func (s *sendStats) recordDrop() {
+	s.recordDrops(1)
+}
+
+func (s *sendStats) recordDrops(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.dropped += uint64(n)
+}
+
+func (s *sendStats) recordStreamReset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.streamResets++
+}
+
+// Status 연결/스트림 상태와 전송 통계
+func (c *GrpcClient) Status() ClientStatus {
+	c.stats.mu.Lock()
+	st := ClientStatus{
+		Addr:          c.getAddr(),
+		LastSuccess:   c.stats.lastSuccess,
+		Sent:          c.stats.sent,
+		SendErrors:    c.stats.sendErrors,
+		ConvertErrors: c.stats.convertErrors,
+		StreamResets:  c.stats.streamResets,
+		Dropped:       c.stats.dropped,
+		LastError:     c.stats.lastError,
+		LastErrorAt:   c.stats.lastErrorAt,
+		RecentErrors:  append([]UpstreamError{}, c.stats.recent...),
+	}
+	c.stats.mu.Unlock()
+
+	c.upstream.mu.Lock()
+	st.LastRecv = c.upstream.lastRecv
+	st.Reconnects = c.upstream.reconnects
+	st.UpSince = c.upstream.upSince
+	st.DownSince = c.upstream.downSince
+	st.Paused = c.upstream.paused
+	c.upstream.mu.Unlock()
+
+	st.Inflight, st.Acked, st.Retransmits = c.inflight.counters()
+	st.ConnState = c.getConnState().String()
+	st.StreamActive = c.getStream() != nil
+	c.mu.RLock()
+	reloader := c.reloader
+	c.mu.RUnlock()
+	if reloader != nil {
+		st.TLS = true
+		st.CertReloads = reloader.reloadCount()
+	}
+
+	if c.compression != "" {
+		st.Compression = c.compression
+		if c.compressOff.Load() {
+			st.Compression = "identity"
+		}
+	}
+
+	st.AgentId = c.AgentID()
+	c.cred.mu.Lock()
+	st.Enrolled = c.cred.enrolled
+	c.cred.mu.Unlock()
+
+	c.reg.mu.Lock()
+	st.Registered = c.reg.registered
+	st.RegisteredAt = c.reg.registeredAt
+	st.LastHeartbeat = c.reg.lastHeartbeat
+	c.reg.mu.Unlock()
+	return st
+}
@@ -2,6 +2,7 @@ package gapi
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"time"
 
@@ -38,43 +39,98 @@ func (c *GrpcClient) manageConnect(ctx context.Context) {
 		// stream이 없으면 서버 상태와 무관하게 생성 시도
 		// 서버가 내려가 있으면 실패하고 재시도, 올라오면 성공한다.
 		if c.getStream() == nil {
+			// 자격 증명이 없으면 bootstrap token 으로 최초 등록
+			if c.needEnroll() {
+				if err := c.enroll(); err != nil {
+					c.stats.recordError(fmt.Errorf("enroll: %w", err))
+					logger.Log.Warn("[manageConnect] enroll failed (retry in 3s): %v", err)
+					time.Sleep(3 * time.Second)
+					continue
+				}
+			}
+
+			// 스트림 생성 전 핸드셰이크 (재연결 시에도 매번 등록)
+			if err := c.register(); err != nil {
+				c.stats.recordError(fmt.Errorf("register: %w", err))
+				logger.Log.Warn("[manageConnect] register failed (retry in 3s): %v", err)
+				c.flushPendingUnary()
+				time.Sleep(3 * time.Second)
+				continue
+			}
+
 			logger.Log.Print(1, "[manageConnect] no stream, attempting createStream..")
-			if err := c.createStream(); err != nil {
+			if err := c.openStream(); err != nil {
+				c.stats.recordError(err)
 				logger.Log.Warn("[manageConnect] createStream failed (retry in 3s): %v", err)
+				// 스트림을 사용할 수 없으면 ACK 대기 메시지는 unary로 전송
+				c.flushPendingUnary()
 				time.Sleep(3 * time.Second)
 				continue
 			}
-			logger.Log.Print(1, "[manageConnect] stream created")
 		}
 
 		time.Sleep(time.Second)
 	}
 }
 
+// openStream: 스트림 생성 후 이전 스트림에서 ACK 받지 못한 메시지 재전송
+// 서버는 연속된 seq 까지만 ACK 하므로, 재전송이 끝날 때까지 sendMu 를 잡아 새 메시지가 먼저 나가지 않게 한다.
+func (c *GrpcClient) openStream() error {
+	c.sendMu.Lock()
+	defer c.sendMu.Unlock()
+
+	if err := c.createStream(); err != nil {
+		return err
+	}
+	logger.Log.Print(1, "[manageConnect] stream created")
+	c.streamUp()
+
+	c.retransmitPending()
+	return nil
+}
+
 // txRoutine: pipeCh에서 pipeline.Message를 읽어 타입에 따라 스트리밍/단항 RPC로 라우팅
 func (c *GrpcClient) txRoutine(ctx context.Context) {
+	if c.batchSize > 1 {
+		c.txBatchRoutine(ctx)
+		return
+	}
+
 	for {
+		// pause 중에는 pipeCh 를 읽지 않는다 (pipeline 큐에 쌓임)
+		in := c.pipeCh
+		paused, wake := c.pauseState()
+		if paused {
+			in = nil
+		}
+
 		select {
 		case <-ctx.Done():
 			logger.Log.Print(1, "[txRoutine] exiting")
 			c.closeSend()
 			return
 
-		case msg, ok := <-c.pipeCh:
+		case <-wake:
+			continue
+
+		case msg, ok := <-in:
 			if !ok {
 				logger.Log.Warn("[txRoutine] pipeCh closed, initiating shutdown")
 				c.cancel()
 				return
 			}
 
+			if c.unaryOnly {
+				c.sendUnary(msg)
+				continue
+			}
+
 			switch msg.Type {
-			// case pipeline.DataTypeList, pipeline.DataTypeStats, pipeline.DataTypeEvent:
-			// case pipeline.DataTypeList:
-			// 	c.sendStream(msg) // 실시간 스트리밍
 			case pipeline.DataTypeList,
-				pipeline.DataTypeInspect,
 				pipeline.DataTypeStats,
 				pipeline.DataTypeEvent:
+				c.sendStream(msg) // 실시간 스트리밍 (seq/ACK)
+			case pipeline.DataTypeInspect:
 				c.sendUnary(msg) // 단발성 스냅샷
 			default:
 				logger.Log.Warn("[txRoutine] unknown message type: %s", msg.Type)
@@ -84,68 +140,131 @@ func (c *GrpcClient) txRoutine(ctx context.Context) {
 }
 
 // sendStream: DataStream 양방향 스트림으로 전송 (List, Stats, Event)
+// 메시지마다 seq를 부여하고 ACK를 받을 때까지 보관한다. 스트림을 사용할 수 없으면 unary로 전송한다.
+// seq 부여와 Send 는 sendMu 안에서 처리해 스트림의 seq 순서를 유지한다. (재전송 중에는 대기)
 func (c *GrpcClient) sendStream(msg pipeline.Message) {
-	logger.Log.Print(2, "sendStream...")
-	if c.getConnState() != connectivity.Ready {
-		logger.Log.Warn("[sendStream] connection not ready, dropping %s", msg.Type)
+	pbMsg, err := c.toAgentMessage(msg)
+	if err != nil {
+		c.stats.recordConvertError(err)
+		logger.Log.Error("[sendStream] convert failed: %v", err)
 		return
 	}
 
+	c.sendMu.Lock()
 	stream := c.getStream()
-	if stream == nil {
-		logger.Log.Warn("[sendStream] stream is nil, dropping %s", msg.Type)
+	if stream == nil || c.getConnState() != connectivity.Ready {
+		c.sendMu.Unlock()
+		logger.Log.Print(1, "[sendStream] stream not ready, fallback to unary: %s", msg.Type)
+		if err := c.sendUnaryPB(pbMsg); err != nil {
+			// unary도 실패하면 ACK 대기 목록에 보관 (스트림 재생성 시 재전송)
+			if c.inflight.add(pbMsg) {
+				logger.Log.Warn("[sendStream] unary fallback failed, queued for retransmit (seq=%d)", pbMsg.Seq)
+				// 현재 스트림으로 보내지 않은 seq 가 생기므로 스트림을 재생성해 순서대로 재전송
+				if stream != nil {
+					c.resetStreamIf(stream)
+				}
+			} else {
+				c.stats.recordDrop()
+				logger.Log.Error("[sendStream] unary fallback failed and inflight window full, message dropped: %s", msg.Type)
+			}
+		}
 		return
 	}
 
-	pbMsg := &pb.AgentMessage{
-		AgentKey: "abcd",
-		Host:     "yun119",
+	if !c.inflight.add(pbMsg) {
+		c.sendMu.Unlock()
+		logger.Log.Warn("[sendStream] inflight window full, fallback to unary: %s", msg.Type)
+		if err := c.sendUnaryPB(pbMsg); err != nil {
+			c.stats.recordDrop()
+			logger.Log.Error("[sendStream] inflight window full and unary failed, message dropped: %s", msg.Type)
+		}
+		return
 	}
 
-	// pbMsg, err := ConvertToAgentMessage(msg, c.agentKey)
-	// if err != nil {
-	// 	logger.Log.Error("[sendStream] convert failed: %v", err)
-	// 	return
-	// }
+	err = stream.Send(pbMsg)
+	c.sendMu.Unlock()
 
-	if err := stream.Send(pbMsg); err != nil {
-		logger.Log.Error("[sendStream] Send error: %v", err)
-		c.resetStream()
+	c.stats.recordSend(1, err)
+	if err != nil {
+		// ACK 대기 목록에 남겨두고 스트림 재생성 후 재전송
+		logger.Log.Error("[sendStream] Send error (seq=%d): %v", pbMsg.Seq, err)
+		c.resetStreamIf(stream)
 	}
 }
 
-// sendUnary: ContainerState 단항 RPC 호출 (Inspect 스냅샷)
+// retransmitPending: 새 스트림으로 ACK 대기 메시지를 seq 순서대로 재전송 (호출자가 sendMu 보유)
+func (c *GrpcClient) retransmitPending() {
+	pending := c.inflight.pending()
+	if len(pending) == 0 {
+		return
+	}
+
+	stream := c.getStream()
+	if stream == nil {
+		return
+	}
+
+	sent := 0
+	for _, m := range pending {
+		if err := stream.Send(m); err != nil {
+			logger.Log.Error("[retransmitPending] Send error (seq=%d): %v", m.Seq, err)
+			c.stats.recordSend(1, err)
+			c.resetStream()
+			break
+		}
+		sent++
+	}
+	c.stats.recordSend(sent, nil)
+	c.inflight.addRetransmits(sent)
+	logger.Log.Print(2, "[retransmitPending] retransmitted %d/%d messages", sent, len(pending))
+}
+
+// flushPendingUnary: 스트림을 사용할 수 없을 때 ACK 대기 메시지를 unary로 전송
+func (c *GrpcClient) flushPendingUnary() {
+	for _, m := range c.inflight.pending() {
+		seq := m.Seq
+		m.Seq = 0
+		if err := c.sendUnaryPB(m); err != nil {
+			m.Seq = seq
+			return // 서버 불가 - 다음 시도에서 다시 전송
+		}
+		c.inflight.remove(seq)
+	}
+}
+
+// sendUnary: 단항 RPC 호출 (Inspect 스냅샷, 스트림 fallback)
 func (c *GrpcClient) sendUnary(msg pipeline.Message) {
-	pbMsg, err := ConvertToAgentMessage(msg, c.agentKey)
+	pbMsg, err := c.toAgentMessage(msg)
 	if err != nil {
+		c.stats.recordConvertError(err)
 		logger.Log.Error("[sendUnary] convert failed: %v", err)
 		return
 	}
-	resp := &pb.ServerMessage{}
+	c.sendUnaryPB(pbMsg)
+}
 
-	switch msg.Type {
-	case pipeline.DataTypeList:
-		resp, err = c.ContainerInfo(pbMsg)
-	case pipeline.DataTypeInspect:
-		resp, err = c.ContainerInspect(pbMsg)
-	case pipeline.DataTypeStats:
-		resp, err = c.ContainerStats(pbMsg)
-	case pipeline.DataTypeEvent:
-		resp, err = c.ContainerEvent(pbMsg)
+// sendUnaryPB: 타입에 맞는 단항 RPC로 전송
+func (c *GrpcClient) sendUnaryPB(pbMsg *pb.AgentMessage) error {
+	var err error
 
+	switch pbMsg.Type {
+	case pb.DataType_CONTAINER_LIST:
+		_, err = c.ContainerInfo(pbMsg)
+	case pb.DataType_CONTAINER_INSPECT:
+		_, err = c.ContainerInspect(pbMsg)
+	case pb.DataType_CONTAINER_STATS:
+		_, err = c.ContainerStats(pbMsg)
+	case pb.DataType_CONTAINER_EVENT:
+		_, err = c.ContainerEvent(pbMsg)
 	default:
-		logger.Log.Print(2, "[sendUnary] unknown message type: %s", msg.Type)
+		err = fmt.Errorf("unknown message type: %s", pbMsg.Type)
 	}
 
-	// resp, err = c.ContainerState(pbMsg)
+	c.stats.recordSend(1, err)
 	if err != nil {
-		logger.Log.Error("[sendUnary] ContainerState error: %v", err)
-		return
+		logger.Log.Error("[sendUnary] %s error: %v", pbMsg.Type, err)
 	}
-
-	_ = resp
-	// 아래는 필요시
-	// c.handleServerMessage(resp)
+	return err
 }
 
 // rxRoutine: DataStream에서 ServerMessage를 수신하여 databus에 발행
@@ -172,23 +291,39 @@ func (c *GrpcClient) rxRoutine(ctx context.Context) {
 		resp, err := stream.Recv()
 		if err == io.EOF {
 			logger.Log.Warn("[rxRoutine] server closed stream")
-			c.resetStream()
+			c.stats.recordError(fmt.Errorf("stream closed by server"))
+			c.resetStreamIf(stream)
 			return
 		}
 		if err != nil {
 			logger.Log.Error("[rxRoutine] Recv error: %v", err)
-			c.resetStream()
+			c.stats.recordError(err)
+			// gzip 미등록 서버 : identity 로 전환 후 재연결 (ACK 대기 메시지는 재전송)
+			c.compressionRejected(c.compressing(), err)
+			c.resetStreamIf(stream)
 			return
 		}
+		c.upstream.markRecv()
+
+		if resp.Command == pb.CommandType_ACK {
+			if resp.AckSeq > 0 {
+				n := c.inflight.ack(resp.AckSeq)
+				logger.Log.Print(1, "[rxRoutine] ack seq<=%d (%d msgs)", resp.AckSeq, n)
+			}
+			continue
+		}
 
 		logger.Log.Print(1, "recv : %v", resp)
-		// c.handleServerMessage(resp)
+		c.handleServerMessage(resp)
 	}
 }
 
+// TopicServerCommand 서버 명령(ServerMessage) databus topic
+const TopicServerCommand = "server_command"
+
 func (c *GrpcClient) handleServerMessage(msg *pb.ServerMessage) {
 	c.ct.Bus.Publish(databus.Message{
-		Topic: "server_command",
+		Topic: TopicServerCommand,
 		Data:  msg,
 	})
 }
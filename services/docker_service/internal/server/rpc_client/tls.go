@@ -0,0 +1,240 @@
+package gapi
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"docker_service/internal/logger"
+)
+
+// TLSConfig gRPC 연결 TLS/mTLS 설정
+type TLSConfig struct {
+	CAFile     string   // 서버 인증서 검증용 CA (PEM), "" : 시스템 CA
+	CertFile   string   // 클라이언트 인증서 (mTLS)
+	KeyFile    string   // 클라이언트 개인키 (mTLS)
+	ServerName string   // 인증서 검증/SNI 서버 이름 (주소의 host 와 다를 때)
+	Pins       []string // 인증서 pinning : SPKI sha256 (base64 또는 hex), 체인 중 하나라도 일치해야 함
+}
+
+// WithTLS enables TLS (mTLS when CertFile/KeyFile are set) for the connection.
+// Certificate files are re-read on change, so new handshakes pick up rotated certs.
+func WithTLS(cfg TLSConfig) ClientOption {
+	return func(c *GrpcClient) {
+		c.tlsCfg = &cfg
+	}
+}
+
+// reloadCheckInterval 인증서 파일 변경 확인 최소 간격
+const reloadCheckInterval = 2 * time.Second
+
+// certReloader 인증서/CA 파일 변경 감지 및 재로딩 (handshake 시점에 확인)
+type certReloader struct {
+	cfg        TLSConfig
+	pins       [][]byte
+	verifyName string // 인증서 검증 이름 (ServerName override 또는 접속 주소의 host)
+
+	mu        sync.Mutex
+	cert      *tls.Certificate
+	roots     *x509.CertPool
+	modTimes  map[string]time.Time
+	lastCheck time.Time
+	reloads   uint64
+}
+
+func newCertReloader(cfg TLSConfig, addr string) (*certReloader, error) {
+	if (cfg.CertFile == "") != (cfg.KeyFile == "") {
+		return nil, errors.New("tls: cert file and key file must be set together")
+	}
+
+	// IP 로 접속하면 SNI 가 비어 있으므로 접속 주소로 검증 (IP SAN)
+	verifyName := cfg.ServerName
+	if verifyName == "" {
+		verifyName = addr
+		if host, _, err := net.SplitHostPort(addr); err == nil {
+			verifyName = host
+		}
+	}
+
+	r := &certReloader{cfg: cfg, verifyName: verifyName, modTimes: make(map[string]time.Time)}
+	for _, p := range cfg.Pins {
+		pin, err := decodePin(p)
+		if err != nil {
+			return nil, err
+		}
+		r.pins = append(r.pins, pin)
+	}
+
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func decodePin(p string) ([]byte, error) {
+	p = strings.TrimPrefix(strings.TrimSpace(p), "sha256/")
+	if b, err := hex.DecodeString(strings.ReplaceAll(p, ":", "")); err == nil && len(b) == sha256.Size {
+		return b, nil
+	}
+	if b, err := base64.StdEncoding.DecodeString(p); err == nil && len(b) == sha256.Size {
+		return b, nil
+	}
+	return nil, fmt.Errorf("tls: invalid pin %q (sha256 SPKI, base64 or hex)", p)
+}
+
+func (r *certReloader) files() []string {
+	var files []string
+	for _, f := range []string{r.cfg.CAFile, r.cfg.CertFile, r.cfg.KeyFile} {
+		if f != "" {
+			files = append(files, f)
+		}
+	}
+	return files
+}
+
+// load 인증서/CA 파일 읽기 (실패하면 기존 값 유지)
+func (r *certReloader) load() error {
+	modTimes := make(map[string]time.Time)
+	for _, f := range r.files() {
+		fi, err := os.Stat(f)
+		if err != nil {
+			return fmt.Errorf("tls: %w", err)
+		}
+		modTimes[f] = fi.ModTime()
+	}
+
+	var roots *x509.CertPool
+	if r.cfg.CAFile != "" {
+		pem, err := os.ReadFile(r.cfg.CAFile)
+		if err != nil {
+			return fmt.Errorf("tls: read ca: %w", err)
+		}
+		roots = x509.NewCertPool()
+		if !roots.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("tls: no certificate in ca file %s", r.cfg.CAFile)
+		}
+	}
+
+	var cert *tls.Certificate
+	if r.cfg.CertFile != "" {
+		kp, err := tls.LoadX509KeyPair(r.cfg.CertFile, r.cfg.KeyFile)
+		if err != nil {
+			return fmt.Errorf("tls: load client cert: %w", err)
+		}
+		cert = &kp
+	}
+
+	r.mu.Lock()
+	r.roots = roots
+	r.cert = cert
+	r.modTimes = modTimes
+	r.mu.Unlock()
+	return nil
+}
+
+// maybeReload 파일이 바뀌었으면 재로딩
+func (r *certReloader) maybeReload() {
+	r.mu.Lock()
+	if time.Since(r.lastCheck) < reloadCheckInterval {
+		r.mu.Unlock()
+		return
+	}
+	r.lastCheck = time.Now()
+	changed := false
+	for _, f := range r.files() {
+		fi, err := os.Stat(f)
+		if err == nil && !fi.ModTime().Equal(r.modTimes[f]) {
+			changed = true
+			break
+		}
+	}
+	r.mu.Unlock()
+
+	if !changed {
+		return
+	}
+	if err := r.load(); err != nil {
+		logger.Log.Error("[tls] certificate reload fail, keep previous: %v", err)
+		return
+	}
+	r.mu.Lock()
+	r.reloads++
+	r.mu.Unlock()
+	logger.Log.Print(3, "[tls] certificates reloaded")
+}
+
+// tlsConfig gRPC credentials 용 tls.Config
+// 재로딩된 CA 를 적용하기 위해 기본 검증 대신 VerifyConnection 에서 직접 검증한다.
+func (r *certReloader) tlsConfig() *tls.Config {
+	cfg := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         r.cfg.ServerName,
+		InsecureSkipVerify: true, // VerifyConnection 에서 검증
+		VerifyConnection:   r.verifyConnection,
+	}
+	if r.cfg.CertFile != "" {
+		cfg.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			r.maybeReload()
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			return r.cert, nil
+		}
+	}
+	return cfg
+}
+
+func (r *certReloader) verifyConnection(cs tls.ConnectionState) error {
+	r.maybeReload()
+
+	if len(cs.PeerCertificates) == 0 {
+		return errors.New("tls: no server certificate")
+	}
+
+	r.mu.Lock()
+	roots := r.roots
+	r.mu.Unlock()
+
+	opts := x509.VerifyOptions{
+		Roots:         roots,
+		DNSName:       r.verifyName,
+		Intermediates: x509.NewCertPool(),
+	}
+	for _, ic := range cs.PeerCertificates[1:] {
+		opts.Intermediates.AddCert(ic)
+	}
+	chains, err := cs.PeerCertificates[0].Verify(opts)
+	if err != nil {
+		return fmt.Errorf("tls: verify server certificate: %w", err)
+	}
+
+	if len(r.pins) == 0 {
+		return nil
+	}
+	for _, chain := range chains {
+		for _, cert := range chain {
+			sum := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+			for _, pin := range r.pins {
+				if string(sum[:]) == string(pin) {
+					return nil
+				}
+			}
+		}
+	}
+	return errors.New("tls: server certificate does not match pinned key")
+}
+
+// reloadCount 인증서 재로딩 횟수
+func (r *certReloader) reloadCount() uint64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.reloads
+}
@@ -0,0 +1,350 @@
+package gapi
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gdygd/goglib/databus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	"docker_service/internal/container"
+	"docker_service/internal/pipeline"
+	"docker_service/internal/testcert"
+	"docker_service/pb"
+)
+
+func TestDecodePin(t *testing.T) {
+	sum := sha256.Sum256([]byte("spki"))
+	b64 := base64.StdEncoding.EncodeToString(sum[:])
+	hx := hex.EncodeToString(sum[:])
+
+	var colon []string
+	for _, b := range sum {
+		colon = append(colon, hex.EncodeToString([]byte{b}))
+	}
+
+	short := sha256.Sum224([]byte("spki"))
+
+	tests := []struct {
+		name    string
+		pin     string
+		wantErr bool
+	}{
+		{"base64", b64, false},
+		{"base64 with prefix", "sha256/" + b64, false},
+		{"hex lower", hx, false},
+		{"hex upper", strings.ToUpper(hx), false},
+		{"hex with colons", strings.Join(colon, ":"), false},
+		{"surrounding spaces", "  " + b64 + " ", false},
+		{"empty", "", true},
+		{"garbage", "not-a-pin", true},
+		{"base64 wrong length", base64.StdEncoding.EncodeToString(short[:]), true},
+		{"hex wrong length", hex.EncodeToString(short[:]), true},
+		{"truncated hex", hx[:len(hx)-2], true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodePin(tt.pin)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("decodePin(%q) = %x, want error", tt.pin, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("decodePin(%q) error: %v", tt.pin, err)
+			}
+			if string(got) != string(sum[:]) {
+				t.Fatalf("decodePin(%q) = %x, want %x", tt.pin, got, sum)
+			}
+		})
+	}
+}
+
+// mustCA 테스트용 CA
+func mustCA(t *testing.T, cn string) *testcert.CA {
+	t.Helper()
+	ca, err := testcert.NewCA(cn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ca
+}
+
+// mustIssue 127.0.0.1, collector.local 용 인증서 발급
+func mustIssue(t *testing.T, ca *testcert.CA, notAfter time.Time, usage x509.ExtKeyUsage) *testcert.Cert {
+	t.Helper()
+	c, err := ca.Issue("collector", []string{"collector.local"}, []net.IP{net.ParseIP("127.0.0.1")}, notAfter, usage)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func writeFile(t *testing.T, dir, name string, data []byte) string {
+	t.Helper()
+	p := filepath.Join(dir, name)
+	if err := os.WriteFile(p, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestVerifyConnection(t *testing.T) {
+	ca := mustCA(t, "test-ca")
+	otherCA := mustCA(t, "other-ca")
+	leaf := mustIssue(t, ca, time.Now().Add(time.Hour), x509.ExtKeyUsageServerAuth)
+	expired := mustIssue(t, ca, time.Now().Add(-time.Hour), x509.ExtKeyUsageServerAuth)
+	foreign := mustIssue(t, otherCA, time.Now().Add(time.Hour), x509.ExtKeyUsageServerAuth)
+
+	caFile := writeFile(t, t.TempDir(), "ca.pem", ca.CertPEM)
+
+	tests := []struct {
+		name       string
+		addr       string
+		serverName string
+		pins       []string
+		peers      []*x509.Certificate
+		wantErr    string
+	}{
+		{name: "valid ip san", addr: "127.0.0.1:19192", peers: []*x509.Certificate{leaf.X509}},
+		{name: "server name override", addr: "10.0.0.1:19192", serverName: "collector.local", peers: []*x509.Certificate{leaf.X509}},
+		{name: "chain with ca", addr: "127.0.0.1:19192", peers: []*x509.Certificate{leaf.X509, ca.X509}},
+		{name: "no peer certificate", addr: "127.0.0.1:19192", wantErr: "no server certificate"},
+		{name: "name mismatch", addr: "10.0.0.1:19192", peers: []*x509.Certificate{leaf.X509}, wantErr: "verify server certificate"},
+		{name: "expired", addr: "127.0.0.1:19192", peers: []*x509.Certificate{expired.X509}, wantErr: "verify server certificate"},
+		{name: "unknown ca", addr: "127.0.0.1:19192", peers: []*x509.Certificate{foreign.X509}, wantErr: "verify server certificate"},
+		{name: "leaf pin", addr: "127.0.0.1:19192", pins: []string{leaf.Pin}, peers: []*x509.Certificate{leaf.X509}},
+		{name: "ca pin", addr: "127.0.0.1:19192", pins: []string{ca.Pin}, peers: []*x509.Certificate{leaf.X509}},
+		{name: "one of pins", addr: "127.0.0.1:19192", pins: []string{otherCA.Pin, leaf.Pin}, peers: []*x509.Certificate{leaf.X509}},
+		{name: "pin mismatch", addr: "127.0.0.1:19192", pins: []string{otherCA.Pin}, peers: []*x509.Certificate{leaf.X509}, wantErr: "does not match pinned key"},
+		{name: "pin not checked before chain", addr: "127.0.0.1:19192", pins: []string{foreign.Pin}, peers: []*x509.Certificate{foreign.X509}, wantErr: "verify server certificate"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := newCertReloader(TLSConfig{CAFile: caFile, ServerName: tt.serverName, Pins: tt.pins}, tt.addr)
+			if err != nil {
+				t.Fatalf("newCertReloader: %v", err)
+			}
+			err = r.verifyConnection(tls.ConnectionState{PeerCertificates: tt.peers})
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("verifyConnection: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("verifyConnection error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewCertReloaderInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  TLSConfig
+	}{
+		{"cert without key", TLSConfig{CertFile: "client.pem"}},
+		{"key without cert", TLSConfig{KeyFile: "client.key"}},
+		{"invalid pin", TLSConfig{Pins: []string{"bad"}}},
+		{"missing ca file", TLSConfig{CAFile: filepath.Join(t.TempDir(), "none.pem")}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := newCertReloader(tt.cfg, "127.0.0.1:19192"); err == nil {
+				t.Fatal("want error")
+			}
+		})
+	}
+}
+
+// tlsStub Heartbeat 만 응답하는 gRPC 서버
+type tlsStub struct {
+	pb.UnimplementedContainerServiceServer
+}
+
+func (s *tlsStub) Heartbeat(ctx context.Context, _ *pb.HeartbeatRequest) (*pb.HeartbeatResponse, error) {
+	return &pb.HeartbeatResponse{Success: true, ServerTime: time.Now().UnixMilli()}, nil
+}
+
+// startTLSServer 127.0.0.1:0 에 TLS gRPC 서버 시작 (clientCA != nil 이면 mTLS 필수)
+func startTLSServer(t *testing.T, cert *testcert.Cert, clientCA *testcert.CA) string {
+	t.Helper()
+	kp, err := cert.KeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := &tls.Config{Certificates: []tls.Certificate{kp}, MinVersion: tls.VersionTLS12}
+	if clientCA != nil {
+		cfg.ClientCAs = clientCA.Pool()
+		cfg.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	gs := grpc.NewServer(grpc.Creds(credentials.NewTLS(cfg)))
+	pb.RegisterContainerServiceServer(gs, &tlsStub{})
+	go gs.Serve(lis)
+	t.Cleanup(gs.Stop)
+	return lis.Addr().String()
+}
+
+func newTLSClient(t *testing.T, addr string, cfg TLSConfig) *GrpcClient {
+	t.Helper()
+	var wg sync.WaitGroup
+	wg.Add(1)
+	ct := &container.Container{Bus: databus.NewDataBus()}
+	client, err := NewClient(&wg, ct, make(chan pipeline.Message), addr, "tls-test", WithTLS(cfg))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	t.Cleanup(client.Shutdown)
+	return client
+}
+
+func heartbeat(client *GrpcClient) error {
+	_, err := client.Heartbeat(&pb.HeartbeatRequest{Timestamp: time.Now().UnixMilli()})
+	return err
+}
+
+func TestTLSHandshake(t *testing.T) {
+	dir := t.TempDir()
+	ca := mustCA(t, "test-ca")
+	otherCA := mustCA(t, "other-ca")
+	valid := time.Now().Add(time.Hour)
+
+	srv := mustIssue(t, ca, valid, x509.ExtKeyUsageServerAuth)
+	expired := mustIssue(t, ca, time.Now().Add(-time.Hour), x509.ExtKeyUsageServerAuth)
+	foreign := mustIssue(t, otherCA, valid, x509.ExtKeyUsageServerAuth)
+	cli := mustIssue(t, ca, valid, x509.ExtKeyUsageClientAuth)
+	foreignCli := mustIssue(t, otherCA, valid, x509.ExtKeyUsageClientAuth)
+
+	caFile := writeFile(t, dir, "ca.pem", ca.CertPEM)
+	otherCAFile := writeFile(t, dir, "other-ca.pem", otherCA.CertPEM)
+	cliCert := writeFile(t, dir, "agent.pem", cli.CertPEM)
+	cliKey := writeFile(t, dir, "agent-key.pem", cli.KeyPEM)
+	foreignCliCert := writeFile(t, dir, "foreign.pem", foreignCli.CertPEM)
+	foreignCliKey := writeFile(t, dir, "foreign-key.pem", foreignCli.KeyPEM)
+
+	srvAddr := startTLSServer(t, srv, nil)
+	expiredAddr := startTLSServer(t, expired, nil)
+	foreignAddr := startTLSServer(t, foreign, nil)
+	mtlsAddr := startTLSServer(t, srv, ca)
+
+	tests := []struct {
+		name    string
+		addr    string
+		cfg     TLSConfig
+		wantErr string
+	}{
+		{name: "tls ok", addr: srvAddr, cfg: TLSConfig{CAFile: caFile}},
+		{name: "pin ok", addr: srvAddr, cfg: TLSConfig{CAFile: caFile, Pins: []string{srv.Pin}}},
+		{name: "expired server cert", addr: expiredAddr, cfg: TLSConfig{CAFile: caFile}, wantErr: "expired"},
+		{name: "server from other ca", addr: foreignAddr, cfg: TLSConfig{CAFile: caFile}, wantErr: "unknown authority"},
+		{name: "client trusts other ca", addr: srvAddr, cfg: TLSConfig{CAFile: otherCAFile}, wantErr: "unknown authority"},
+		{name: "pin mismatch", addr: srvAddr, cfg: TLSConfig{CAFile: caFile, Pins: []string{otherCA.Pin}}, wantErr: "pinned key"},
+		{name: "mtls ok", addr: mtlsAddr, cfg: TLSConfig{CAFile: caFile, CertFile: cliCert, KeyFile: cliKey}},
+		{name: "mtls without client cert", addr: mtlsAddr, cfg: TLSConfig{CAFile: caFile}, wantErr: "certificate required"},
+		{name: "mtls client cert from other ca", addr: mtlsAddr, cfg: TLSConfig{CAFile: caFile, CertFile: foreignCliCert, KeyFile: foreignCliKey}, wantErr: "unknown certificate authority"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := heartbeat(newTLSClient(t, tt.addr, tt.cfg))
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Heartbeat: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Heartbeat error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCertHotReload(t *testing.T) {
+	ca := mustCA(t, "test-ca")
+	otherCA := mustCA(t, "other-ca")
+	valid := time.Now().Add(time.Hour)
+	srv := mustIssue(t, ca, valid, x509.ExtKeyUsageServerAuth)
+	cli := mustIssue(t, ca, valid, x509.ExtKeyUsageClientAuth)
+	foreignCli := mustIssue(t, otherCA, valid, x509.ExtKeyUsageClientAuth)
+
+	tests := []struct {
+		name     string
+		clientCA *testcert.CA      // 서버가 요구하는 client CA (nil : TLS)
+		before   map[string][]byte // 최초 파일 (실패해야 함)
+		after    map[string][]byte // 교체 파일 (재로딩 후 성공해야 함)
+		cfg      func(dir string) TLSConfig
+	}{
+		{
+			name:   "ca file",
+			before: map[string][]byte{"ca.pem": otherCA.CertPEM},
+			after:  map[string][]byte{"ca.pem": ca.CertPEM},
+			cfg: func(dir string) TLSConfig {
+				return TLSConfig{CAFile: filepath.Join(dir, "ca.pem")}
+			},
+		},
+		{
+			name:     "client cert",
+			clientCA: ca,
+			before:   map[string][]byte{"ca.pem": ca.CertPEM, "agent.pem": foreignCli.CertPEM, "agent-key.pem": foreignCli.KeyPEM},
+			after:    map[string][]byte{"agent.pem": cli.CertPEM, "agent-key.pem": cli.KeyPEM},
+			cfg: func(dir string) TLSConfig {
+				return TLSConfig{CAFile: filepath.Join(dir, "ca.pem"), CertFile: filepath.Join(dir, "agent.pem"), KeyFile: filepath.Join(dir, "agent-key.pem")}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, data := range tt.before {
+				writeFile(t, dir, name, data)
+			}
+			client := newTLSClient(t, startTLSServer(t, srv, tt.clientCA), tt.cfg(dir))
+			if err := heartbeat(client); err == nil {
+				t.Fatal("Heartbeat succeeded before certificate rotation")
+			}
+
+			// 같은 클라이언트로 파일 교체 후 재로딩 확인 (mtime 으로 변경 감지)
+			future := time.Now().Add(time.Minute)
+			for name, data := range tt.after {
+				p := writeFile(t, dir, name, data)
+				if err := os.Chtimes(p, future, future); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			deadline := time.Now().Add(20 * time.Second)
+			var err error
+			for time.Now().Before(deadline) {
+				if err = heartbeat(client); err == nil {
+					break
+				}
+				time.Sleep(200 * time.Millisecond)
+			}
+			if err != nil {
+				t.Fatalf("Heartbeat after rotation: %v", err)
+			}
+			if n := client.Status().CertReloads; n == 0 {
+				t.Fatal("CertReloads = 0 after rotation")
+			}
+		})
+	}
+}
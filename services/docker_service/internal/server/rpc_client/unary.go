@@ -89,3 +89,75 @@ func (c *GrpcClient) ContainerEvent(req *pb.AgentMessage) (*pb.ServerMessage, er
 	defer cancel()
 	return client.ContainerEvent(ctx, req)
 }
+
+func (c *GrpcClient) ContainerBatch(req *pb.AgentMessageBatch) (*pb.ServerMessage, error) {
+	logger.Log.Print(1, "ContainerBatch.. (%d)", len(req.Messages))
+	client, err := c.newServiceClient()
+	if err != nil {
+		logger.Log.Error("ContainerBatch error : %v", err)
+		return nil, fmt.Errorf("[ContainerBatch] error : %w", err)
+	}
+	ctx, cancel := context.WithTimeout(c.ctx, 5*time.Second)
+	defer cancel()
+	return client.ContainerBatch(ctx, req)
+}
+
+func (c *GrpcClient) ReportCommand(req *pb.CommandResult) (*pb.ServerMessage, error) {
+	logger.Log.Print(1, "ReportCommand.. (%s)", req.CommandId)
+	client, err := c.newServiceClient()
+	if err != nil {
+		logger.Log.Error("ReportCommand error : %v", err)
+		return nil, fmt.Errorf("[ReportCommand] error : %w", err)
+	}
+	ctx, cancel := context.WithTimeout(c.ctx, 5*time.Second)
+	defer cancel()
+	return client.ReportCommand(ctx, req)
+}
+
+func (c *GrpcClient) Register(req *pb.RegisterRequest) (*pb.RegisterResponse, error) {
+	logger.Log.Print(1, "Register..")
+	client, err := c.newServiceClient()
+	if err != nil {
+		logger.Log.Error("Register error : %v", err)
+		return nil, fmt.Errorf("[Register] error : %w", err)
+	}
+	ctx, cancel := context.WithTimeout(c.ctx, 5*time.Second)
+	defer cancel()
+	return client.Register(ctx, req)
+}
+
+func (c *GrpcClient) Heartbeat(req *pb.HeartbeatRequest) (*pb.HeartbeatResponse, error) {
+	logger.Log.Print(1, "Heartbeat..")
+	client, err := c.newServiceClient()
+	if err != nil {
+		logger.Log.Error("Heartbeat error : %v", err)
+		return nil, fmt.Errorf("[Heartbeat] error : %w", err)
+	}
+	ctx, cancel := context.WithTimeout(c.ctx, 5*time.Second)
+	defer cancel()
+	return client.Heartbeat(ctx, req)
+}
+
+func (c *GrpcClient) Enroll(req *pb.EnrollRequest) (*pb.EnrollResponse, error) {
+	logger.Log.Print(1, "Enroll..")
+	client, err := c.newServiceClient()
+	if err != nil {
+		logger.Log.Error("Enroll error : %v", err)
+		return nil, fmt.Errorf("[Enroll] error : %w", err)
+	}
+	ctx, cancel := context.WithTimeout(c.ctx, 5*time.Second)
+	defer cancel()
+	return client.Enroll(ctx, req)
+}
+
+func (c *GrpcClient) RotateKey(req *pb.RotateKeyRequest) (*pb.RotateKeyResponse, error) {
+	logger.Log.Print(1, "RotateKey..")
+	client, err := c.newServiceClient()
+	if err != nil {
+		logger.Log.Error("RotateKey error : %v", err)
+		return nil, fmt.Errorf("[RotateKey] error : %w", err)
+	}
+	ctx, cancel := context.WithTimeout(c.ctx, 5*time.Second)
+	defer cancel()
+	return client.RotateKey(ctx, req)
+}
@@ -0,0 +1,195 @@
+package gapi
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"docker_service/internal/logger"
+)
+
+// maxRecentErrors Status 에 보관할 최근 오류 수
+const maxRecentErrors = 10
+
+// UpstreamError 최근 오류 기록
+type UpstreamError struct {
+	At    time.Time `json:"at"`
+	Error string    `json:"error"`
+}
+
+// UpstreamListener 스트림 up/down 전환 알림 (up : 스트림 생성, down : 스트림 끊김)
+// 전환 시점에 호출되므로 blocking 하지 않아야 한다.
+type UpstreamListener func(up bool, addr, reason string)
+
+// upstreamState 운영자 제어(pause, 재연결) 및 연결 이력
+type upstreamState struct {
+	mu         sync.Mutex
+	paused     bool
+	wake       chan struct{} // pause/resume 시 close (txRoutine 깨우기)
+	lastRecv   time.Time     // 서버로부터 마지막 수신 (ACK, 명령, Register/Heartbeat 응답)
+	connected  bool          // 스트림 생성 이력 (첫 연결 이후 생성은 재연결)
+	reconnects uint64
+	upSince    time.Time
+	downSince  time.Time
+	listener   UpstreamListener
+}
+
+func (u *upstreamState) markRecv() {
+	u.mu.Lock()
+	u.lastRecv = time.Now()
+	u.mu.Unlock()
+}
+
+// SetUpstreamListener 스트림 up/down 전환 알림 설정
+func (c *GrpcClient) SetUpstreamListener(fn UpstreamListener) {
+	c.upstream.mu.Lock()
+	c.upstream.listener = fn
+	c.upstream.mu.Unlock()
+}
+
+// streamUp 스트림 생성 기록 및 up 알림
+func (c *GrpcClient) streamUp() {
+	u := &c.upstream
+	u.mu.Lock()
+	if u.connected {
+		u.reconnects++
+	}
+	u.connected = true
+	u.upSince = time.Now()
+	u.downSince = time.Time{}
+	fn := u.listener
+	u.mu.Unlock()
+
+	if fn != nil {
+		fn(true, c.getAddr(), "")
+	}
+}
+
+// streamDown 스트림 끊김 기록 및 down 알림 (addr : 끊긴 스트림의 서버 주소)
+func (c *GrpcClient) streamDown(addr, reason string) {
+	u := &c.upstream
+	u.mu.Lock()
+	u.downSince = time.Now()
+	u.upSince = time.Time{}
+	fn := u.listener
+	u.mu.Unlock()
+
+	if fn != nil {
+		fn(false, addr, reason)
+	}
+}
+
+// Paused 데이터 전송 중지 여부
+func (c *GrpcClient) Paused() bool {
+	c.upstream.mu.Lock()
+	defer c.upstream.mu.Unlock()
+	return c.upstream.paused
+}
+
+// Pause 데이터 전송 중지 (연결/Heartbeat 는 유지, 수집 데이터는 pipeline 큐에 쌓인다)
+func (c *GrpcClient) Pause() {
+	c.setPaused(true)
+	logger.Log.Print(2, "[upstream] transmission paused")
+}
+
+// Resume 데이터 전송 재개
+func (c *GrpcClient) Resume() {
+	c.setPaused(false)
+	logger.Log.Print(2, "[upstream] transmission resumed")
+}
+
+func (c *GrpcClient) setPaused(paused bool) {
+	u := &c.upstream
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.paused = paused
+	if u.wake != nil {
+		close(u.wake)
+	}
+	u.wake = make(chan struct{})
+}
+
+// pauseState txRoutine 용 : pause 여부와 상태 변경 알림 채널
+func (c *GrpcClient) pauseState() (bool, <-chan struct{}) {
+	u := &c.upstream
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if u.wake == nil {
+		u.wake = make(chan struct{})
+	}
+	return u.paused, u.wake
+}
+
+// Reconnect 스트림과 연결을 끊고 다시 연결 (manageConnect 가 재생성)
+func (c *GrpcClient) Reconnect() {
+	logger.Log.Print(2, "[upstream] reconnect requested")
+	c.mu.Lock()
+	addr := c.addr
+	hadStream := c.dropConnLocked()
+	c.mu.Unlock()
+
+	if hadStream {
+		c.streamDown(addr, "reconnect requested")
+	}
+}
+
+// dropConnLocked 스트림 해제 및 conn 종료 (c.mu 보유 상태에서 호출), 스트림이 있었으면 true
+func (c *GrpcClient) dropConnLocked() bool {
+	hadStream := c.stream != nil
+	if hadStream {
+		c.stats.recordStreamReset()
+	}
+	c.stream = nil
+	if c.conn != nil {
+		// 닫힌 conn 은 Shutdown 상태가 되어 manageConnect 가 새로 연결한다
+		_ = c.conn.Close()
+	}
+	return hadStream
+}
+
+// SetAddress 수집 서버 주소 변경 후 재연결 (재시작 없이 적용)
+func (c *GrpcClient) SetAddress(addr string) error {
+	addr = strings.TrimSpace(addr)
+	if addr == "" {
+		return fmt.Errorf("address is empty")
+	}
+	if !strings.Contains(addr, "://") {
+		if _, _, err := net.SplitHostPort(addr); err != nil {
+			return fmt.Errorf("invalid address %q: %w", addr, err)
+		}
+	}
+
+	// TLS 검증 이름이 주소에서 정해지므로 reloader 도 새 주소로 생성
+	var reloader *certReloader
+	if c.tlsCfg != nil {
+		r, err := newCertReloader(*c.tlsCfg, addr)
+		if err != nil {
+			return err
+		}
+		reloader = r
+	}
+
+	// 주소 변경과 연결 종료를 한 번에 처리 (이전 주소로 재연결되지 않도록)
+	c.mu.Lock()
+	old := c.addr
+	c.addr = addr
+	if reloader != nil {
+		c.reloader = reloader
+	}
+	hadStream := c.dropConnLocked()
+	c.mu.Unlock()
+
+	logger.Log.Print(2, "[upstream] address changed %s -> %s", old, addr)
+	if hadStream {
+		c.streamDown(old, "upstream address changed")
+	}
+	return nil
+}
+
+func (c *GrpcClient) getAddr() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.addr
+}
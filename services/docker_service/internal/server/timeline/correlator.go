@@ -0,0 +1,536 @@
+package timeline
+
+// 컨테이너 lifecycle 상관 분석
+// - EventManager 구독(timeline) 으로 container 이벤트를 모아 컨테이너별 timeline 유지
+//   (실행 구간, 종료 코드, OOM, 요청에 의한 종료 여부, 재시작 간격)
+// - window 안 crash 가 threshold 회 이상이면 crash loop, start 가 flap threshold 회 이상이면 flapping 으로 판단해
+//   lifecycle 이벤트(crashloop_detected, flapping_detected ...) 를 EventManager 에 발행한다.
+//   window 동안 crash(start) 가 없으면 해제(*_resolved) 한다.
+// - GET /containers/:hostid/:id/timeline
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"docker_service/internal/container"
+	"docker_service/internal/docker"
+	evt "docker_service/internal/event2"
+	"docker_service/internal/logger"
+)
+
+const (
+	defaultCrashLoopThreshold = 3
+	defaultWindow             = 10 * time.Minute
+	defaultFlapThreshold      = 6
+
+	maxRuns       = 50
+	maxEntries    = 100
+	maxIntervals  = 20
+	maxContainers = 5000 // 초과 시 destroy 된 컨테이너부터 제거
+
+	requestGrace  = 30 * time.Second // kill 후 이 시간 안의 종료는 요청에 의한 종료
+	oomGrace      = 10 * time.Second // oom 후 이 시간 안의 die 는 OOM 종료
+	lateEvent     = 5 * time.Second  // 이벤트 시각과 수신 시각 차이가 이보다 크면 이벤트 시각 사용 (재수신)
+	retention     = time.Hour        // destroy 된 컨테이너 timeline 보관
+	checkInterval = 30 * time.Second // 해제/보관 기간 확인 주기
+
+	subscriberID     = "timeline"
+	subscriberBuffer = 500
+)
+
+// tracker 컨테이너별 상태
+type tracker struct {
+	tl          Timeline // Runs, Events 는 오래된 순으로 보관 (조회 시 역순)
+	uptimeTotal float64
+	uptimeCount int
+	lastStart   time.Time
+	requestedAt time.Time // 마지막 kill
+	oomAt       time.Time // 마지막 oom
+	crashes     []time.Time
+	starts      []time.Time
+	destroyedAt time.Time
+}
+
+// Correlator 컨테이너 lifecycle 상관 분석기
+type Correlator struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     *sync.WaitGroup
+	done   chan struct{}
+	evtMgr *evt.EventManager
+
+	crashThreshold int
+	flapThreshold  int
+	window         time.Duration
+
+	mu       sync.RWMutex
+	trackers map[string]*tracker // host/container
+}
+
+// NewCorrelator 감지 기준(CRASHLOOP_THRESHOLD, CRASHLOOP_WINDOW, FLAP_THRESHOLD) 설정으로 생성
+func NewCorrelator(wg *sync.WaitGroup, ct *container.Container, evtMgr *evt.EventManager) *Correlator {
+	c := &Correlator{
+		wg:             wg,
+		done:           make(chan struct{}),
+		evtMgr:         evtMgr,
+		crashThreshold: ct.Config.CrashLoopThreshold,
+		flapThreshold:  ct.Config.FlapThreshold,
+		window:         ct.Config.CrashLoopWindow,
+		trackers:       make(map[string]*tracker),
+	}
+	if c.crashThreshold <= 0 {
+		c.crashThreshold = defaultCrashLoopThreshold
+	}
+	if c.flapThreshold <= 0 {
+		c.flapThreshold = defaultFlapThreshold
+	}
+	if c.window <= 0 {
+		c.window = defaultWindow
+	}
+	c.ctx, c.cancel = context.WithCancel(context.Background())
+	return c
+}
+
+// Start 이벤트 구독 시작 (ctx 종료 시 반환)
+func (c *Correlator) Start() error {
+	defer close(c.done)
+
+	sub := c.evtMgr.Subscribe(subscriberID, subscriberBuffer, func(e evt.ContainerEvent) bool {
+		return e.Type == "container"
+	})
+	defer c.evtMgr.Unsubscribe(subscriberID)
+
+	ticker := time.NewTicker(checkInterval)
+	defer ticker.Stop()
+
+	logger.Log.Print(3, "[Timeline] started, crash loop: %d/%v, flapping: %d/%v",
+		c.crashThreshold, c.window, c.flapThreshold, c.window)
+
+	for {
+		select {
+		case <-c.ctx.Done():
+			return nil
+		case e, ok := <-sub.Events:
+			if !ok {
+				return nil
+			}
+			c.handleEvent(e)
+		case <-ticker.C:
+			c.check(time.Now())
+		}
+	}
+}
+
+// Shutdown 구독 종료
+func (c *Correlator) Shutdown() error {
+	logger.Log.Print(3, "[Timeline] shutting down...")
+	defer c.wg.Done()
+
+	c.cancel()
+	<-c.done
+
+	logger.Log.Print(3, "[Timeline] shutdown complete")
+	return nil
+}
+
+func containerKey(host, id string) string {
+	return host + "/" + shortID(id)
+}
+
+func shortID(id string) string {
+	if len(id) > 12 {
+		return id[:12]
+	}
+	return id
+}
+
+// prune window 이전 시각 제거
+func prune(list []time.Time, window time.Duration, now time.Time) []time.Time {
+	n := 0
+	for _, t := range list {
+		if now.Sub(t) < window {
+			list[n] = t
+			n++
+		}
+	}
+	return list[:n]
+}
+
+// eventTime 이벤트 시각 (초 단위 timestamp 는 uptime 계산에 부정확하므로 늦게 수신된 경우에만 사용)
+func eventTime(e evt.ContainerEvent) time.Time {
+	now := time.Now()
+	if e.Timestamp > 0 {
+		if t := time.Unix(e.Timestamp, 0); now.Sub(t) > lateEvent {
+			return t
+		}
+	}
+	return now
+}
+
+func (c *Correlator) handleEvent(e evt.ContainerEvent) {
+	now := eventTime(e)
+	key := containerKey(e.Host, e.ActorID)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t, ok := c.trackers[key]
+	if !ok {
+		if e.Action == "destroy" {
+			return // 기록 없는 컨테이너
+		}
+		c.evict()
+		t = &tracker{tl: Timeline{
+			Host:        e.Host,
+			ContainerID: e.ActorID,
+			State:       StateCreated,
+			FirstSeen:   now,
+			Window:      c.window.String(),
+		}}
+		c.trackers[key] = t
+	}
+	if e.ActorName != "" {
+		t.tl.Name = e.ActorName
+	}
+	if img := e.Attrs["image"]; img != "" {
+		t.tl.Image = img
+	}
+	t.tl.UpdatedAt = now
+
+	detail, evaluate := "", false
+	switch e.Action {
+	case "create":
+		t.tl.State = StateCreated
+
+	case "start":
+		t.closeRun(now, nil) // die 이벤트 유실
+		t.tl.State = StateRunning
+		t.requestedAt, t.oomAt = time.Time{}, time.Time{}
+		t.tl.Runs = append(t.tl.Runs, Run{StartedAt: now})
+		if len(t.tl.Runs) > maxRuns {
+			t.tl.Runs = t.tl.Runs[len(t.tl.Runs)-maxRuns:]
+		}
+		s := &t.tl.Summary
+		s.Starts++
+		if !t.lastStart.IsZero() {
+			s.RestartIntervalsSec = append(s.RestartIntervalsSec, round(now.Sub(t.lastStart).Seconds()))
+			if len(s.RestartIntervalsSec) > maxIntervals {
+				s.RestartIntervalsSec = s.RestartIntervalsSec[len(s.RestartIntervalsSec)-maxIntervals:]
+			}
+			var sum float64
+			for _, v := range s.RestartIntervalsSec {
+				sum += v
+			}
+			s.MeanRestartIntervalSec = round(sum / float64(len(s.RestartIntervalsSec)))
+		}
+		t.lastStart = now
+		t.starts = append(prune(t.starts, c.window, now), now)
+		evaluate = true
+
+	case "die":
+		var code *int
+		if v, err := strconv.Atoi(e.Attrs["exitCode"]); err == nil {
+			code = &v
+			detail = "exitCode=" + e.Attrs["exitCode"]
+		}
+		if run := t.closeRun(now, code); run != nil {
+			if run.Crashed {
+				t.crashes = append(prune(t.crashes, c.window, now), now)
+			}
+			detail += fmt.Sprintf(" uptime=%gs", run.UptimeSec)
+			if run.OOMKilled {
+				detail += " oom"
+			}
+			if run.Requested {
+				detail += " requested"
+			}
+		}
+		t.tl.State = StateExited
+		evaluate = true
+
+	case "kill": // docker stop/restart/kill : kill -> die -> (stop)
+		t.requestedAt = now
+		if sig := e.Attrs["signal"]; sig != "" {
+			detail = "signal=" + sig
+			if n := len(t.tl.Runs); n > 0 && t.tl.Runs[n-1].EndedAt == nil {
+				t.tl.Runs[n-1].Signal = sig
+			}
+		}
+
+	case "oom":
+		t.oomAt = now
+		t.tl.Summary.OOMKills++
+
+	case "restart":
+		t.tl.Summary.Restarts++
+
+	case "pause":
+		t.tl.State = StatePaused
+	case "unpause":
+		t.tl.State = StateRunning
+
+	case docker.ActionHealthStatus:
+		detail = "health=" + e.Attrs[docker.AttrHealthStatus]
+
+	case "destroy":
+		t.closeRun(now, nil)
+		t.tl.State = StateDestroyed
+		t.destroyedAt = now
+	}
+
+	// 원본 이벤트를 먼저 기록한 뒤 파생 이벤트 판단
+	t.addEntry(Entry{Time: now, Action: e.Action, Detail: strings.TrimSpace(detail)})
+	if evaluate {
+		c.evaluate(t, now)
+	}
+	if e.Action == "destroy" {
+		c.resolve(t, now, "container destroyed")
+	}
+}
+
+// closeRun 실행 중인 구간 종료 (실행 중이 아니면 nil)
+func (t *tracker) closeRun(now time.Time, code *int) *Run {
+	n := len(t.tl.Runs)
+	if n == 0 || t.tl.Runs[n-1].EndedAt != nil {
+		return nil
+	}
+	run := &t.tl.Runs[n-1]
+	ended := now
+	run.EndedAt = &ended
+	run.UptimeSec = round(now.Sub(run.StartedAt).Seconds())
+	run.ExitCode = code
+	run.OOMKilled = !t.oomAt.IsZero() && now.Sub(t.oomAt) < oomGrace
+	run.Requested = !t.requestedAt.IsZero() && now.Sub(t.requestedAt) < requestGrace && !run.OOMKilled
+	run.Crashed = run.OOMKilled || (code != nil && *code != 0 && !run.Requested)
+
+	s := &t.tl.Summary
+	s.LastExitCode = code
+	s.LastUptimeSec = run.UptimeSec
+	t.uptimeTotal += run.UptimeSec
+	t.uptimeCount++
+	s.MeanUptimeSec = round(t.uptimeTotal / float64(t.uptimeCount))
+	if run.Crashed {
+		s.Crashes++
+	}
+	return run
+}
+
+func (t *tracker) addEntry(en Entry) {
+	t.tl.Events = append(t.tl.Events, en)
+	if len(t.tl.Events) > maxEntries {
+		t.tl.Events = t.tl.Events[len(t.tl.Events)-maxEntries:]
+	}
+}
+
+// evaluate crash loop / flapping 감지 - c.mu 보유 상태에서 호출
+func (c *Correlator) evaluate(t *tracker, now time.Time) {
+	t.crashes = prune(t.crashes, c.window, now)
+	t.starts = prune(t.starts, c.window, now)
+
+	if !t.tl.Status.CrashLoop && len(t.crashes) >= c.crashThreshold {
+		since := t.crashes[0]
+		t.tl.Status.CrashLoop = true
+		t.tl.Status.CrashLoopSince = &since
+		t.tl.Status.Reason = c.crashReason(t)
+		c.emit(t, now, ActionCrashLoopDetected, map[string]string{
+			"crashes": strconv.Itoa(len(t.crashes)),
+			"reason":  t.tl.Status.Reason,
+		})
+	}
+	if !t.tl.Status.Flapping && len(t.starts) >= c.flapThreshold {
+		since := t.starts[0]
+		t.tl.Status.Flapping = true
+		t.tl.Status.FlappingSince = &since
+		t.tl.Status.Reason = fmt.Sprintf("%d starts in %v, mean interval %gs",
+			len(t.starts), c.window, t.tl.Summary.MeanRestartIntervalSec)
+		c.emit(t, now, ActionFlappingDetected, map[string]string{
+			"starts": strconv.Itoa(len(t.starts)),
+			"reason": t.tl.Status.Reason,
+		})
+	}
+}
+
+// crashReason crash loop 판단 근거
+func (c *Correlator) crashReason(t *tracker) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d crashes in %v", len(t.crashes), c.window)
+
+	// window 안 crash 구간의 평균 uptime, OOM 횟수
+	var sum float64
+	n, oom := 0, 0
+	for _, r := range t.tl.Runs {
+		if !r.Crashed || r.EndedAt == nil || len(t.crashes) == 0 || r.EndedAt.Before(t.crashes[0]) {
+			continue
+		}
+		sum += r.UptimeSec
+		n++
+		if r.OOMKilled {
+			oom++
+		}
+	}
+	if n > 0 {
+		fmt.Fprintf(&b, ", mean uptime %gs", round(sum/float64(n)))
+	}
+	if oom > 0 {
+		fmt.Fprintf(&b, ", oom %d", oom)
+	}
+	if code := t.tl.Summary.LastExitCode; code != nil {
+		fmt.Fprintf(&b, ", last exit %d", *code)
+	}
+	return b.String()
+}
+
+// resolve crash loop / flapping 해제 - c.mu 보유 상태에서 호출
+func (c *Correlator) resolve(t *tracker, now time.Time, reason string) {
+	if t.tl.Status.CrashLoop {
+		t.tl.Status.CrashLoop = false
+		t.tl.Status.CrashLoopSince = nil
+		c.emit(t, now, ActionCrashLoopResolved, map[string]string{"reason": reason})
+	}
+	if t.tl.Status.Flapping {
+		t.tl.Status.Flapping = false
+		t.tl.Status.FlappingSince = nil
+		c.emit(t, now, ActionFlappingResolved, map[string]string{"reason": reason})
+	}
+}
+
+// check window 동안 crash/start 가 없으면 해제, 보관 기간이 지난 timeline 제거
+func (c *Correlator) check(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, t := range c.trackers {
+		if !t.destroyedAt.IsZero() {
+			if now.Sub(t.destroyedAt) > retention {
+				delete(c.trackers, key)
+			}
+			continue
+		}
+
+		t.crashes = prune(t.crashes, c.window, now)
+		t.starts = prune(t.starts, c.window, now)
+		reason := fmt.Sprintf("stable for %v", c.window)
+		if t.tl.Status.CrashLoop && len(t.crashes) == 0 {
+			t.tl.Status.CrashLoop = false
+			t.tl.Status.CrashLoopSince = nil
+			c.emit(t, now, ActionCrashLoopResolved, map[string]string{"reason": reason})
+		}
+		if t.tl.Status.Flapping && len(t.starts) == 0 {
+			t.tl.Status.Flapping = false
+			t.tl.Status.FlappingSince = nil
+			c.emit(t, now, ActionFlappingResolved, map[string]string{"reason": reason})
+		}
+		if !t.tl.Status.CrashLoop && !t.tl.Status.Flapping {
+			t.tl.Status.Reason = ""
+		}
+	}
+}
+
+// evict 컨테이너 수 제한 - destroy 된 컨테이너 중 오래된 것부터, 없으면 가장 오래 갱신되지 않은 것 제거
+func (c *Correlator) evict() {
+	if len(c.trackers) < maxContainers {
+		return
+	}
+	var oldest string
+	var oldestTime time.Time
+	destroyed := false
+	for key, t := range c.trackers {
+		isDestroyed := !t.destroyedAt.IsZero()
+		if destroyed && !isDestroyed {
+			continue
+		}
+		if oldest == "" || (isDestroyed && !destroyed) || t.tl.UpdatedAt.Before(oldestTime) {
+			oldest, oldestTime, destroyed = key, t.tl.UpdatedAt, isDestroyed
+		}
+	}
+	delete(c.trackers, oldest)
+}
+
+// emit lifecycle 이벤트 발행 및 timeline 기록 - c.mu 보유 상태에서 호출
+func (c *Correlator) emit(t *tracker, now time.Time, action string, attrs map[string]string) {
+	attrs["name"] = t.tl.Name
+	if t.tl.Image != "" {
+		attrs["image"] = t.tl.Image
+	}
+	attrs["window"] = c.window.String()
+	if code := t.tl.Summary.LastExitCode; code != nil {
+		attrs["exitCode"] = strconv.Itoa(*code)
+	}
+
+	t.addEntry(Entry{Time: now, Action: action, Detail: attrs["reason"]})
+	if strings.HasSuffix(action, "_detected") {
+		logger.Log.Warn("[Timeline] %s/%s %s: %s", t.tl.Host, t.tl.Name, action, attrs["reason"])
+	} else {
+		logger.Log.Print(3, "[Timeline] %s/%s %s: %s", t.tl.Host, t.tl.Name, action, attrs["reason"])
+	}
+
+	c.evtMgr.Publish(evt.ContainerEvent{
+		Host:      t.tl.Host,
+		Type:      evt.TypeLifecycle,
+		Action:    action,
+		ActorID:   t.tl.ContainerID,
+		ActorName: t.tl.Name,
+		Timestamp: now.Unix(),
+		Attrs:     attrs,
+	})
+}
+
+// Window crash loop / flapping 집계 구간
+func (c *Correlator) Window() time.Duration {
+	return c.window
+}
+
+// Get 컨테이너 timeline (id : 컨테이너 ID(prefix) 또는 이름, 같은 이름이면 최근 갱신된 컨테이너)
+func (c *Correlator) Get(host, id string) (Timeline, bool) {
+	id = strings.TrimPrefix(id, "/")
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var found *tracker
+	for _, t := range c.trackers {
+		if t.tl.Host != host {
+			continue
+		}
+		if !strings.HasPrefix(t.tl.ContainerID, id) && strings.TrimPrefix(t.tl.Name, "/") != id {
+			continue
+		}
+		if found == nil || t.tl.UpdatedAt.After(found.tl.UpdatedAt) {
+			found = t
+		}
+	}
+	if found == nil {
+		return Timeline{}, false
+	}
+	return found.snapshot(time.Now(), c.window), true
+}
+
+// snapshot 조회용 복사본 (최신 먼저) - c.mu 보유 상태에서 호출
+func (t *tracker) snapshot(now time.Time, window time.Duration) Timeline {
+	tl := t.tl
+	tl.Summary.RestartIntervalsSec = append([]float64(nil), t.tl.Summary.RestartIntervalsSec...)
+	tl.Summary.CrashesInWindow = len(prune(append([]time.Time(nil), t.crashes...), window, now))
+	tl.Summary.StartsInWindow = len(prune(append([]time.Time(nil), t.starts...), window, now))
+
+	tl.Runs = make([]Run, len(t.tl.Runs))
+	for i, r := range t.tl.Runs {
+		if r.EndedAt == nil {
+			r.UptimeSec = round(now.Sub(r.StartedAt).Seconds())
+		}
+		tl.Runs[len(t.tl.Runs)-1-i] = r
+	}
+	tl.Events = make([]Entry, len(t.tl.Events))
+	for i, en := range t.tl.Events {
+		tl.Events[len(t.tl.Events)-1-i] = en
+	}
+	return tl
+}
+
+// round 소수점 1자리
+func round(v float64) float64 {
+	return float64(int64(v*10+0.5)) / 10
+}
@@ -0,0 +1,79 @@
+package timeline
+
+import "time"
+
+// lifecycle 이벤트 Action (evt.TypeLifecycle)
+const (
+	ActionCrashLoopDetected = "crashloop_detected"
+	ActionCrashLoopResolved = "crashloop_resolved"
+	ActionFlappingDetected  = "flapping_detected"
+	ActionFlappingResolved  = "flapping_resolved"
+)
+
+// 컨테이너 상태 (Timeline.State)
+const (
+	StateCreated   = "created"
+	StateRunning   = "running"
+	StatePaused    = "paused"
+	StateExited    = "exited"
+	StateDestroyed = "destroyed"
+)
+
+// Run 실행 구간 (start ~ die)
+type Run struct {
+	StartedAt time.Time  `json:"started_at"`
+	EndedAt   *time.Time `json:"ended_at,omitempty"` // nil : 실행 중
+	UptimeSec float64    `json:"uptime_sec"`         // 실행 중이면 현재까지
+	ExitCode  *int       `json:"exit_code,omitempty"`
+	Signal    string     `json:"signal,omitempty"` // kill signal
+	OOMKilled bool       `json:"oom_killed,omitempty"`
+	Requested bool       `json:"requested,omitempty"` // stop/kill 요청에 의한 종료
+	Crashed   bool       `json:"crashed,omitempty"`   // exitCode != 0 (요청 제외) 또는 OOM
+}
+
+// Entry 이벤트 기록 (docker 이벤트 + lifecycle 이벤트)
+type Entry struct {
+	Time   time.Time `json:"time"`
+	Action string    `json:"action"`
+	Detail string    `json:"detail,omitempty"` // ex: exitCode=137, health=unhealthy
+}
+
+// Summary 집계 (agent 기동 또는 컨테이너 최초 이벤트 이후)
+type Summary struct {
+	Starts                 int       `json:"starts"`
+	Crashes                int       `json:"crashes"`
+	OOMKills               int       `json:"oom_kills"`
+	Restarts               int       `json:"restarts"` // docker restart
+	LastExitCode           *int      `json:"last_exit_code,omitempty"`
+	LastUptimeSec          float64   `json:"last_uptime_sec"`
+	MeanUptimeSec          float64   `json:"mean_uptime_sec"`                 // 종료된 실행 구간 평균
+	RestartIntervalsSec    []float64 `json:"restart_intervals_sec,omitempty"` // 연속 start 간격 (최근 20개, 오래된 순)
+	MeanRestartIntervalSec float64   `json:"mean_restart_interval_sec"`
+	CrashesInWindow        int       `json:"crashes_in_window"`
+	StartsInWindow         int       `json:"starts_in_window"`
+}
+
+// Status crash loop / flapping 상태
+type Status struct {
+	CrashLoop      bool       `json:"crash_loop"`
+	CrashLoopSince *time.Time `json:"crash_loop_since,omitempty"`
+	Flapping       bool       `json:"flapping"`
+	FlappingSince  *time.Time `json:"flapping_since,omitempty"`
+	Reason         string     `json:"reason,omitempty"` // 최근 판단 근거
+}
+
+// Timeline 컨테이너 lifecycle
+type Timeline struct {
+	Host        string    `json:"host"`
+	ContainerID string    `json:"container_id"`
+	Name        string    `json:"name"`
+	Image       string    `json:"image,omitempty"`
+	State       string    `json:"state"`
+	FirstSeen   time.Time `json:"first_seen"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	Window      string    `json:"window"` // crash loop / flapping 집계 구간
+	Status      Status    `json:"status"`
+	Summary     Summary   `json:"summary"`
+	Runs        []Run     `json:"runs"`   // 최신 먼저 (최근 50개)
+	Events      []Entry   `json:"events"` // 최신 먼저 (최근 100개)
+}
@@ -15,10 +15,15 @@ const (
 	pingPeriod = 50 * time.Second
 )
 
+const maxMessageSize = 4096 // 수신 메시지 최대 크기 (구독 필터 메시지)
+
 type Client struct {
 	Hub  *Hub
 	Conn *websocket.Conn
 	Send chan []byte
+
+	OnMessage func(msg []byte) // 수신 메시지 처리 (nil : echo)
+	OnClose   func()           // 연결 종료 시 호출 (구독 해제 등)
 }
 
 func (c *Client) WsRead() {
@@ -31,9 +36,12 @@ func (c *Client) WsRead() {
 		case <-c.Hub.Ctx.Done():
 			// hub 종료 중 → 보내지 않기위해
 		}
+		if c.OnClose != nil {
+			c.OnClose()
+		}
 	}()
 
-	c.Conn.SetReadLimit(512)
+	c.Conn.SetReadLimit(maxMessageSize)
 	c.Conn.SetReadDeadline(time.Now().Add(pongWait))
 	c.Conn.SetPongHandler(func(string) error {
 		c.Conn.SetReadDeadline(time.Now().Add(pongWait))
@@ -50,6 +58,10 @@ func (c *Client) WsRead() {
 		}
 		logger.Log.Print(2, "[client] recv:%s", string(msg))
 
+		if c.OnMessage != nil {
+			c.OnMessage(msg)
+			continue
+		}
 		res := fmt.Sprintf("%s hello", msg)
 		c.Send <- []byte(res)
 	}
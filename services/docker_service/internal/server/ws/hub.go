@@ -12,16 +12,24 @@ type Hub struct {
 	Register   chan *Client
 	Unregister chan *Client
 	broadcast  chan []byte
+	direct     chan directMsg
 	Ctx        context.Context
 	closing    atomic.Bool
 }
 
+// directMsg 특정 클라이언트 전송 메시지
+type directMsg struct {
+	client *Client
+	msg    []byte
+}
+
 func NewHub(ctx context.Context) *Hub {
 	return &Hub{
 		clients:    make(map[*Client]bool),
 		Register:   make(chan *Client),
 		Unregister: make(chan *Client),
 		broadcast:  make(chan []byte), // 전체 클라이언트 broadcase
+		direct:     make(chan directMsg),
 		Ctx:        ctx,
 	}
 }
@@ -31,6 +39,14 @@ func (h *Hub) Broadcast(msg []byte) {
 	h.broadcast <- msg
 }
 
+// SendTo 특정 클라이언트에 전송 (등록 해제된 클라이언트면 버림, 버퍼 full 이면 broadcast 와 같이 연결 정리)
+func (h *Hub) SendTo(c *Client, msg []byte) {
+	select {
+	case h.direct <- directMsg{client: c, msg: msg}:
+	case <-h.Ctx.Done():
+	}
+}
+
 func (h *Hub) Run() {
 	logger.Log.Print(2, "hub run...")
 	for {
@@ -62,6 +78,19 @@ func (h *Hub) Run() {
 				logger.Log.Print(2, "[hub] client disconnected:", len(h.clients))
 			}
 
+		case d := <-h.direct:
+			if _, ok := h.clients[d.client]; !ok {
+				continue
+			}
+			select {
+			case d.client.Send <- d.msg:
+			default:
+				delete(h.clients, d.client)
+				close(d.client.Send)
+				d.client.Close()
+				logger.Log.Warn("[hub] client send buffer full, disconnected: %d", len(h.clients))
+			}
+
 		case msg := <-h.broadcast:
 			var deadClients []*Client
 
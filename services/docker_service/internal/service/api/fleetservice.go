@@ -0,0 +1,300 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"docker_service/internal/docker"
+	"docker_service/internal/service"
+)
+
+const (
+	DefaultFleetLimit   = 50
+	MaxFleetLimit       = 500
+	DefaultFleetTimeout = 3 * time.Second
+	MaxFleetTimeout     = 4 * time.Second // http WriteTimeout(5초) 이내
+
+	fleetWorkers = 16 // 호스트별 inspect/stats 동시 요청 수
+)
+
+// FleetContainers 모든 호스트의 컨테이너를 동시에 조회해 필터/정렬/페이지 적용
+// 호스트마다 q.Timeout 안에 조회하며, 실패한 호스트는 Hosts 에 오류로 남기고 나머지 호스트로 응답한다.
+func (s *ApiService) FleetContainers(ctx context.Context, q service.FleetQuery) (service.FleetPage, error) {
+	if err := normalizeFleetQuery(&q); err != nil {
+		return service.FleetPage{}, err
+	}
+	labels, err := docker.NewContainerFilter(docker.FilterRules{IncludeLabels: q.Labels})
+	if err != nil {
+		return service.FleetPage{}, fmt.Errorf("label: %w", err)
+	}
+	after, err := decodeFleetCursor(q)
+	if err != nil {
+		return service.FleetPage{}, err
+	}
+
+	hosts := q.Hosts
+	if len(hosts) == 0 {
+		hosts = s.docMng.GetHostNames()
+	}
+	sort.Strings(hosts)
+
+	type hostResult struct {
+		containers []service.FleetContainer
+		status     service.FleetHost
+	}
+	results := make([]hostResult, len(hosts))
+
+	var wg sync.WaitGroup
+	for i, host := range hosts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i].containers, results[i].status = s.fleetHost(ctx, host, q, labels)
+		}()
+	}
+	wg.Wait()
+
+	page := service.FleetPage{Hosts: make([]service.FleetHost, 0, len(hosts))}
+	var all []service.FleetContainer
+	for _, r := range results {
+		page.Hosts = append(page.Hosts, r.status)
+		all = append(all, r.containers...)
+	}
+	page.Total = len(all)
+
+	sort.Slice(all, func(i, j int) bool {
+		return fleetLess(q, keyOf(q, all[i]), keyOf(q, all[j]))
+	})
+
+	start := 0
+	if after != nil {
+		start = sort.Search(len(all), func(i int) bool {
+			return fleetLess(q, *after, keyOf(q, all[i]))
+		})
+	}
+	end := min(start+q.Limit, len(all))
+	page.Containers = all[start:end]
+	if end < len(all) {
+		page.NextCursor = encodeFleetCursor(keyOf(q, all[end-1]))
+	}
+	return page, nil
+}
+
+// fleetHost 단일 호스트 조회 (목록 → 조건 → running 컨테이너 상세)
+func (s *ApiService) fleetHost(ctx context.Context, host string, q service.FleetQuery, labels *docker.ContainerFilter) ([]service.FleetContainer, service.FleetHost) {
+	begin := time.Now()
+	status := service.FleetHost{Host: host}
+
+	hctx, cancel := context.WithTimeout(ctx, q.Timeout)
+	defer cancel()
+
+	client, err := s.docMng.Get(host)
+	if err != nil {
+		status.Error = err.Error()
+		status.Elapsed = time.Since(begin)
+		return nil, status
+	}
+	list, err := client.ListContainers(hctx)
+	if err != nil {
+		status.Error = err.Error()
+		status.Elapsed = time.Since(begin)
+		return nil, status
+	}
+	status.OK = true
+
+	var matched []service.FleetContainer
+	for _, c := range list {
+		if matchFleet(q, labels, c) {
+			matched = append(matched, service.FleetContainer{Host: host, Container: c})
+		}
+	}
+	status.Containers = len(matched)
+
+	// running 컨테이너 상세 (uptime, health, stats) : deadline 안에 조회한 것만 채운다
+	withStats := q.Stats || q.Sort == service.FleetSortCPU || q.Sort == service.FleetSortMemory
+	sem := make(chan struct{}, fleetWorkers)
+	var missed atomic.Int32
+	var dwg sync.WaitGroup
+	for i := range matched {
+		if matched[i].State != "running" {
+			continue
+		}
+		dwg.Add(1)
+		go func() {
+			defer dwg.Done()
+			select {
+			case sem <- struct{}{}:
+			case <-hctx.Done():
+				missed.Add(1)
+				return
+			}
+			defer func() { <-sem }()
+
+			if !s.fleetDetail(hctx, client, &matched[i], withStats) {
+				missed.Add(1)
+			}
+		}()
+	}
+	dwg.Wait()
+
+	if n := missed.Load(); n > 0 {
+		status.Partial = true
+		status.Error = fmt.Sprintf("details of %d containers omitted", n)
+		if hctx.Err() != nil {
+			status.Error += fmt.Sprintf(" (timeout %v)", q.Timeout)
+		}
+	}
+	status.Elapsed = time.Since(begin)
+	return matched, status
+}
+
+// fleetDetail inspect(uptime, health) 와 stats 조회, 실패하면 false
+func (s *ApiService) fleetDetail(ctx context.Context, client *docker.Client, c *service.FleetContainer, withStats bool) bool {
+	res, err := client.InspectContainer(ctx, c.ID)
+	if err != nil {
+		return false
+	}
+	inspect := docker.ConvertInspectResult(res)
+	if inspect.State != nil {
+		c.Health = inspect.State.Health
+		if t, err := time.Parse(time.RFC3339Nano, inspect.State.StartedAt); err == nil && !t.IsZero() {
+			c.StartedAt = &t
+			c.UptimeSec = time.Since(t).Seconds()
+		}
+	}
+	if !withStats {
+		return true
+	}
+
+	stats, err := s.ContainerStats2(ctx, c.Host, c.ID, false)
+	if err != nil {
+		return false
+	}
+	stats.ID = c.ID
+	stats.Name = c.Name
+	c.Stats = stats
+	return true
+}
+
+func normalizeFleetQuery(q *service.FleetQuery) error {
+	if q.Limit <= 0 {
+		q.Limit = DefaultFleetLimit
+	}
+	if q.Limit > MaxFleetLimit {
+		q.Limit = MaxFleetLimit
+	}
+	if q.Timeout <= 0 {
+		q.Timeout = DefaultFleetTimeout
+	}
+	if q.Timeout > MaxFleetTimeout {
+		q.Timeout = MaxFleetTimeout
+	}
+	switch q.Sort {
+	case "":
+		q.Sort = service.FleetSortName
+	case service.FleetSortName, service.FleetSortCPU, service.FleetSortMemory, service.FleetSortUptime:
+	default:
+		return fmt.Errorf("invalid sort %q", q.Sort)
+	}
+	q.Name = strings.ToLower(q.Name)
+	q.Image = strings.ToLower(q.Image)
+	return nil
+}
+
+func matchFleet(q service.FleetQuery, labels *docker.ContainerFilter, c docker.Container) bool {
+	if len(q.States) > 0 && !docker.Contains(q.States, c.State) {
+		return false
+	}
+	if q.Name != "" && !strings.Contains(strings.ToLower(c.Name), q.Name) {
+		return false
+	}
+	if q.Image != "" && !strings.Contains(strings.ToLower(c.Image), q.Image) {
+		return false
+	}
+	return labels.Match("", "", c.Labels)
+}
+
+// fleetKey 정렬 키 (cursor 에 그대로 저장)
+type fleetKey struct {
+	Sort  string  `json:"s"`
+	Desc  bool    `json:"d,omitempty"`
+	Name  string  `json:"n"`
+	Value float64 `json:"v,omitempty"` // cpu, memory (stats 없음 : -1), uptime (-시작 시각)
+	Host  string  `json:"h"`
+	ID    string  `json:"i"`
+}
+
+func keyOf(q service.FleetQuery, c service.FleetContainer) fleetKey {
+	k := fleetKey{Sort: q.Sort, Desc: q.Desc, Name: c.Name, Host: c.Host, ID: c.ID}
+	switch q.Sort {
+	case service.FleetSortCPU:
+		k.Value = -1
+		if c.Stats != nil {
+			k.Value = c.Stats.CPUPercent
+		}
+	case service.FleetSortMemory:
+		k.Value = -1
+		if c.Stats != nil {
+			k.Value = float64(c.Stats.MemoryUsage)
+		}
+	case service.FleetSortUptime:
+		// 요청마다 달라지는 uptime 대신 시작 시각으로 비교 (cursor 유지), 실행 중이 아니면 가장 작은 값
+		k.Value = -math.MaxFloat64
+		if c.StartedAt != nil {
+			k.Value = -float64(c.StartedAt.UnixNano()) / 1e9
+		}
+	}
+	return k
+}
+
+// fleetLess 정렬 기준 → 이름 → 호스트 → ID 순 (desc 는 정렬 기준에만 적용)
+func fleetLess(q service.FleetQuery, a, b fleetKey) bool {
+	if q.Sort != service.FleetSortName && a.Value != b.Value {
+		if q.Desc {
+			return a.Value > b.Value
+		}
+		return a.Value < b.Value
+	}
+	if a.Name != b.Name {
+		if q.Sort == service.FleetSortName && q.Desc {
+			return a.Name > b.Name
+		}
+		return a.Name < b.Name
+	}
+	if a.Host != b.Host {
+		return a.Host < b.Host
+	}
+	return a.ID < b.ID
+}
+
+// encodeFleetCursor 마지막 항목의 정렬 키 (다음 페이지는 이 키 이후부터)
+func encodeFleetCursor(k fleetKey) string {
+	b, _ := json.Marshal(k)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeFleetCursor(q service.FleetQuery) (*fleetKey, error) {
+	if q.Cursor == "" {
+		return nil, nil
+	}
+	b, err := base64.RawURLEncoding.DecodeString(q.Cursor)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor %q", q.Cursor)
+	}
+	var k fleetKey
+	if err := json.Unmarshal(b, &k); err != nil {
+		return nil, fmt.Errorf("invalid cursor %q", q.Cursor)
+	}
+	if k.Sort != q.Sort || k.Desc != q.Desc {
+		return nil, fmt.Errorf("cursor does not match sort/order")
+	}
+	return &k, nil
+}
@@ -1 +1,60 @@
 package service
+
+import (
+	"time"
+
+	"docker_service/internal/docker"
+)
+
+// fleet 컨테이너 조회 정렬 기준
+const (
+	FleetSortName   = "name"
+	FleetSortCPU    = "cpu"
+	FleetSortMemory = "memory"
+	FleetSortUptime = "uptime"
+)
+
+// FleetQuery 전체 호스트 컨테이너 조회 조건 (빈 값 : 조건 없음)
+type FleetQuery struct {
+	Hosts   []string // 호스트 이름
+	States  []string // running, exited, paused ...
+	Name    string   // 컨테이너 이름 부분 문자열 (대소문자 무시)
+	Image   string   // 이미지 부분 문자열 (대소문자 무시)
+	Labels  []string // label selector (key, key=value, key!=value, !key), 모두 일치
+	Sort    string   // name(기본), cpu, memory, uptime
+	Desc    bool
+	Stats   bool          // cpu/memory 조회 (sort=cpu, memory 이면 항상 조회)
+	Timeout time.Duration // 호스트별 deadline
+	Limit   int
+	Cursor  string // 이전 페이지의 next_cursor
+}
+
+// FleetContainer 호스트 정보가 포함된 컨테이너
+type FleetContainer struct {
+	Host string
+	docker.Container
+
+	Health    string     // healthy, unhealthy, starting ("" : healthcheck 없음 또는 미조회)
+	StartedAt *time.Time // running 컨테이너만
+	UptimeSec float64
+
+	Stats *docker.ContainerStats // nil : 미조회 또는 조회 실패
+}
+
+// FleetHost 호스트별 조회 결과
+type FleetHost struct {
+	Host       string
+	OK         bool   // 목록 조회 성공 여부
+	Partial    bool   // deadline 초과 등으로 일부 상세(uptime, stats) 누락
+	Error      string // 실패/누락 사유
+	Containers int    // 조건에 일치한 컨테이너 수
+	Elapsed    time.Duration
+}
+
+// FleetPage 조회 결과
+type FleetPage struct {
+	Containers []FleetContainer
+	Hosts      []FleetHost
+	Total      int    // 조건에 일치한 전체 컨테이너 수 (조회 성공 호스트 기준)
+	NextCursor string // "" : 마지막 페이지
+}
@@ -22,6 +22,8 @@ type ServiceInterface interface {
 
 	ContainerStatsStream(ctx context.Context, id string, stream bool, ch_rst chan *docker.ContainerStats) error
 
+	FleetContainers(ctx context.Context, q FleetQuery) (FleetPage, error)
+
 	EventStream(ctx context.Context, host string)
 
 	CreateUser(ctx context.Context, arg db.CreateUserParams) (db.User, error)
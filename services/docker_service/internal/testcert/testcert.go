@@ -0,0 +1,109 @@
+// Package testcert 테스트/검증용 임시 CA 와 인증서 발급 (go test, loadtest -tls-check 공용)
+package testcert
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"sync/atomic"
+	"time"
+)
+
+var serial atomic.Int64
+
+// Cert 발급된 인증서 (PEM, SPKI pin 포함)
+type Cert struct {
+	X509    *x509.Certificate
+	Key     *ecdsa.PrivateKey
+	CertPEM []byte
+	KeyPEM  []byte
+	Pin     string // base64(sha256(SubjectPublicKeyInfo))
+}
+
+// CA 인증서 발급용 CA
+type CA struct {
+	Cert
+}
+
+// NewCA self-signed CA 생성
+func NewCA(cn string) (*CA, error) {
+	c, err := create(&x509.Certificate{
+		SerialNumber:          big.NewInt(serial.Add(1)),
+		Subject:               pkix.Name{CommonName: cn},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &CA{Cert: *c}, nil
+}
+
+// Issue leaf 인증서 발급 (notAfter 가 과거면 만료 인증서)
+func (ca *CA) Issue(cn string, dnsNames []string, ips []net.IP, notAfter time.Time, usage x509.ExtKeyUsage) (*Cert, error) {
+	return create(&x509.Certificate{
+		SerialNumber: big.NewInt(serial.Add(1)),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-48 * time.Hour),
+		NotAfter:     notAfter,
+		DNSNames:     dnsNames,
+		IPAddresses:  ips,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+	}, &ca.Cert)
+}
+
+// Pool CA 만 담은 인증서 풀
+func (ca *CA) Pool() *x509.CertPool {
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.X509)
+	return pool
+}
+
+// KeyPair tls.Config 용 인증서
+func (c *Cert) KeyPair() (tls.Certificate, error) {
+	return tls.X509KeyPair(c.CertPEM, c.KeyPEM)
+}
+
+// create tmpl 로 인증서 생성 (parent 가 nil 이면 self-signed)
+func create(tmpl *x509.Certificate, parent *Cert) (*Cert, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	signer, signerKey := tmpl, key
+	if parent != nil {
+		signer, signerKey = parent.X509, parent.Key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, signer, &key.PublicKey, signerKey)
+	if err != nil {
+		return nil, err
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, err
+	}
+	kb, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+	return &Cert{
+		X509:    cert,
+		Key:     key,
+		CertPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		KeyPEM:  pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: kb}),
+		Pin:     base64.StdEncoding.EncodeToString(sum[:]),
+	}, nil
+}
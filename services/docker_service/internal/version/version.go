@@ -0,0 +1,4 @@
+package version
+
+// Version agent 버전 (빌드 시 주입 : -ldflags "-X docker_service/internal/version.Version=1.2.0")
+var Version = "0.1.0"
@@ -20,14 +20,15 @@ type SimAgent struct {
 	pipeCh   chan pipeline.Message
 	client   *gapi.GrpcClient
 	gen      *Generator
-	rateMs   time.Duration
+	scenario *Scenario
+	metrics  *Metrics
 	clientWg sync.WaitGroup
 }
 
 // NewSimAgent creates a SimAgent with agentId in range [2, 1001].
 // It reuses the production GrpcClient unchanged; only the Container.Bus
 // field is initialised since GrpcClient does not use any other field.
-func NewSimAgent(id int, addr string, m *Metrics, containers, rateMs int) (*SimAgent, error) {
+func NewSimAgent(id int, addr string, m *Metrics, containers int, sc *Scenario, opts ...gapi.ClientOption) (*SimAgent, error) {
 	pipeCh := make(chan pipeline.Message, 50)
 
 	ct := &container.Container{
@@ -35,20 +36,25 @@ func NewSimAgent(id int, addr string, m *Metrics, containers, rateMs int) (*SimA
 	}
 
 	a := &SimAgent{
-		id:     id,
-		pipeCh: pipeCh,
-		gen:    NewGenerator(id, containers),
-		rateMs: time.Duration(rateMs) * time.Millisecond,
+		id:       id,
+		pipeCh:   pipeCh,
+		gen:      NewGenerator(id, containers),
+		scenario: sc,
+		metrics:  m,
 	}
 
 	a.clientWg.Add(1)
+	opts = append([]gapi.ClientOption{
+		gapi.WithUnaryInterceptor(m.Interceptor()),
+		gapi.WithStreamInterceptor(m.StreamInterceptor()),
+	}, opts...)
 	client, err := gapi.NewClient(
 		&a.clientWg,
 		ct,
 		pipeCh,
 		addr,
 		fmt.Sprintf("loadtest-%04d", id),
-		gapi.WithUnaryInterceptor(m.Interceptor()),
+		opts...,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("agent %d NewClient: %w", id, err)
@@ -65,10 +71,42 @@ func (a *SimAgent) Run(ctx context.Context, wg *sync.WaitGroup) {
 	// GrpcClient runs in its own goroutine and calls clientWg.Done on exit.
 	go a.client.Start()
 
-	ticker := time.NewTicker(a.rateMs)
-	defer ticker.Stop()
+	phaseIdx := -1
+	var (
+		ticker *time.Ticker
+		storm  *time.Ticker
+		stormC <-chan time.Time
+	)
+	defer func() {
+		ticker.Stop()
+		if storm != nil {
+			storm.Stop()
+		}
+	}()
 
 	for {
+		// Switch rate / mix / event storm when the scenario moves to the next phase.
+		if cur := a.scenario.Current(); cur != phaseIdx {
+			phaseIdx = cur
+			p := a.scenario.Phases[cur]
+			if ticker == nil {
+				ticker = time.NewTicker(time.Duration(p.RateMs) * time.Millisecond)
+			} else {
+				ticker.Reset(time.Duration(p.RateMs) * time.Millisecond)
+			}
+			a.gen.SetMix(p.Mix)
+
+			if storm != nil {
+				storm.Stop()
+				storm, stormC = nil, nil
+			}
+			// (id*37)%100 spreads the selected agents evenly even for small agent counts
+			if s := p.EventStorm; s != nil && (s.AgentsPct <= 0 || a.id*37%100 < s.AgentsPct) {
+				storm = time.NewTicker(time.Duration(s.RateMs) * time.Millisecond)
+				stormC = storm.C
+			}
+		}
+
 		select {
 		case <-ctx.Done():
 			a.client.Shutdown()
@@ -76,13 +114,20 @@ func (a *SimAgent) Run(ctx context.Context, wg *sync.WaitGroup) {
 			return
 
 		case <-ticker.C:
-			msg := a.gen.Next()
-			select {
-			case a.pipeCh <- msg:
-			default:
-				// pipeCh is full; GrpcClient applies backpressure internally.
-				// Drop this tick rather than blocking the generator.
-			}
+			a.push(a.gen.Next())
+
+		case <-stormC:
+			a.push(a.gen.NextEvent())
 		}
 	}
 }
+
+func (a *SimAgent) push(msg pipeline.Message) {
+	select {
+	case a.pipeCh <- msg:
+	default:
+		// pipeCh is full; GrpcClient applies backpressure internally.
+		// Drop this tick rather than blocking the generator.
+		a.metrics.dropped.Add(1)
+	}
+}
@@ -0,0 +1,255 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gdygd/goglib/databus"
+
+	"docker_service/internal/chaos"
+	"docker_service/internal/container"
+	"docker_service/internal/pipeline"
+	"docker_service/internal/server/mockserver"
+	gapi "docker_service/internal/server/rpc_client"
+)
+
+/*
+GrpcClient 장애 복구 검증 (chaos proxy + 로컬 mock 서버)
+
+	./loadtest -chaos-check
+
+GrpcClient -> chaos.Proxy -> MockServer(TCP) 구성으로 장애(지연/지터, 대역폭 제한,
+drop, 주기적 reset, half-open, 서버 재시작)를 주입하고 다음을 확인한다.
+  - 장애 해제 후 제한 시간 안에 스트림 재연결 및 전송 재개
+  - 장애 중/후에 보낸 이벤트가 모두 서버에 도착 (중복 허용), ACK 대기 0, dropped 0
+  - 모든 클라이언트/프록시 종료 후 goroutine 수가 시작 전 수준으로 복귀
+*/
+
+const (
+	chaosKeepalive   = time.Second // half-open 감지용 keepalive
+	chaosDrainWait   = 30 * time.Second
+	chaosGoroutineOK = 2 // 런타임 내부 goroutine 오차 허용
+)
+
+// chaosCase 장애 시나리오
+type chaosCase struct {
+	name    string
+	spec    string                      // 장애 설정 (chaos.ParseConfig)
+	hold    time.Duration               // 장애 유지 시간 (이 동안 이벤트 전송)
+	recover time.Duration               // 장애 해제 후 재연결 제한 시간
+	inject  func(h *chaosHarness)       // spec 외 추가 장애 (nil : 없음)
+	restore func(h *chaosHarness) error // 장애 해제 시 추가 처리
+}
+
+// chaosHarness 시나리오 1개 실행 환경
+type chaosHarness struct {
+	mock    *mockserver.Server
+	srvAddr string
+	proxy   *chaos.Proxy
+	bus     *databus.DataBus
+	client  *gapi.GrpcClient
+	wg      sync.WaitGroup
+	pipeCh  chan pipeline.Message
+	sent    int // 전송한 이벤트 수 (marker 1..sent)
+}
+
+func newChaosHarness() (*chaosHarness, error) {
+	h := &chaosHarness{
+		mock:   mockserver.New(0),
+		bus:    databus.NewDataBus(),
+		pipeCh: make(chan pipeline.Message, 100),
+	}
+	addr, err := h.mock.ServeTCP("127.0.0.1:0")
+	if err != nil {
+		h.mock.Stop()
+		return nil, err
+	}
+	h.srvAddr = addr
+
+	h.proxy, err = chaos.NewProxy("127.0.0.1:0", addr, chaos.Config{})
+	if err != nil {
+		h.mock.Stop()
+		return nil, err
+	}
+
+	h.wg.Add(1)
+	ct := &container.Container{Bus: h.bus}
+	h.client, err = gapi.NewClient(&h.wg, ct, h.pipeCh, h.proxy.Addr(), "chaos-check",
+		gapi.WithKeepalive(chaosKeepalive, chaosKeepalive))
+	if err != nil {
+		h.proxy.Close()
+		h.mock.Stop()
+		h.bus.ShutDown()
+		return nil, err
+	}
+	go h.client.Start()
+	return h, nil
+}
+
+// close 클라이언트 종료 대기 후 프록시/서버 종료
+func (h *chaosHarness) close() {
+	h.client.Shutdown()
+	h.wg.Wait()
+	h.proxy.Close()
+	h.mock.Stop()
+	h.bus.ShutDown()
+}
+
+// sendEvent marker 가 붙은 이벤트 1건 전송
+func (h *chaosHarness) sendEvent() {
+	h.sent++
+	n := strconv.Itoa(h.sent)
+	h.pipeCh <- pipeline.Message{
+		AgentId:   1,
+		Type:      pipeline.DataTypeEvent,
+		Host:      "chaos-host",
+		Timestamp: time.Now(),
+		Data: pipeline.ContainerEvent{
+			Host:      "chaos-host",
+			Type:      "container",
+			Action:    "start",
+			ActorID:   "chaos-" + n,
+			Timestamp: time.Now().UnixNano(),
+			Attrs:     map[string]string{"n": n},
+		},
+	}
+}
+
+// waitFor cond 가 true 가 될 때까지 대기, 걸린 시간 반환
+func waitFor(timeout time.Duration, cond func() bool) (time.Duration, bool) {
+	start := time.Now()
+	for time.Since(start) < timeout {
+		if cond() {
+			return time.Since(start), true
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	return time.Since(start), cond()
+}
+
+// missing 서버에 도착하지 않은 marker 수
+func (h *chaosHarness) missing() int {
+	n := 0
+	for i := 1; i <= h.sent; i++ {
+		if !h.mock.HasMark(strconv.Itoa(i)) {
+			n++
+		}
+	}
+	return n
+}
+
+// run 시나리오 실행, 실패 사유 반환 ("" : 성공)
+func (h *chaosHarness) run(tc chaosCase) (string, time.Duration) {
+	if _, ok := waitFor(10*time.Second, func() bool { return h.client.Status().StreamActive }); !ok {
+		return "stream not established before fault", 0
+	}
+	h.sendEvent()
+
+	cfg, err := chaos.ParseConfig(tc.spec)
+	if err != nil {
+		return err.Error(), 0
+	}
+	h.proxy.SetConfig(cfg)
+	if tc.inject != nil {
+		tc.inject(h)
+	}
+
+	// 장애 중 이벤트 전송 (100ms 간격)
+	deadline := time.Now().Add(tc.hold)
+	for time.Now().Before(deadline) {
+		h.sendEvent()
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	h.proxy.SetConfig(chaos.Config{})
+	if tc.restore != nil {
+		if err := tc.restore(h); err != nil {
+			return "restore: " + err.Error(), 0
+		}
+	}
+
+	// 장애 해제 후 보낸 이벤트가 스트림으로 도착하면 복구로 판단
+	h.sendEvent()
+	marker := strconv.Itoa(h.sent)
+	took, ok := waitFor(tc.recover, func() bool {
+		return h.client.Status().StreamActive && h.mock.HasMark(marker)
+	})
+	if !ok {
+		return fmt.Sprintf("not recovered within %v", tc.recover), took
+	}
+
+	if _, ok := waitFor(chaosDrainWait, func() bool {
+		return h.missing() == 0 && h.client.Status().Inflight == 0
+	}); !ok {
+		return fmt.Sprintf("data loss: %d/%d events missing, inflight=%d",
+			h.missing(), h.sent, h.client.Status().Inflight), took
+	}
+
+	st := h.client.Status()
+	if st.Dropped > 0 {
+		return fmt.Sprintf("client dropped %d messages", st.Dropped), took
+	}
+	if received := h.mock.Stats().Received["event"]; int64(st.Acked) > received {
+		return fmt.Sprintf("acked %d > received %d", st.Acked, received), took
+	}
+	return "", took
+}
+
+// runChaosCheck 장애 시나리오 실행, 실패 케이스 수 반환
+func runChaosCheck() int {
+	base := runtime.NumGoroutine()
+
+	cases := []chaosCase{
+		{name: "latency+jitter", spec: "latency=100ms,jitter=50ms", hold: 2 * time.Second, recover: 5 * time.Second},
+		{name: "bandwidth 32K/s", spec: "bw=32K", hold: 2 * time.Second, recover: 5 * time.Second},
+		{name: "drop 20%", spec: "drop=0.2", hold: 2 * time.Second, recover: 5 * time.Second},
+		{name: "periodic reset 700ms", spec: "reset=700ms", hold: 3 * time.Second, recover: 15 * time.Second},
+		{name: "reset all", hold: time.Second, recover: 15 * time.Second,
+			inject: func(h *chaosHarness) { h.proxy.ResetAll() }},
+		{name: "half-open 4s", spec: "halfopen", hold: 4 * time.Second, recover: 30 * time.Second},
+		{name: "server restart", hold: 2 * time.Second, recover: 30 * time.Second,
+			inject: func(h *chaosHarness) { h.mock.StopTCP() },
+			restore: func(h *chaosHarness) error {
+				_, err := h.mock.ServeTCP(h.srvAddr)
+				return err
+			}},
+	}
+
+	failed := 0
+	for _, tc := range cases {
+		h, err := newChaosHarness()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "chaos-check: %v\n", err)
+			return failed + 1
+		}
+		reason, took := h.run(tc)
+		st := h.client.Status()
+		ps := h.proxy.Stats()
+		h.close()
+
+		if reason != "" {
+			failed++
+		}
+		fmt.Printf("%-4s %-22s recover=%-8v events=%-3d resets=%d retransmits=%d conns=%d %s\n",
+			passFail(reason == ""), tc.name, took.Round(time.Millisecond), h.sent,
+			st.StreamResets, st.Retransmits, ps.Accepted, reason)
+	}
+
+	// 모든 클라이언트/프록시 종료 후 goroutine 누수 확인
+	var now int
+	_, ok := waitFor(10*time.Second, func() bool {
+		now = runtime.NumGoroutine()
+		return now <= base+chaosGoroutineOK
+	})
+	if !ok {
+		failed++
+	}
+	fmt.Printf("%-4s %-22s before=%d after=%d\n", passFail(ok), "goroutine leak", base, now)
+
+	fmt.Printf("\n%d/%d cases passed\n", len(cases)+1-failed, len(cases)+1)
+	return failed
+}
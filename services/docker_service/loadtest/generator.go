@@ -10,13 +10,27 @@ import (
 
 var eventActions = [...]string{"start", "stop", "die", "restart", "kill", "pause", "unpause"}
 
+// mixTypes maps scenario mix keys to data types, in cycle order.
+var mixTypes = map[string]pipeline.DataType{
+	"list":    pipeline.DataTypeList,
+	"inspect": pipeline.DataTypeInspect,
+	"stats":   pipeline.DataTypeStats,
+	"event":   pipeline.DataTypeEvent,
+}
+
+// cycleTypes is indexed like typeNames (idxList..idxEvent).
+var cycleTypes = [...]pipeline.DataType{pipeline.DataTypeList, pipeline.DataTypeInspect, pipeline.DataTypeStats, pipeline.DataTypeEvent}
+
 // Generator produces synthetic pipeline.Message values for a single agent.
-// It cycles through all four DataTypes in sequence.
+// It cycles through all four DataTypes in sequence unless a weighted mix is set.
 type Generator struct {
 	agentId    int
 	containers int
 	seq        int
 	rng        *rand.Rand
+
+	mixTypes   []pipeline.DataType // weighted mix (nil = cycle)
+	mixWeights []int               // cumulative weights
 }
 
 func NewGenerator(agentId, containers int) *Generator {
@@ -27,29 +41,59 @@ func NewGenerator(agentId, containers int) *Generator {
 	}
 }
 
-// Next returns the next synthetic message, cycling list → inspect → stats → event.
+// SetMix sets relative weights per data type (nil = cycle).
+func (g *Generator) SetMix(mix Mix) {
+	g.mixTypes, g.mixWeights = nil, nil
+	total := 0
+	for i, t := range cycleTypes { // fixed order keeps runs reproducible
+		if w := mix[typeNames[i]]; w > 0 {
+			total += w
+			g.mixTypes = append(g.mixTypes, t)
+			g.mixWeights = append(g.mixWeights, total)
+		}
+	}
+}
+
+// Next returns the next synthetic message, cycling list → inspect → stats → event
+// or drawing from the weighted mix.
 func (g *Generator) Next() pipeline.Message {
-	idx := g.seq % 4
-	g.seq++
+	var dt pipeline.DataType
+	if len(g.mixTypes) == 0 {
+		dt = cycleTypes[g.seq%len(cycleTypes)]
+		g.seq++
+	} else {
+		r := g.rng.IntN(g.mixWeights[len(g.mixWeights)-1])
+		for i, w := range g.mixWeights {
+			if r < w {
+				dt = g.mixTypes[i]
+				break
+			}
+		}
+	}
+	return g.message(dt)
+}
+
+// NextEvent returns an event message (event storm).
+func (g *Generator) NextEvent() pipeline.Message {
+	return g.message(pipeline.DataTypeEvent)
+}
 
+func (g *Generator) message(dt pipeline.DataType) pipeline.Message {
 	msg := pipeline.Message{
 		AgentId:   g.agentId,
+		Type:      dt,
 		Host:      fmt.Sprintf("host-%04d", g.agentId),
 		Timestamp: time.Now(),
 	}
 
-	switch idx {
-	case 0:
-		msg.Type = pipeline.DataTypeList
+	switch dt {
+	case pipeline.DataTypeList:
 		msg.Data = g.genList()
-	case 1:
-		msg.Type = pipeline.DataTypeInspect
+	case pipeline.DataTypeInspect:
 		msg.Data = g.genInspect()
-	case 2:
-		msg.Type = pipeline.DataTypeStats
+	case pipeline.DataTypeStats:
 		msg.Data = g.genStats()
-	case 3:
-		msg.Type = pipeline.DataTypeEvent
+	case pipeline.DataTypeEvent:
 		msg.Data = g.genEvent()
 	}
 	return msg
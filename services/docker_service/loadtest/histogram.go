@@ -0,0 +1,134 @@
+package main
+
+import (
+	"math"
+	"math/bits"
+	"sync/atomic"
+	"time"
+)
+
+// HDR-style log-linear histogram (microsecond resolution).
+// Values below 128us are counted exactly; above that every power-of-two range
+// is split into 64 linear sub-buckets, so the relative error is < 1/64 (~1.6%).
+const (
+	histSubBits    = 7
+	histSubCount   = 1 << histSubBits // 128
+	histHalfCount  = histSubCount / 2 // 64
+	histMaxShift   = 32               // up to ~2^39us (~6 days), larger values are clamped
+	histBucketSize = histSubCount + histMaxShift*histHalfCount
+)
+
+// Histogram is a lock-free latency histogram safe for concurrent Record calls.
+type Histogram struct {
+	counts [histBucketSize]atomic.Int64
+	total  atomic.Int64
+	sumUs  atomic.Int64
+	maxUs  atomic.Int64
+	minUs  atomic.Int64 // 0 = no sample yet (stored as value+1)
+}
+
+func histIndex(v int64) int {
+	if v < histSubCount {
+		return int(v)
+	}
+	shift := bits.Len64(uint64(v)) - histSubBits
+	if shift > histMaxShift {
+		return histBucketSize - 1
+	}
+	return histSubCount + (shift-1)*histHalfCount + int(v>>shift) - histHalfCount
+}
+
+// histUpper returns the highest value that maps to bucket idx.
+func histUpper(idx int) int64 {
+	if idx < histSubCount {
+		return int64(idx)
+	}
+	shift := (idx-histSubCount)/histHalfCount + 1
+	sub := int64((idx-histSubCount)%histHalfCount + histHalfCount)
+	return (sub+1)<<shift - 1
+}
+
+// Record adds one latency sample.
+func (h *Histogram) Record(d time.Duration) {
+	v := d.Microseconds()
+	if v < 0 {
+		v = 0
+	}
+	h.counts[histIndex(v)].Add(1)
+	h.total.Add(1)
+	h.sumUs.Add(v)
+	for {
+		cur := h.maxUs.Load()
+		if v <= cur || h.maxUs.CompareAndSwap(cur, v) {
+			break
+		}
+	}
+	for {
+		cur := h.minUs.Load()
+		if (cur != 0 && v+1 >= cur) || h.minUs.CompareAndSwap(cur, v+1) {
+			break
+		}
+	}
+}
+
+// LatencyStats is a point-in-time summary of a Histogram (milliseconds).
+type LatencyStats struct {
+	Count int64   `json:"count"`
+	Min   float64 `json:"min_ms"`
+	Avg   float64 `json:"avg_ms"`
+	P50   float64 `json:"p50_ms"`
+	P90   float64 `json:"p90_ms"`
+	P99   float64 `json:"p99_ms"`
+	P999  float64 `json:"p999_ms"`
+	Max   float64 `json:"max_ms"`
+}
+
+// Stats computes count/min/avg/percentiles/max from the current counts.
+func (h *Histogram) Stats() LatencyStats {
+	var counts [histBucketSize]int64
+	var total int64
+	for i := range counts {
+		counts[i] = h.counts[i].Load()
+		total += counts[i]
+	}
+	if total == 0 {
+		return LatencyStats{}
+	}
+
+	quantiles := []float64{0.50, 0.90, 0.99, 0.999}
+	targets := make([]int64, len(quantiles))
+	for i, q := range quantiles {
+		targets[i] = int64(math.Ceil(q * float64(total)))
+	}
+	values := make([]float64, len(quantiles))
+
+	var cum int64
+	qi := 0
+	for i := 0; i < histBucketSize && qi < len(targets); i++ {
+		cum += counts[i]
+		for qi < len(targets) && cum >= targets[qi] {
+			values[qi] = usToMs(histUpper(i))
+			qi++
+		}
+	}
+
+	maxMs := usToMs(h.maxUs.Load())
+	for i := range values {
+		values[i] = math.Min(values[i], maxMs) // bucket upper bound never exceeds the observed max
+	}
+
+	return LatencyStats{
+		Count: total,
+		Min:   usToMs(h.minUs.Load() - 1),
+		Avg:   usToMs(h.sumUs.Load()) / float64(total),
+		P50:   values[0],
+		P90:   values[1],
+		P99:   values[2],
+		P999:  values[3],
+		Max:   maxMs,
+	}
+}
+
+func usToMs(us int64) float64 {
+	return float64(us) / 1000
+}
@@ -10,6 +10,10 @@ import (
 	"sync/atomic"
 	"syscall"
 	"time"
+
+	"docker_service/internal/chaos"
+	"docker_service/internal/server/mockserver"
+	gapi "docker_service/internal/server/rpc_client"
 )
 
 /*
@@ -24,6 +28,23 @@ go build -o loadtest ./
 	  -containers 5
 
 	  ./loadtest -addr 10.1.0.119:19192 -agents 1000 -duration 5m -rampup 30s -rate-ms 500 -containers 5
+
+	batched + gzip (compare msg/s, avg_msg with the unary run above):
+	  ./loadtest -addr 10.1.0.119:19192 -agents 1000 -duration 5m -batch 50 -flush-ms 500 -gzip
+
+	modes : -mode unary (모든 데이터 단항 RPC) / stream (agent 기본: list/stats/event DataStream, inspect 단항) / batch
+
+	in-process mock server (네트워크 없음, CI 비교용 리포트):
+	  ./loadtest -mock -agents 200 -duration 30s -rampup 2s -mode stream -json stream.json -csv stream.csv -series-csv stream_series.csv
+	  ./loadtest -mock -agents 200 -scenario scenarios/event_storm.json -json storm.json
+
+	TLS / mTLS:
+	  ./loadtest -addr collector:19193 -ca ca.pem -cert agent.pem -key agent-key.pem
+	  ./loadtest -tls-check   (로컬 TLS stub 으로 정상/만료/다른 CA/pinning/hot-reload 확인)
+
+	fault injection (chaos proxy 를 target 앞에 두고 실행, -mock 과 함께 사용 가능):
+	  ./loadtest -mock -agents 50 -duration 1m -chaos "latency=80ms,jitter=20ms,drop=0.01,reset=20s" -keepalive 5s
+	  ./loadtest -chaos-check   (재연결 시간, 데이터 손실, goroutine 누수 확인)
 */
 func main() {
 	addr := flag.String("addr", "10.1.0.119:19192", "gRPC server address")
@@ -32,17 +53,137 @@ func main() {
 	rampup := flag.Duration("rampup", 30*time.Second, "ramp-up period for agent start") // 30s / 1000 = 30ms 간격으로 에이전트를 순차 기동해서 커넥션을 분산
 	rateMs := flag.Int("rate-ms", 500, "message generation interval per agent in ms")
 	containers := flag.Int("containers", 5, "fake container count per agent")
+	modeFlag := flag.String("mode", "stream", "transmission mode: unary | stream | batch")
+	batch := flag.Int("batch", 0, "messages per ContainerBatch RPC (> 1 implies -mode batch)")
+	flushMs := flag.Int("flush-ms", 500, "batch flush interval in ms")
+	useGzip := flag.Bool("gzip", false, "enable gzip compression on the connection")
+	caFile := flag.String("ca", "", "server CA file (enables TLS)")
+	certFile := flag.String("cert", "", "client certificate file (mTLS)")
+	keyFile := flag.String("key", "", "client key file (mTLS)")
+	serverName := flag.String("server-name", "", "TLS server name override")
+	tlsCheck := flag.Bool("tls-check", false, "run TLS/mTLS checks against local stub servers and exit")
+	useMock := flag.Bool("mock", false, "run against an in-process mock ContainerService (no network)")
+	mockLatency := flag.Duration("mock-latency", 0, "simulated processing time per call on the mock server")
+	scenarioFile := flag.String("scenario", "", "scenario file (JSON phases with rate, mix, event storm); overrides -duration")
+	jsonOut := flag.String("json", "", "write the final report as JSON")
+	csvOut := flag.String("csv", "", "write per-RPC summary (latency percentiles) as CSV")
+	seriesOut := flag.String("series-csv", "", "write the per-second throughput series as CSV")
+	chaosSpec := flag.String("chaos", "", "run through a fault-injection proxy (e.g. \"latency=50ms,jitter=10ms,bw=512K,drop=0.01,halfopen,reset=30s\")")
+	keepaliveIv := flag.Duration("keepalive", 0, "client keepalive ping interval (0 = off)")
+	chaosCheck := flag.Bool("chaos-check", false, "run GrpcClient recovery checks through the chaos proxy and exit")
 	flag.Parse()
 
-	metrics := &Metrics{}
+	if *tlsCheck {
+		if runTLSCheck() > 0 {
+			os.Exit(1)
+		}
+		return
+	}
+	if *chaosCheck {
+		if runChaosCheck() > 0 {
+			os.Exit(1)
+		}
+		return
+	}
+
+	sc := DefaultScenario(*duration, *rateMs)
+	if *scenarioFile != "" {
+		loaded, err := LoadScenario(*scenarioFile, *rateMs)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+		sc = loaded
+		*duration = sc.TotalDuration()
+	}
+
+	var clientOpts []gapi.ClientOption
+	if *batch > 1 {
+		*modeFlag = "batch"
+	}
+	var mode string
+	switch *modeFlag {
+	case "unary":
+		clientOpts = append(clientOpts, gapi.WithUnaryOnly())
+		mode = "unary"
+	case "stream":
+		mode = "stream"
+	case "batch":
+		if *batch <= 1 {
+			*batch = 50
+		}
+		clientOpts = append(clientOpts, gapi.WithBatch(*batch, time.Duration(*flushMs)*time.Millisecond))
+		mode = fmt.Sprintf("batch=%d/%dms", *batch, *flushMs)
+	default:
+		fmt.Fprintf(os.Stderr, "unknown -mode %q (unary | stream | batch)\n", *modeFlag)
+		os.Exit(1)
+	}
+	if *useGzip {
+		clientOpts = append(clientOpts, gapi.WithCompression("gzip"))
+		mode += "+gzip"
+	}
+	target := *addr
+	var mock *mockserver.Server
+	if *useMock {
+		if *caFile != "" || *certFile != "" {
+			fmt.Fprintln(os.Stderr, "-mock does not support TLS options")
+			os.Exit(1)
+		}
+		mock = mockserver.New(*mockLatency)
+		defer mock.Stop()
+		target = "mock"
+		if *chaosSpec != "" {
+			// proxy 는 TCP 로 전달하므로 mock 도 TCP 로 연다
+			tcpAddr, err := mock.ServeTCP("127.0.0.1:0")
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "mock: %v\n", err)
+				os.Exit(1)
+			}
+			*addr = tcpAddr
+		} else {
+			*addr = mockserver.Target
+			clientOpts = append(clientOpts, gapi.WithDialer(mock.Dialer()))
+		}
+	}
+	var proxy *chaos.Proxy
+	if *chaosSpec != "" {
+		cfg, err := chaos.ParseConfig(*chaosSpec)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+		proxy, err = chaos.NewProxy("127.0.0.1:0", *addr, cfg)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "chaos proxy: %v\n", err)
+			os.Exit(1)
+		}
+		defer proxy.Close()
+		*addr = proxy.Addr()
+		target += " via chaos(" + cfg.String() + ")"
+		mode += "+chaos"
+	}
+	if *keepaliveIv > 0 {
+		clientOpts = append(clientOpts, gapi.WithKeepalive(*keepaliveIv, 0))
+	}
+	if *caFile != "" || *certFile != "" {
+		clientOpts = append(clientOpts, gapi.WithTLS(gapi.TLSConfig{
+			CAFile:     *caFile,
+			CertFile:   *certFile,
+			KeyFile:    *keyFile,
+			ServerName: *serverName,
+		}))
+		mode += "+tls"
+	}
+
+	metrics := &Metrics{Mode: mode}
 
 	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer cancel()
 
 	fmt.Printf("=== gRPC Load Test ===\n")
-	fmt.Printf("addr=%s  agents=%d  agentId=[2..%d]\n", *addr, *agentCount, *agentCount+1)
-	fmt.Printf("duration=%v  rampup=%v  rate=%dms  containers=%d\n\n",
-		*duration, *rampup, *rateMs, *containers)
+	fmt.Printf("addr=%s  agents=%d  agentId=[2..%d]\n", target, *agentCount, *agentCount+1)
+	fmt.Printf("duration=%v  rampup=%v  rate=%dms  containers=%d  mode=%s  scenario=%s\n\n",
+		*duration, *rampup, *rateMs, *containers, mode, sc.Name)
 
 	var (
 		activeAgents atomic.Int32
@@ -58,6 +199,11 @@ func main() {
 	startTime := time.Now()
 	started := 0
 
+	seriesCtx, stopSeries := context.WithCancel(context.Background())
+	defer stopSeries()
+	go metrics.RunSeries(seriesCtx, startTime, func() int { return int(activeAgents.Load()) }, sc.PhaseName)
+	go sc.Run(ctx.Done())
+
 	for i := 0; i < *agentCount; i++ {
 		select {
 		case <-ctx.Done():
@@ -67,7 +213,7 @@ func main() {
 		}
 
 		agentId := i + 2 // real agent uses ID=1; load test uses 2 ~ agentCount+1
-		agent, err := NewSimAgent(agentId, *addr, metrics, *containers, *rateMs)
+		agent, err := NewSimAgent(agentId, *addr, metrics, *containers, sc, clientOpts...)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "agent %d create failed: %v\n", agentId, err)
 			continue
@@ -126,5 +272,54 @@ func main() {
 WAIT:
 	fmt.Println("Waiting for all agents to stop...")
 	agentWg.Wait()
-	fmt.Printf("\n%s", metrics.Summary(time.Since(startTime)))
+	elapsed := time.Since(startTime)
+	stopSeries()
+	fmt.Printf("\n%s", metrics.Summary(elapsed))
+
+	report := metrics.BuildReport(startTime, elapsed)
+	report.Scenario = sc.Name
+	report.Target = target
+	report.Params = map[string]any{
+		"agents":     *agentCount,
+		"containers": *containers,
+		"rate_ms":    *rateMs,
+		"rampup":     rampup.String(),
+		"duration":   duration.String(),
+		"batch":      *batch,
+		"flush_ms":   *flushMs,
+		"gzip":       *useGzip,
+	}
+	if mock != nil {
+		st := mock.Stats()
+		report.Mock = &st
+		fmt.Print(st)
+	}
+	if proxy != nil {
+		st := proxy.Stats()
+		report.Chaos = &st
+		fmt.Printf("chaos proxy : accepted=%d resets=%d up=%dB down=%dB delayed=%d discarded=%dB\n",
+			st.Accepted, st.Resets, st.BytesUp, st.BytesDown, st.Dropped, st.Discarded)
+	}
+	writeReports(report, *jsonOut, *csvOut, *seriesOut)
+}
+
+func writeReports(r *RunReport, jsonPath, csvPath, seriesPath string) {
+	outputs := []struct {
+		path  string
+		write func(string) error
+	}{
+		{jsonPath, r.WriteJSON},
+		{csvPath, r.WriteCSV},
+		{seriesPath, r.WriteSeriesCSV},
+	}
+	for _, o := range outputs {
+		if o.path == "" {
+			continue
+		}
+		if err := o.write(o.path); err != nil {
+			fmt.Fprintf(os.Stderr, "write %s: %v\n", o.path, err)
+			continue
+		}
+		fmt.Printf("report written: %s\n", o.path)
+	}
 }
@@ -2,12 +2,19 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"time"
 
+	"docker_service/pb"
+
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 const (
@@ -15,28 +22,41 @@ const (
 	idxInspect = 1
 	idxStats   = 2
 	idxEvent   = 3
-	idxCount   = 4
+	idxBatch   = 4
+	idxStream  = 5 // DataStream: per-message Send -> cumulative ACK latency
+	idxCount   = 6
 )
 
-var typeNames = [idxCount]string{"list", "inspect", "stats", "event"}
+var typeNames = [idxCount]string{"list", "inspect", "stats", "event", "batch", "stream"}
 
-// Metrics collects per-RPC-type success/error counts and cumulative latency.
+// Metrics collects per-RPC-type success/error counts and latency histograms.
 // All fields are updated atomically from concurrent goroutines.
 type Metrics struct {
+	// Mode describes the transmission mode under test (e.g. "unary", "batch=50 gzip").
+	Mode string
+
 	success    [idxCount]atomic.Int64
 	errors     [idxCount]atomic.Int64
+	messages   [idxCount]atomic.Int64 // AgentMessages delivered (batch RPCs carry many)
 	latencyNs  [idxCount]atomic.Int64 // cumulative nanoseconds
 	latencyCnt [idxCount]atomic.Int64 // sample count
+	hist       [idxCount]Histogram
+	dropped    atomic.Int64 // generated messages dropped because pipeCh was full
+
+	seriesMu sync.Mutex
+	series   []SecondSample
 }
 
-func (m *Metrics) record(idx int, lat time.Duration, err error) {
+func (m *Metrics) record(idx int, msgs int, lat time.Duration, err error) {
 	if err != nil {
 		m.errors[idx].Add(1)
 		return
 	}
 	m.success[idx].Add(1)
+	m.messages[idx].Add(int64(msgs))
 	m.latencyNs[idx].Add(lat.Nanoseconds())
 	m.latencyCnt[idx].Add(1)
+	m.hist[idx].Record(lat)
 }
 
 // Interceptor returns a gRPC unary client interceptor that records metrics.
@@ -49,60 +69,173 @@ func (m *Metrics) Interceptor() grpc.UnaryClientInterceptor {
 		invoker grpc.UnaryInvoker,
 		opts ...grpc.CallOption,
 	) error {
-		idx := methodToIdx(method)
+		idx, ok := methodToIdx(method)
+		if !ok {
+			// Register / Heartbeat / ReportCommand are control RPCs, not data
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+		msgs := 1
+		if batch, ok := req.(*pb.AgentMessageBatch); ok {
+			msgs = len(batch.Messages)
+		}
 		start := time.Now()
 		err := invoker(ctx, method, req, reply, cc, opts...)
-		m.record(idx, time.Since(start), err)
+		m.record(idx, msgs, time.Since(start), err)
+		return err
+	}
+}
+
+// StreamInterceptor returns a gRPC stream client interceptor that measures
+// DataStream latency: the time from Send(seq) until an ACK covering seq arrives.
+func (m *Metrics) StreamInterceptor() grpc.StreamClientInterceptor {
+	return func(
+		ctx context.Context,
+		desc *grpc.StreamDesc,
+		cc *grpc.ClientConn,
+		method string,
+		streamer grpc.Streamer,
+		opts ...grpc.CallOption,
+	) (grpc.ClientStream, error) {
+		cs, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil || !strings.HasSuffix(method, "DataStream") {
+			return cs, err
+		}
+		return &meteredStream{ClientStream: cs, m: m, sent: make(map[uint64]time.Time)}, nil
+	}
+}
+
+// meteredStream tracks send time per seq until the server acknowledges it.
+// Messages still unacknowledged when the stream breaks are counted as errors
+// (the client retransmits them on the next stream, where they are measured again).
+// On normal shutdown (EOF / Canceled) they are discarded.
+type meteredStream struct {
+	grpc.ClientStream
+	m *Metrics
+
+	mu   sync.Mutex
+	sent map[uint64]time.Time
+}
+
+func (s *meteredStream) SendMsg(msg any) error {
+	am, ok := msg.(*pb.AgentMessage)
+	if ok && am.Seq > 0 {
+		s.mu.Lock()
+		s.sent[am.Seq] = time.Now()
+		s.mu.Unlock()
+	}
+	err := s.ClientStream.SendMsg(msg)
+	if err != nil && ok && am.Seq > 0 {
+		s.mu.Lock()
+		delete(s.sent, am.Seq)
+		s.mu.Unlock()
+		s.m.record(idxStream, 1, 0, err)
+	}
+	return err
+}
+
+func (s *meteredStream) RecvMsg(msg any) error {
+	err := s.ClientStream.RecvMsg(msg)
+	if err != nil {
+		s.mu.Lock()
+		lost := len(s.sent)
+		s.sent = make(map[uint64]time.Time)
+		s.mu.Unlock()
+		if errors.Is(err, io.EOF) || status.Code(err) == codes.Canceled {
+			return err // normal shutdown: unacked messages are not failures
+		}
+		for i := 0; i < lost; i++ {
+			s.m.record(idxStream, 1, 0, err)
+		}
 		return err
 	}
+
+	sm, ok := msg.(*pb.ServerMessage)
+	if !ok || sm.Command != pb.CommandType_ACK || sm.AckSeq == 0 {
+		return nil
+	}
+	now := time.Now()
+	s.mu.Lock()
+	for seq, t := range s.sent {
+		if seq <= sm.AckSeq {
+			s.m.record(idxStream, 1, now.Sub(t), nil)
+			delete(s.sent, seq)
+		}
+	}
+	s.mu.Unlock()
+	return nil
 }
 
 // methodToIdx maps gRPC full method name to a DataType index.
-func methodToIdx(method string) int {
+func methodToIdx(method string) (int, bool) {
 	switch {
-	case strings.HasSuffix(method, "ContainerInfo"):
-		return idxList
+	case strings.HasSuffix(method, "ContainerInfo"), strings.HasSuffix(method, "ContainerState"):
+		return idxList, true
 	case strings.HasSuffix(method, "ContainerInspect"):
-		return idxInspect
+		return idxInspect, true
 	case strings.HasSuffix(method, "ContainerStats"):
-		return idxStats
+		return idxStats, true
 	case strings.HasSuffix(method, "ContainerEvent"):
-		return idxEvent
+		return idxEvent, true
+	case strings.HasSuffix(method, "ContainerBatch"):
+		return idxBatch, true
 	default:
-		return idxList
+		return 0, false
 	}
 }
 
-// Report returns a formatted metrics snapshot.
-func (m *Metrics) Report(elapsed time.Duration, activeAgents int) string {
-	var totalOk, totalErr int64
+// totals returns (ok RPCs, errors, messages, latency ns, latency samples) over all types.
+func (m *Metrics) totals() (ok, errCnt, msgs, latNs, latCnt int64) {
 	for i := 0; i < idxCount; i++ {
-		totalOk += m.success[i].Load()
-		totalErr += m.errors[i].Load()
+		ok += m.success[i].Load()
+		errCnt += m.errors[i].Load()
+		msgs += m.messages[i].Load()
+		latNs += m.latencyNs[i].Load()
+		latCnt += m.latencyCnt[i].Load()
 	}
+	return
+}
+
+// Report returns a formatted metrics snapshot.
+func (m *Metrics) Report(elapsed time.Duration, activeAgents int) string {
+	totalOk, totalErr, totalMsgs, totalLatNs, totalLatCnt := m.totals()
 
-	tps := 0.0
+	tps, mps := 0.0, 0.0
 	if s := elapsed.Seconds(); s > 0 {
 		tps = float64(totalOk) / s
+		mps = float64(totalMsgs) / s
 	}
 	errRate := 0.0
 	if total := totalOk + totalErr; total > 0 {
 		errRate = float64(totalErr) / float64(total) * 100
 	}
+	// per-message latency: a batch RPC's latency is amortised over its messages
+	avgMsgMs := 0.0
+	if totalMsgs > 0 {
+		avgMsgMs = float64(totalLatNs) / float64(totalMsgs) / 1e6
+	}
+	avgRpcMs := 0.0
+	if totalLatCnt > 0 {
+		avgRpcMs = float64(totalLatNs) / float64(totalLatCnt) / 1e6
+	}
+
+	mode := m.Mode
+	if mode == "" {
+		mode = "unary"
+	}
 
 	var sb strings.Builder
-	fmt.Fprintf(&sb, "[T+%ds] agents=%d tps=%.1f err_rate=%.2f%%\n",
-		int(elapsed.Seconds()), activeAgents, tps, errRate)
+	fmt.Fprintf(&sb, "[T+%ds] mode=%s agents=%d rpc/s=%.1f msg/s=%.1f err_rate=%.2f%% avg_rpc=%.2fms avg_msg=%.3fms dropped=%d\n",
+		int(elapsed.Seconds()), mode, activeAgents, tps, mps, errRate, avgRpcMs, avgMsgMs, m.dropped.Load())
 
 	for i := 0; i < idxCount; i++ {
 		ok := m.success[i].Load()
 		errCnt := m.errors[i].Load()
-		avgMs := 0.0
-		if cnt := m.latencyCnt[i].Load(); cnt > 0 {
-			avgMs = float64(m.latencyNs[i].Load()) / float64(cnt) / 1e6
+		if ok == 0 && errCnt == 0 {
+			continue
 		}
-		fmt.Fprintf(&sb, "  %-8s ok=%-8d err=%-5d avg=%.2fms\n",
-			typeNames[i], ok, errCnt, avgMs)
+		st := m.hist[i].Stats()
+		fmt.Fprintf(&sb, "  %-8s ok=%-8d msgs=%-8d err=%-5d avg=%.2fms p50=%.2fms p90=%.2fms p99=%.2fms p999=%.2fms max=%.2fms\n",
+			typeNames[i], ok, m.messages[i].Load(), errCnt, st.Avg, st.P50, st.P90, st.P99, st.P999, st.Max)
 	}
 	return sb.String()
 }
@@ -111,3 +244,66 @@ func (m *Metrics) Report(elapsed time.Duration, activeAgents int) string {
 func (m *Metrics) Summary(elapsed time.Duration) string {
 	return "[FINAL SUMMARY]\n" + m.Report(elapsed, 0)
 }
+
+// SecondSample is the throughput observed during one second of the run.
+type SecondSample struct {
+	T      int              `json:"t"` // seconds since start
+	Phase  string           `json:"phase,omitempty"`
+	Agents int              `json:"agents"`
+	RPC    int64            `json:"rpc"`
+	Msgs   int64            `json:"msgs"`
+	Errors int64            `json:"errors"`
+	AvgMs  float64          `json:"avg_ms"`  // mean latency of samples completed in this second
+	ByType map[string]int64 `json:"by_type"` // messages per type
+}
+
+// RunSeries samples the counters once per second until ctx is cancelled.
+// agents and phase are polled at each tick (phase may be nil).
+func (m *Metrics) RunSeries(ctx context.Context, start time.Time, agents func() int, phase func() string) {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	var prevMsgs [idxCount]int64
+	var prevOk, prevErr, prevLatNs, prevLatCnt int64
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			ok, errCnt, _, latNs, latCnt := m.totals()
+			s := SecondSample{
+				T:      int(now.Sub(start).Round(time.Second).Seconds()),
+				Agents: agents(),
+				RPC:    ok - prevOk,
+				Errors: errCnt - prevErr,
+				ByType: make(map[string]int64),
+			}
+			if phase != nil {
+				s.Phase = phase()
+			}
+			for i := 0; i < idxCount; i++ {
+				cur := m.messages[i].Load()
+				if d := cur - prevMsgs[i]; d > 0 {
+					s.ByType[typeNames[i]] = d
+					s.Msgs += d
+				}
+				prevMsgs[i] = cur
+			}
+			if n := latCnt - prevLatCnt; n > 0 {
+				s.AvgMs = float64(latNs-prevLatNs) / float64(n) / 1e6
+			}
+			prevOk, prevErr, prevLatNs, prevLatCnt = ok, errCnt, latNs, latCnt
+
+			m.seriesMu.Lock()
+			m.series = append(m.series, s)
+			m.seriesMu.Unlock()
+		}
+	}
+}
+
+// Series returns a copy of the per-second samples collected so far.
+func (m *Metrics) Series() []SecondSample {
+	m.seriesMu.Lock()
+	defer m.seriesMu.Unlock()
+	return append([]SecondSample(nil), m.series...)
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"strconv"
+	"time"
+
+	"docker_service/internal/chaos"
+	"docker_service/internal/server/mockserver"
+)
+
+// RunReport is the machine-readable result of a run (-json / -csv / -series-csv),
+// intended for comparing runs in CI.
+type RunReport struct {
+	Mode      string         `json:"mode"`
+	Scenario  string         `json:"scenario"`
+	Target    string         `json:"target"` // server address or "mock"
+	Params    map[string]any `json:"params"`
+	StartedAt time.Time      `json:"started_at"`
+	Elapsed   float64        `json:"elapsed_sec"`
+
+	RPCs     int64   `json:"rpcs"`
+	Messages int64   `json:"messages"`
+	Errors   int64   `json:"errors"`
+	Dropped  int64   `json:"dropped"`
+	RPCRate  float64 `json:"rpc_per_sec"`
+	MsgRate  float64 `json:"msg_per_sec"`
+	ErrRate  float64 `json:"err_rate_pct"`
+
+	ByRPC  []RPCReport       `json:"by_rpc"`
+	Series []SecondSample    `json:"series"`
+	Mock   *mockserver.Stats `json:"mock,omitempty"`  // server-side counts in -mock mode
+	Chaos  *chaos.Stats      `json:"chaos,omitempty"` // fault-injection proxy counters (-chaos)
+}
+
+// RPCReport is the per-RPC summary with latency percentiles.
+type RPCReport struct {
+	Name     string `json:"name"`
+	OK       int64  `json:"ok"`
+	Errors   int64  `json:"errors"`
+	Messages int64  `json:"messages"`
+	LatencyStats
+}
+
+// BuildReport snapshots the metrics into a RunReport.
+func (m *Metrics) BuildReport(start time.Time, elapsed time.Duration) *RunReport {
+	ok, errCnt, msgs, _, _ := m.totals()
+	r := &RunReport{
+		Mode:      m.Mode,
+		StartedAt: start,
+		Elapsed:   elapsed.Seconds(),
+		RPCs:      ok,
+		Messages:  msgs,
+		Errors:    errCnt,
+		Dropped:   m.dropped.Load(),
+		Series:    m.Series(),
+	}
+	if s := elapsed.Seconds(); s > 0 {
+		r.RPCRate = float64(ok) / s
+		r.MsgRate = float64(msgs) / s
+	}
+	if total := ok + errCnt; total > 0 {
+		r.ErrRate = float64(errCnt) / float64(total) * 100
+	}
+	for i := 0; i < idxCount; i++ {
+		okCnt, errs := m.success[i].Load(), m.errors[i].Load()
+		if okCnt == 0 && errs == 0 {
+			continue
+		}
+		r.ByRPC = append(r.ByRPC, RPCReport{
+			Name:         typeNames[i],
+			OK:           okCnt,
+			Errors:       errs,
+			Messages:     m.messages[i].Load(),
+			LatencyStats: m.hist[i].Stats(),
+		})
+	}
+	return r
+}
+
+func (r *RunReport) WriteJSON(path string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// WriteCSV writes one row per RPC type plus a "total" row.
+func (r *RunReport) WriteCSV(path string) error {
+	rows := [][]string{{"mode", "scenario", "rpc", "ok", "errors", "messages", "msg_per_sec",
+		"avg_ms", "p50_ms", "p90_ms", "p99_ms", "p999_ms", "max_ms"}}
+	for _, b := range r.ByRPC {
+		rows = append(rows, []string{r.Mode, r.Scenario, b.Name,
+			itoa(b.OK), itoa(b.Errors), itoa(b.Messages), ftoa(float64(b.Messages) / r.Elapsed),
+			ftoa(b.Avg), ftoa(b.P50), ftoa(b.P90), ftoa(b.P99), ftoa(b.P999), ftoa(b.Max)})
+	}
+	rows = append(rows, []string{r.Mode, r.Scenario, "total",
+		itoa(r.RPCs), itoa(r.Errors), itoa(r.Messages), ftoa(r.MsgRate), "", "", "", "", "", ""})
+	return writeCSV(path, rows)
+}
+
+// WriteSeriesCSV writes the per-second throughput time series.
+func (r *RunReport) WriteSeriesCSV(path string) error {
+	header := []string{"t", "phase", "agents", "rpc", "msgs", "errors", "avg_ms"}
+	header = append(header, typeNames[:]...)
+	rows := [][]string{header}
+	for _, s := range r.Series {
+		row := []string{strconv.Itoa(s.T), s.Phase, strconv.Itoa(s.Agents),
+			itoa(s.RPC), itoa(s.Msgs), itoa(s.Errors), ftoa(s.AvgMs)}
+		for _, name := range typeNames {
+			row = append(row, itoa(s.ByType[name]))
+		}
+		rows = append(rows, row)
+	}
+	return writeCSV(path, rows)
+}
+
+func writeCSV(path string, rows [][]string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	w := csv.NewWriter(f)
+	if err := w.WriteAll(rows); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func itoa(v int64) string   { return strconv.FormatInt(v, 10) }
+func ftoa(v float64) string { return strconv.FormatFloat(v, 'f', 3, 64) }
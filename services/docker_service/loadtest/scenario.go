@@ -0,0 +1,178 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync/atomic"
+	"time"
+)
+
+/*
+Scenario file (JSON): phases run back to back, each with its own send rate,
+data-type mix and optional event storm.
+
+	{
+	  "name": "event-storm",
+	  "mix": {"list": 1, "inspect": 1, "stats": 4, "event": 1},
+	  "phases": [
+	    {"name": "warmup", "duration": "20s", "rate_ms": 1000},
+	    {"name": "storm",  "duration": "30s", "rate_ms": 500,
+	     "event_storm": {"rate_ms": 5, "agents_pct": 20}},
+	    {"name": "cooldown", "duration": "20s", "rate_ms": 1000, "mix": {"stats": 1}}
+	  ]
+	}
+
+mix       : relative weights per data type (omitted = list→inspect→stats→event cycle)
+rate_ms   : message interval per agent (0 = -rate-ms flag)
+event_storm: extra event-only stream at rate_ms on agents_pct % of agents
+*/
+
+// Duration unmarshals from a Go duration string ("30s", "2m").
+type Duration time.Duration
+
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// Mix is a relative weight per data type ("list", "inspect", "stats", "event").
+type Mix map[string]int
+
+// EventStorm adds an event-only message stream on a subset of agents.
+type EventStorm struct {
+	RateMs    int `json:"rate_ms"`
+	AgentsPct int `json:"agents_pct"` // 0 = all agents
+}
+
+type Phase struct {
+	Name       string      `json:"name"`
+	Duration   Duration    `json:"duration"`
+	RateMs     int         `json:"rate_ms,omitempty"`
+	Mix        Mix         `json:"mix,omitempty"` // nil = scenario mix
+	EventStorm *EventStorm `json:"event_storm,omitempty"`
+}
+
+type Scenario struct {
+	Name   string  `json:"name"`
+	Mix    Mix     `json:"mix,omitempty"`
+	Phases []Phase `json:"phases"`
+
+	current atomic.Int32
+}
+
+// LoadScenario reads and validates a scenario file. defaultRateMs fills phases without rate_ms.
+func LoadScenario(path string, defaultRateMs int) (*Scenario, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var sc Scenario
+	if err := json.Unmarshal(data, &sc); err != nil {
+		return nil, fmt.Errorf("scenario %s: %w", path, err)
+	}
+	if len(sc.Phases) == 0 {
+		return nil, fmt.Errorf("scenario %s: no phases", path)
+	}
+	if sc.Name == "" {
+		sc.Name = path
+	}
+	for i := range sc.Phases {
+		p := &sc.Phases[i]
+		if p.Name == "" {
+			p.Name = fmt.Sprintf("phase-%d", i+1)
+		}
+		if p.Duration <= 0 {
+			return nil, fmt.Errorf("scenario %s: phase %s: duration is required", path, p.Name)
+		}
+		if p.RateMs <= 0 {
+			p.RateMs = defaultRateMs
+		}
+		if p.Mix == nil {
+			p.Mix = sc.Mix
+		}
+		if err := p.Mix.validate(); err != nil {
+			return nil, fmt.Errorf("scenario %s: phase %s: %w", path, p.Name, err)
+		}
+		if s := p.EventStorm; s != nil && s.RateMs <= 0 {
+			return nil, fmt.Errorf("scenario %s: phase %s: event_storm.rate_ms is required", path, p.Name)
+		}
+	}
+	return &sc, nil
+}
+
+// DefaultScenario is a single phase equivalent to the plain command-line flags.
+func DefaultScenario(duration time.Duration, rateMs int) *Scenario {
+	return &Scenario{
+		Name:   "default",
+		Phases: []Phase{{Name: "steady", Duration: Duration(duration), RateMs: rateMs}},
+	}
+}
+
+func (m Mix) validate() error {
+	total := 0
+	for k, w := range m {
+		if _, ok := mixTypes[k]; !ok {
+			return fmt.Errorf("unknown data type in mix: %q", k)
+		}
+		if w < 0 {
+			return fmt.Errorf("negative weight for %q", k)
+		}
+		total += w
+	}
+	if m != nil && total == 0 {
+		return fmt.Errorf("mix has no positive weight")
+	}
+	return nil
+}
+
+// TotalDuration is the sum of all phases (0 if any phase is unbounded).
+func (sc *Scenario) TotalDuration() time.Duration {
+	var total time.Duration
+	for _, p := range sc.Phases {
+		if p.Duration <= 0 {
+			return 0
+		}
+		total += time.Duration(p.Duration)
+	}
+	return total
+}
+
+// Current returns the active phase index.
+func (sc *Scenario) Current() int {
+	return int(sc.current.Load())
+}
+
+func (sc *Scenario) PhaseName() string {
+	return sc.Phases[sc.Current()].Name
+}
+
+// Run advances the active phase according to elapsed time and returns when
+// the last phase ends (or immediately for an unbounded final phase).
+func (sc *Scenario) Run(done <-chan struct{}) {
+	for i := range sc.Phases {
+		sc.current.Store(int32(i))
+		d := time.Duration(sc.Phases[i].Duration)
+		if d <= 0 {
+			return
+		}
+		fmt.Printf(">>> phase %d/%d %q for %v (rate=%dms)\n", i+1, len(sc.Phases), sc.Phases[i].Name, d, sc.Phases[i].RateMs)
+		select {
+		case <-done:
+			return
+		case <-time.After(d):
+		}
+	}
+}
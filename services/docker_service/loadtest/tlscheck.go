@@ -0,0 +1,198 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/gdygd/goglib/databus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	"docker_service/internal/container"
+	"docker_service/internal/pipeline"
+	gapi "docker_service/internal/server/rpc_client"
+	"docker_service/internal/testcert"
+	"docker_service/pb"
+)
+
+/*
+TLS/mTLS 검증 (로컬 TLS gRPC 서버 stub)
+
+	./loadtest -tls-check
+
+임시 CA/인증서를 생성하고 정상/실패 케이스(만료 인증서, 다른 CA, mTLS 미제출,
+server-name override, pinning, 인증서 hot-reload)를 GrpcClient 로 확인한다.
+*/
+
+// tlsStub Heartbeat 만 응답하는 TLS gRPC 서버 stub
+type tlsStub struct {
+	pb.UnimplementedContainerServiceServer
+}
+
+func (s *tlsStub) Heartbeat(ctx context.Context, _ *pb.HeartbeatRequest) (*pb.HeartbeatResponse, error) {
+	return &pb.HeartbeatResponse{Success: true, ServerTime: time.Now().UnixMilli()}, nil
+}
+
+// startTLSStub TLS gRPC 서버 시작 (clientCA != nil 이면 mTLS 필수)
+func startTLSStub(cert *testcert.Cert, clientCA *testcert.CA) (string, func(), error) {
+	kp, err := cert.KeyPair()
+	if err != nil {
+		return "", nil, err
+	}
+	cfg := &tls.Config{Certificates: []tls.Certificate{kp}, MinVersion: tls.VersionTLS12}
+	if clientCA != nil {
+		cfg.ClientCAs = clientCA.Pool()
+		cfg.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", nil, err
+	}
+	gs := grpc.NewServer(grpc.Creds(credentials.NewTLS(cfg)))
+	pb.RegisterContainerServiceServer(gs, &tlsStub{})
+	go gs.Serve(lis)
+	return lis.Addr().String(), gs.Stop, nil
+}
+
+// tlsHeartbeat GrpcClient 로 Heartbeat 1회 호출
+func tlsHeartbeat(addr string, cfg gapi.TLSConfig) error {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	ct := &container.Container{Bus: databus.NewDataBus()}
+	client, err := gapi.NewClient(&wg, ct, make(chan pipeline.Message), addr, "tls-check", gapi.WithTLS(cfg))
+	if err != nil {
+		return err
+	}
+	defer client.Shutdown()
+	_, err = client.Heartbeat(&pb.HeartbeatRequest{Timestamp: time.Now().UnixMilli()})
+	return err
+}
+
+func writeFile(dir, name string, data []byte) string {
+	p := filepath.Join(dir, name)
+	if err := os.WriteFile(p, data, 0600); err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// runTLSCheck 정상/실패 케이스 실행, 실패 케이스 수 반환
+func runTLSCheck() int {
+	dir, err := os.MkdirTemp("", "tlscheck")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "tls-check: %v\n", err)
+		return 1
+	}
+	defer os.RemoveAll(dir)
+
+	ca, _ := testcert.NewCA("tlscheck-ca")
+	otherCA, _ := testcert.NewCA("tlscheck-other-ca")
+	localhost := []net.IP{net.ParseIP("127.0.0.1")}
+	valid := time.Now().Add(24 * time.Hour)
+
+	srv, _ := ca.Issue("server", nil, localhost, valid, x509.ExtKeyUsageServerAuth)
+	expired, _ := ca.Issue("expired", nil, localhost, time.Now().Add(-time.Hour), x509.ExtKeyUsageServerAuth)
+	other, _ := otherCA.Issue("other", nil, localhost, valid, x509.ExtKeyUsageServerAuth)
+	named, _ := ca.Issue("named", []string{"collector.tlscheck"}, nil, valid, x509.ExtKeyUsageServerAuth)
+	cli, _ := ca.Issue("agent", nil, nil, valid, x509.ExtKeyUsageClientAuth)
+	otherPin, _ := otherCA.Issue("pin", nil, nil, valid, x509.ExtKeyUsageServerAuth)
+
+	caFile := writeFile(dir, "ca.pem", ca.CertPEM)
+	otherCAFile := writeFile(dir, "other-ca.pem", otherCA.CertPEM)
+	cliCertFile := writeFile(dir, "agent.pem", cli.CertPEM)
+	cliKeyFile := writeFile(dir, "agent-key.pem", cli.KeyPEM)
+
+	srvAddr, stop1, _ := startTLSStub(srv, nil)
+	defer stop1()
+	expAddr, stop2, _ := startTLSStub(expired, nil)
+	defer stop2()
+	otherAddr, stop3, _ := startTLSStub(other, nil)
+	defer stop3()
+	namedAddr, stop4, _ := startTLSStub(named, nil)
+	defer stop4()
+	mtlsAddr, stop5, _ := startTLSStub(srv, ca)
+	defer stop5()
+
+	cases := []struct {
+		name    string
+		addr    string
+		cfg     gapi.TLSConfig
+		wantErr bool
+	}{
+		{"tls ok", srvAddr, gapi.TLSConfig{CAFile: caFile}, false},
+		{"expired server cert", expAddr, gapi.TLSConfig{CAFile: caFile}, true},
+		{"wrong ca", otherAddr, gapi.TLSConfig{CAFile: caFile}, true},
+		{"wrong ca (client trusts other ca)", srvAddr, gapi.TLSConfig{CAFile: otherCAFile}, true},
+		{"server-name override", namedAddr, gapi.TLSConfig{CAFile: caFile, ServerName: "collector.tlscheck"}, false},
+		{"server-name mismatch", namedAddr, gapi.TLSConfig{CAFile: caFile}, true},
+		{"pin match", srvAddr, gapi.TLSConfig{CAFile: caFile, Pins: []string{srv.Pin}}, false},
+		{"pin mismatch", srvAddr, gapi.TLSConfig{CAFile: caFile, Pins: []string{otherPin.Pin}}, true},
+		{"mtls ok", mtlsAddr, gapi.TLSConfig{CAFile: caFile, CertFile: cliCertFile, KeyFile: cliKeyFile}, false},
+		{"mtls without client cert", mtlsAddr, gapi.TLSConfig{CAFile: caFile}, true},
+	}
+
+	failed := 0
+	for _, tc := range cases {
+		err := tlsHeartbeat(tc.addr, tc.cfg)
+		ok := (err != nil) == tc.wantErr
+		if !ok {
+			failed++
+		}
+		fmt.Printf("%-4s %-36s err=%v\n", passFail(ok), tc.name, err)
+	}
+
+	// hot-reload : 다른 CA 를 신뢰하다가 CA 파일이 교체되면 같은 클라이언트로 연결 성공
+	ok := checkReload(dir, srvAddr, ca.CertPEM, otherCA.CertPEM)
+	if !ok {
+		failed++
+	}
+	fmt.Printf("%-4s %-36s\n", passFail(ok), "ca hot-reload")
+
+	fmt.Printf("\n%d/%d cases passed\n", len(cases)+1-failed, len(cases)+1)
+	return failed
+}
+
+func checkReload(dir, addr string, goodCA, wrongCA []byte) bool {
+	caFile := writeFile(dir, "reload-ca.pem", wrongCA)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	ct := &container.Container{Bus: databus.NewDataBus()}
+	client, err := gapi.NewClient(&wg, ct, make(chan pipeline.Message), addr, "tls-check", gapi.WithTLS(gapi.TLSConfig{CAFile: caFile}))
+	if err != nil {
+		return false
+	}
+	defer client.Shutdown()
+
+	if _, err := client.Heartbeat(&pb.HeartbeatRequest{}); err == nil {
+		return false // 교체 전에는 실패해야 함
+	}
+
+	writeFile(dir, "reload-ca.pem", goodCA)
+	future := time.Now().Add(time.Minute)
+	os.Chtimes(caFile, future, future)
+
+	deadline := time.Now().Add(20 * time.Second)
+	for time.Now().Before(deadline) {
+		if _, err := client.Heartbeat(&pb.HeartbeatRequest{}); err == nil {
+			return client.Status().CertReloads > 0
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+	return false
+}
+
+func passFail(ok bool) string {
+	if ok {
+		return "PASS"
+	}
+	return "FAIL"
+}
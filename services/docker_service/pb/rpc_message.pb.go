@@ -73,6 +73,8 @@ type AgentMessage struct {
 	Type      DataType               `protobuf:"varint,3,opt,name=type,proto3,enum=pb.DataType" json:"type,omitempty"`
 	Host      string                 `protobuf:"bytes,4,opt,name=host,proto3" json:"host,omitempty"`
 	Timestamp int64                  `protobuf:"varint,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Scope     string                 `protobuf:"bytes,6,opt,name=scope,proto3" json:"scope,omitempty"` // "" : 호스트 전체, container id : 해당 컨테이너만 (이벤트 트리거 수집)
+	Seq       uint64                 `protobuf:"varint,7,opt,name=seq,proto3" json:"seq,omitempty"`    // DataStream 전송 순번 (1부터 증가, unary 전송은 0)
 	// Types that are valid to be assigned to Data:
 	//
 	//	*AgentMessage_ListData
@@ -149,6 +151,20 @@ func (x *AgentMessage) GetTimestamp() int64 {
 	return 0
 }
 
+func (x *AgentMessage) GetScope() string {
+	if x != nil {
+		return x.Scope
+	}
+	return ""
+}
+
+func (x *AgentMessage) GetSeq() uint64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
 func (x *AgentMessage) GetData() isAgentMessage_Data {
 	if x != nil {
 		return x.Data
@@ -220,26 +236,763 @@ func (*AgentMessage_StatsData) isAgentMessage_Data() {}
 
 func (*AgentMessage_EventData) isAgentMessage_Data() {}
 
+// 배치 전송 (size 또는 flush 주기 도달 시 한 번에 전송)
+type AgentMessageBatch struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Messages      []*AgentMessage        `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AgentMessageBatch) Reset() {
+	*x = AgentMessageBatch{}
+	mi := &file_rpc_message_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgentMessageBatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentMessageBatch) ProtoMessage() {}
+
+func (x *AgentMessageBatch) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_message_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgentMessageBatch.ProtoReflect.Descriptor instead.
+func (*AgentMessageBatch) Descriptor() ([]byte, []int) {
+	return file_rpc_message_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *AgentMessageBatch) GetMessages() []*AgentMessage {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+// Register 연결(재연결) 시 핸드셰이크
+type HostInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Addr          string                 `protobuf:"bytes,2,opt,name=addr,proto3" json:"addr,omitempty"`
+	Mode          int32                  `protobuf:"varint,3,opt,name=mode,proto3" json:"mode,omitempty"` // 1:docker.sock, 2:tls
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HostInfo) Reset() {
+	*x = HostInfo{}
+	mi := &file_rpc_message_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HostInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HostInfo) ProtoMessage() {}
+
+func (x *HostInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_message_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HostInfo.ProtoReflect.Descriptor instead.
+func (*HostInfo) Descriptor() ([]byte, []int) {
+	return file_rpc_message_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *HostInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *HostInfo) GetAddr() string {
+	if x != nil {
+		return x.Addr
+	}
+	return ""
+}
+
+func (x *HostInfo) GetMode() int32 {
+	if x != nil {
+		return x.Mode
+	}
+	return 0
+}
+
+type RegisterRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Agentid       int32                  `protobuf:"varint,1,opt,name=agentid,proto3" json:"agentid,omitempty"`
+	AgentKey      string                 `protobuf:"bytes,2,opt,name=agent_key,json=agentKey,proto3" json:"agent_key,omitempty"`
+	AgentName     string                 `protobuf:"bytes,3,opt,name=agent_name,json=agentName,proto3" json:"agent_name,omitempty"`
+	Version       string                 `protobuf:"bytes,4,opt,name=version,proto3" json:"version,omitempty"`
+	Hosts         []*HostInfo            `protobuf:"bytes,5,rep,name=hosts,proto3" json:"hosts,omitempty"`
+	DataTypes     []DataType             `protobuf:"varint,6,rep,packed,name=data_types,json=dataTypes,proto3,enum=pb.DataType" json:"data_types,omitempty"` // 전송하는 데이터 타입
+	Commands      []CommandType          `protobuf:"varint,7,rep,packed,name=commands,proto3,enum=pb.CommandType" json:"commands,omitempty"`                 // 실행 허용 원격 명령
+	Timestamp     int64                  `protobuf:"varint,8,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterRequest) Reset() {
+	*x = RegisterRequest{}
+	mi := &file_rpc_message_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterRequest) ProtoMessage() {}
+
+func (x *RegisterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_message_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterRequest.ProtoReflect.Descriptor instead.
+func (*RegisterRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_message_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RegisterRequest) GetAgentid() int32 {
+	if x != nil {
+		return x.Agentid
+	}
+	return 0
+}
+
+func (x *RegisterRequest) GetAgentKey() string {
+	if x != nil {
+		return x.AgentKey
+	}
+	return ""
+}
+
+func (x *RegisterRequest) GetAgentName() string {
+	if x != nil {
+		return x.AgentName
+	}
+	return ""
+}
+
+func (x *RegisterRequest) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *RegisterRequest) GetHosts() []*HostInfo {
+	if x != nil {
+		return x.Hosts
+	}
+	return nil
+}
+
+func (x *RegisterRequest) GetDataTypes() []DataType {
+	if x != nil {
+		return x.DataTypes
+	}
+	return nil
+}
+
+func (x *RegisterRequest) GetCommands() []CommandType {
+	if x != nil {
+		return x.Commands
+	}
+	return nil
+}
+
+func (x *RegisterRequest) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+type RegisterResponse struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Success              bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message              string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Agentid              int32                  `protobuf:"varint,3,opt,name=agentid,proto3" json:"agentid,omitempty"`
+	CollectorSettings    string                 `protobuf:"bytes,4,opt,name=collector_settings,json=collectorSettings,proto3" json:"collector_settings,omitempty"`             // PIPELINE_COLLECTORS 와 같은 JSON ("" : agent 설정 유지)
+	HeartbeatIntervalSec int32                  `protobuf:"varint,5,opt,name=heartbeat_interval_sec,json=heartbeatIntervalSec,proto3" json:"heartbeat_interval_sec,omitempty"` // 0 : agent 기본값
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *RegisterResponse) Reset() {
+	*x = RegisterResponse{}
+	mi := &file_rpc_message_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterResponse) ProtoMessage() {}
+
+func (x *RegisterResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_message_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterResponse.ProtoReflect.Descriptor instead.
+func (*RegisterResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_message_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RegisterResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *RegisterResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *RegisterResponse) GetAgentid() int32 {
+	if x != nil {
+		return x.Agentid
+	}
+	return 0
+}
+
+func (x *RegisterResponse) GetCollectorSettings() string {
+	if x != nil {
+		return x.CollectorSettings
+	}
+	return ""
+}
+
+func (x *RegisterResponse) GetHeartbeatIntervalSec() int32 {
+	if x != nil {
+		return x.HeartbeatIntervalSec
+	}
+	return 0
+}
+
+// Heartbeat 주기적 상태 보고
+type HeartbeatRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Agentid       int32                  `protobuf:"varint,1,opt,name=agentid,proto3" json:"agentid,omitempty"`
+	AgentKey      string                 `protobuf:"bytes,2,opt,name=agent_key,json=agentKey,proto3" json:"agent_key,omitempty"`
+	Timestamp     int64                  `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Health        string                 `protobuf:"bytes,4,opt,name=health,proto3" json:"health,omitempty"`                                                                                                      // ok, degraded
+	QueueDepth    map[string]int32       `protobuf:"bytes,5,rep,name=queue_depth,json=queueDepth,proto3" json:"queue_depth,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"` // data type -> 전송 대기 메시지 수
+	SendChLen     int32                  `protobuf:"varint,6,opt,name=send_ch_len,json=sendChLen,proto3" json:"send_ch_len,omitempty"`
+	Inflight      int32                  `protobuf:"varint,7,opt,name=inflight,proto3" json:"inflight,omitempty"` // DataStream ACK 대기 메시지 수
+	LastError     string                 `protobuf:"bytes,8,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HeartbeatRequest) Reset() {
+	*x = HeartbeatRequest{}
+	mi := &file_rpc_message_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HeartbeatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeartbeatRequest) ProtoMessage() {}
+
+func (x *HeartbeatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_message_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeartbeatRequest.ProtoReflect.Descriptor instead.
+func (*HeartbeatRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_message_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *HeartbeatRequest) GetAgentid() int32 {
+	if x != nil {
+		return x.Agentid
+	}
+	return 0
+}
+
+func (x *HeartbeatRequest) GetAgentKey() string {
+	if x != nil {
+		return x.AgentKey
+	}
+	return ""
+}
+
+func (x *HeartbeatRequest) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *HeartbeatRequest) GetHealth() string {
+	if x != nil {
+		return x.Health
+	}
+	return ""
+}
+
+func (x *HeartbeatRequest) GetQueueDepth() map[string]int32 {
+	if x != nil {
+		return x.QueueDepth
+	}
+	return nil
+}
+
+func (x *HeartbeatRequest) GetSendChLen() int32 {
+	if x != nil {
+		return x.SendChLen
+	}
+	return 0
+}
+
+func (x *HeartbeatRequest) GetInflight() int32 {
+	if x != nil {
+		return x.Inflight
+	}
+	return 0
+}
+
+func (x *HeartbeatRequest) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+type HeartbeatResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	ServerTime    int64                  `protobuf:"varint,2,opt,name=server_time,json=serverTime,proto3" json:"server_time,omitempty"` // unix milli
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HeartbeatResponse) Reset() {
+	*x = HeartbeatResponse{}
+	mi := &file_rpc_message_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HeartbeatResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeartbeatResponse) ProtoMessage() {}
+
+func (x *HeartbeatResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_message_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeartbeatResponse.ProtoReflect.Descriptor instead.
+func (*HeartbeatResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_message_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *HeartbeatResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *HeartbeatResponse) GetServerTime() int64 {
+	if x != nil {
+		return x.ServerTime
+	}
+	return 0
+}
+
+// Enroll 최초 등록 (bootstrap token -> agent ID, agent key 발급)
+type EnrollRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	BootstrapToken string                 `protobuf:"bytes,1,opt,name=bootstrap_token,json=bootstrapToken,proto3" json:"bootstrap_token,omitempty"` // 서버가 발급한 단기 토큰 (1회용)
+	AgentName      string                 `protobuf:"bytes,2,opt,name=agent_name,json=agentName,proto3" json:"agent_name,omitempty"`
+	AgentAddress   string                 `protobuf:"bytes,3,opt,name=agent_address,json=agentAddress,proto3" json:"agent_address,omitempty"`
+	Version        string                 `protobuf:"bytes,4,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *EnrollRequest) Reset() {
+	*x = EnrollRequest{}
+	mi := &file_rpc_message_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnrollRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnrollRequest) ProtoMessage() {}
+
+func (x *EnrollRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_message_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnrollRequest.ProtoReflect.Descriptor instead.
+func (*EnrollRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_message_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *EnrollRequest) GetBootstrapToken() string {
+	if x != nil {
+		return x.BootstrapToken
+	}
+	return ""
+}
+
+func (x *EnrollRequest) GetAgentName() string {
+	if x != nil {
+		return x.AgentName
+	}
+	return ""
+}
+
+func (x *EnrollRequest) GetAgentAddress() string {
+	if x != nil {
+		return x.AgentAddress
+	}
+	return ""
+}
+
+func (x *EnrollRequest) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+type EnrollResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Agentid       int32                  `protobuf:"varint,3,opt,name=agentid,proto3" json:"agentid,omitempty"`
+	AgentKey      string                 `protobuf:"bytes,4,opt,name=agent_key,json=agentKey,proto3" json:"agent_key,omitempty"` // 장기 자격 증명 (agent 가 상태 파일에 보관)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnrollResponse) Reset() {
+	*x = EnrollResponse{}
+	mi := &file_rpc_message_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnrollResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnrollResponse) ProtoMessage() {}
+
+func (x *EnrollResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_message_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnrollResponse.ProtoReflect.Descriptor instead.
+func (*EnrollResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_message_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *EnrollResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *EnrollResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *EnrollResponse) GetAgentid() int32 {
+	if x != nil {
+		return x.Agentid
+	}
+	return 0
+}
+
+func (x *EnrollResponse) GetAgentKey() string {
+	if x != nil {
+		return x.AgentKey
+	}
+	return ""
+}
+
+// RotateKey agent key 교체 (현재 agent-key 로 인증)
+type RotateKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Agentid       int32                  `protobuf:"varint,1,opt,name=agentid,proto3" json:"agentid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RotateKeyRequest) Reset() {
+	*x = RotateKeyRequest{}
+	mi := &file_rpc_message_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RotateKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotateKeyRequest) ProtoMessage() {}
+
+func (x *RotateKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_message_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotateKeyRequest.ProtoReflect.Descriptor instead.
+func (*RotateKeyRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_message_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *RotateKeyRequest) GetAgentid() int32 {
+	if x != nil {
+		return x.Agentid
+	}
+	return 0
+}
+
+type RotateKeyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	AgentKey      string                 `protobuf:"bytes,3,opt,name=agent_key,json=agentKey,proto3" json:"agent_key,omitempty"` // 새 agent key (이전 key 는 즉시 무효)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RotateKeyResponse) Reset() {
+	*x = RotateKeyResponse{}
+	mi := &file_rpc_message_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RotateKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotateKeyResponse) ProtoMessage() {}
+
+func (x *RotateKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_message_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotateKeyResponse.ProtoReflect.Descriptor instead.
+func (*RotateKeyResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_message_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RotateKeyResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *RotateKeyResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *RotateKeyResponse) GetAgentKey() string {
+	if x != nil {
+		return x.AgentKey
+	}
+	return ""
+}
+
 var File_rpc_message_proto protoreflect.FileDescriptor
 
 const file_rpc_message_proto_rawDesc = "" +
 	"\n" +
-	"\x11rpc_message.proto\x12\x02pb\x1a\x17container_message.proto\"\x19\n" +
+	"\x11rpc_message.proto\x12\x02pb\x1a\x17container_message.proto\x1a\x14server_message.proto\"\x19\n" +
 	"\x05Hello\x12\x10\n" +
-	"\x03msg\x18\x01 \x01(\tR\x03msg\"\x88\x03\n" +
+	"\x03msg\x18\x01 \x01(\tR\x03msg\"\xb0\x03\n" +
 	"\fAgentMessage\x12\x18\n" +
 	"\aagentid\x18\x01 \x01(\x05R\aagentid\x12\x1b\n" +
 	"\tagent_key\x18\x02 \x01(\tR\bagentKey\x12 \n" +
 	"\x04type\x18\x03 \x01(\x0e2\f.pb.DataTypeR\x04type\x12\x12\n" +
 	"\x04host\x18\x04 \x01(\tR\x04host\x12\x1c\n" +
-	"\ttimestamp\x18\x05 \x01(\x03R\ttimestamp\x124\n" +
+	"\ttimestamp\x18\x05 \x01(\x03R\ttimestamp\x12\x14\n" +
+	"\x05scope\x18\x06 \x01(\tR\x05scope\x12\x10\n" +
+	"\x03seq\x18\a \x01(\x04R\x03seq\x124\n" +
 	"\tlist_data\x18\v \x01(\v2\x15.pb.ContainerListDataH\x00R\blistData\x12=\n" +
 	"\finspect_data\x18\f \x01(\v2\x18.pb.ContainerInspectDataH\x00R\vinspectData\x127\n" +
 	"\n" +
 	"stats_data\x18\r \x01(\v2\x16.pb.ContainerStatsDataH\x00R\tstatsData\x127\n" +
 	"\n" +
 	"event_data\x18\x0e \x01(\v2\x16.pb.ContainerEventDataH\x00R\teventDataB\x06\n" +
-	"\x04dataB\x13Z\x11docker_service/pbb\x06proto3"
+	"\x04data\"A\n" +
+	"\x11AgentMessageBatch\x12,\n" +
+	"\bmessages\x18\x01 \x03(\v2\x10.pb.AgentMessageR\bmessages\"F\n" +
+	"\bHostInfo\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04addr\x18\x02 \x01(\tR\x04addr\x12\x12\n" +
+	"\x04mode\x18\x03 \x01(\x05R\x04mode\"\x9d\x02\n" +
+	"\x0fRegisterRequest\x12\x18\n" +
+	"\aagentid\x18\x01 \x01(\x05R\aagentid\x12\x1b\n" +
+	"\tagent_key\x18\x02 \x01(\tR\bagentKey\x12\x1d\n" +
+	"\n" +
+	"agent_name\x18\x03 \x01(\tR\tagentName\x12\x18\n" +
+	"\aversion\x18\x04 \x01(\tR\aversion\x12\"\n" +
+	"\x05hosts\x18\x05 \x03(\v2\f.pb.HostInfoR\x05hosts\x12+\n" +
+	"\n" +
+	"data_types\x18\x06 \x03(\x0e2\f.pb.DataTypeR\tdataTypes\x12+\n" +
+	"\bcommands\x18\a \x03(\x0e2\x0f.pb.CommandTypeR\bcommands\x12\x1c\n" +
+	"\ttimestamp\x18\b \x01(\x03R\ttimestamp\"\xc5\x01\n" +
+	"\x10RegisterResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x18\n" +
+	"\aagentid\x18\x03 \x01(\x05R\aagentid\x12-\n" +
+	"\x12collector_settings\x18\x04 \x01(\tR\x11collectorSettings\x124\n" +
+	"\x16heartbeat_interval_sec\x18\x05 \x01(\x05R\x14heartbeatIntervalSec\"\xe0\x02\n" +
+	"\x10HeartbeatRequest\x12\x18\n" +
+	"\aagentid\x18\x01 \x01(\x05R\aagentid\x12\x1b\n" +
+	"\tagent_key\x18\x02 \x01(\tR\bagentKey\x12\x1c\n" +
+	"\ttimestamp\x18\x03 \x01(\x03R\ttimestamp\x12\x16\n" +
+	"\x06health\x18\x04 \x01(\tR\x06health\x12E\n" +
+	"\vqueue_depth\x18\x05 \x03(\v2$.pb.HeartbeatRequest.QueueDepthEntryR\n" +
+	"queueDepth\x12\x1e\n" +
+	"\vsend_ch_len\x18\x06 \x01(\x05R\tsendChLen\x12\x1a\n" +
+	"\binflight\x18\a \x01(\x05R\binflight\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\b \x01(\tR\tlastError\x1a=\n" +
+	"\x0fQueueDepthEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\"N\n" +
+	"\x11HeartbeatResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x1f\n" +
+	"\vserver_time\x18\x02 \x01(\x03R\n" +
+	"serverTime\"\x96\x01\n" +
+	"\rEnrollRequest\x12'\n" +
+	"\x0fbootstrap_token\x18\x01 \x01(\tR\x0ebootstrapToken\x12\x1d\n" +
+	"\n" +
+	"agent_name\x18\x02 \x01(\tR\tagentName\x12#\n" +
+	"\ragent_address\x18\x03 \x01(\tR\fagentAddress\x12\x18\n" +
+	"\aversion\x18\x04 \x01(\tR\aversion\"{\n" +
+	"\x0eEnrollResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x18\n" +
+	"\aagentid\x18\x03 \x01(\x05R\aagentid\x12\x1b\n" +
+	"\tagent_key\x18\x04 \x01(\tR\bagentKey\",\n" +
+	"\x10RotateKeyRequest\x12\x18\n" +
+	"\aagentid\x18\x01 \x01(\x05R\aagentid\"d\n" +
+	"\x11RotateKeyResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1b\n" +
+	"\tagent_key\x18\x03 \x01(\tR\bagentKeyB\x13Z\x11docker_service/pbb\x06proto3"
 
 var (
 	file_rpc_message_proto_rawDescOnce sync.Once
@@ -253,27 +1006,44 @@ func file_rpc_message_proto_rawDescGZIP() []byte {
 	return file_rpc_message_proto_rawDescData
 }
 
-var file_rpc_message_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_rpc_message_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
 var file_rpc_message_proto_goTypes = []any{
 	(*Hello)(nil),                // 0: pb.Hello
 	(*AgentMessage)(nil),         // 1: pb.AgentMessage
-	(DataType)(0),                // 2: pb.DataType
-	(*ContainerListData)(nil),    // 3: pb.ContainerListData
-	(*ContainerInspectData)(nil), // 4: pb.ContainerInspectData
-	(*ContainerStatsData)(nil),   // 5: pb.ContainerStatsData
-	(*ContainerEventData)(nil),   // 6: pb.ContainerEventData
+	(*AgentMessageBatch)(nil),    // 2: pb.AgentMessageBatch
+	(*HostInfo)(nil),             // 3: pb.HostInfo
+	(*RegisterRequest)(nil),      // 4: pb.RegisterRequest
+	(*RegisterResponse)(nil),     // 5: pb.RegisterResponse
+	(*HeartbeatRequest)(nil),     // 6: pb.HeartbeatRequest
+	(*HeartbeatResponse)(nil),    // 7: pb.HeartbeatResponse
+	(*EnrollRequest)(nil),        // 8: pb.EnrollRequest
+	(*EnrollResponse)(nil),       // 9: pb.EnrollResponse
+	(*RotateKeyRequest)(nil),     // 10: pb.RotateKeyRequest
+	(*RotateKeyResponse)(nil),    // 11: pb.RotateKeyResponse
+	nil,                          // 12: pb.HeartbeatRequest.QueueDepthEntry
+	(DataType)(0),                // 13: pb.DataType
+	(*ContainerListData)(nil),    // 14: pb.ContainerListData
+	(*ContainerInspectData)(nil), // 15: pb.ContainerInspectData
+	(*ContainerStatsData)(nil),   // 16: pb.ContainerStatsData
+	(*ContainerEventData)(nil),   // 17: pb.ContainerEventData
+	(CommandType)(0),             // 18: pb.CommandType
 }
 var file_rpc_message_proto_depIdxs = []int32{
-	2, // 0: pb.AgentMessage.type:type_name -> pb.DataType
-	3, // 1: pb.AgentMessage.list_data:type_name -> pb.ContainerListData
-	4, // 2: pb.AgentMessage.inspect_data:type_name -> pb.ContainerInspectData
-	5, // 3: pb.AgentMessage.stats_data:type_name -> pb.ContainerStatsData
-	6, // 4: pb.AgentMessage.event_data:type_name -> pb.ContainerEventData
-	5, // [5:5] is the sub-list for method output_type
-	5, // [5:5] is the sub-list for method input_type
-	5, // [5:5] is the sub-list for extension type_name
-	5, // [5:5] is the sub-list for extension extendee
-	0, // [0:5] is the sub-list for field type_name
+	13, // 0: pb.AgentMessage.type:type_name -> pb.DataType
+	14, // 1: pb.AgentMessage.list_data:type_name -> pb.ContainerListData
+	15, // 2: pb.AgentMessage.inspect_data:type_name -> pb.ContainerInspectData
+	16, // 3: pb.AgentMessage.stats_data:type_name -> pb.ContainerStatsData
+	17, // 4: pb.AgentMessage.event_data:type_name -> pb.ContainerEventData
+	1,  // 5: pb.AgentMessageBatch.messages:type_name -> pb.AgentMessage
+	3,  // 6: pb.RegisterRequest.hosts:type_name -> pb.HostInfo
+	13, // 7: pb.RegisterRequest.data_types:type_name -> pb.DataType
+	18, // 8: pb.RegisterRequest.commands:type_name -> pb.CommandType
+	12, // 9: pb.HeartbeatRequest.queue_depth:type_name -> pb.HeartbeatRequest.QueueDepthEntry
+	10, // [10:10] is the sub-list for method output_type
+	10, // [10:10] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
 }
 
 func init() { file_rpc_message_proto_init() }
@@ -282,6 +1052,7 @@ func file_rpc_message_proto_init() {
 		return
 	}
 	file_container_message_proto_init()
+	file_server_message_proto_init()
 	file_rpc_message_proto_msgTypes[1].OneofWrappers = []any{
 		(*AgentMessage_ListData)(nil),
 		(*AgentMessage_InspectData)(nil),
@@ -294,7 +1065,7 @@ func file_rpc_message_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rpc_message_proto_rawDesc), len(file_rpc_message_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   13,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -24,7 +24,7 @@ var File_rpc_service_proto protoreflect.FileDescriptor
 
 const file_rpc_service_proto_rawDesc = "" +
 	"\n" +
-	"\x11rpc_service.proto\x12\x02pb\x1a\x11rpc_message.proto\x1a\x14server_message.proto\x1a\x12test_message.proto\x1a\x17container_message.proto2\xc0\x03\n" +
+	"\x11rpc_service.proto\x12\x02pb\x1a\x11rpc_message.proto\x1a\x14server_message.proto\x1a\x12test_message.proto\x1a\x17container_message.proto2\x8f\x06\n" +
 	"\x10ContainerService\x12'\n" +
 	"\vConnMessage\x12\t.pb.Hello\x1a\t.pb.Hello(\x010\x01\x125\n" +
 	"\n" +
@@ -34,37 +34,65 @@ const file_rpc_service_proto_rawDesc = "" +
 	"\rContainerInfo\x12\x10.pb.AgentMessage\x1a\x11.pb.ServerMessage\x127\n" +
 	"\x10ContainerInspect\x12\x10.pb.AgentMessage\x1a\x11.pb.ServerMessage\x125\n" +
 	"\x0eContainerStats\x12\x10.pb.AgentMessage\x1a\x11.pb.ServerMessage\x125\n" +
-	"\x0eContainerEvent\x12\x10.pb.AgentMessage\x1a\x11.pb.ServerMessageB\x13Z\x11docker_service/pbb\x06proto3"
+	"\x0eContainerEvent\x12\x10.pb.AgentMessage\x1a\x11.pb.ServerMessage\x12:\n" +
+	"\x0eContainerBatch\x12\x15.pb.AgentMessageBatch\x1a\x11.pb.ServerMessage\x125\n" +
+	"\bRegister\x12\x13.pb.RegisterRequest\x1a\x14.pb.RegisterResponse\x128\n" +
+	"\tHeartbeat\x12\x14.pb.HeartbeatRequest\x1a\x15.pb.HeartbeatResponse\x12/\n" +
+	"\x06Enroll\x12\x11.pb.EnrollRequest\x1a\x12.pb.EnrollResponse\x128\n" +
+	"\tRotateKey\x12\x14.pb.RotateKeyRequest\x1a\x15.pb.RotateKeyResponse\x125\n" +
+	"\rReportCommand\x12\x11.pb.CommandResult\x1a\x11.pb.ServerMessageB\x13Z\x11docker_service/pbb\x06proto3"
 
 var file_rpc_service_proto_goTypes = []any{
 	(*Hello)(nil),             // 0: pb.Hello
 	(*AgentMessage)(nil),      // 1: pb.AgentMessage
 	(*LoginUserRequest)(nil),  // 2: pb.LoginUserRequest
-	(*ServerMessage)(nil),     // 3: pb.ServerMessage
-	(*LoginUserResponse)(nil), // 4: pb.LoginUserResponse
+	(*AgentMessageBatch)(nil), // 3: pb.AgentMessageBatch
+	(*RegisterRequest)(nil),   // 4: pb.RegisterRequest
+	(*HeartbeatRequest)(nil),  // 5: pb.HeartbeatRequest
+	(*EnrollRequest)(nil),     // 6: pb.EnrollRequest
+	(*RotateKeyRequest)(nil),  // 7: pb.RotateKeyRequest
+	(*CommandResult)(nil),     // 8: pb.CommandResult
+	(*ServerMessage)(nil),     // 9: pb.ServerMessage
+	(*LoginUserResponse)(nil), // 10: pb.LoginUserResponse
+	(*RegisterResponse)(nil),  // 11: pb.RegisterResponse
+	(*HeartbeatResponse)(nil), // 12: pb.HeartbeatResponse
+	(*EnrollResponse)(nil),    // 13: pb.EnrollResponse
+	(*RotateKeyResponse)(nil), // 14: pb.RotateKeyResponse
 }
 var file_rpc_service_proto_depIdxs = []int32{
-	0, // 0: pb.ContainerService.ConnMessage:input_type -> pb.Hello
-	1, // 1: pb.ContainerService.DataStream:input_type -> pb.AgentMessage
-	2, // 2: pb.ContainerService.LoginUser:input_type -> pb.LoginUserRequest
-	1, // 3: pb.ContainerService.ContainerState:input_type -> pb.AgentMessage
-	1, // 4: pb.ContainerService.ContainerInfo:input_type -> pb.AgentMessage
-	1, // 5: pb.ContainerService.ContainerInspect:input_type -> pb.AgentMessage
-	1, // 6: pb.ContainerService.ContainerStats:input_type -> pb.AgentMessage
-	1, // 7: pb.ContainerService.ContainerEvent:input_type -> pb.AgentMessage
-	0, // 8: pb.ContainerService.ConnMessage:output_type -> pb.Hello
-	3, // 9: pb.ContainerService.DataStream:output_type -> pb.ServerMessage
-	4, // 10: pb.ContainerService.LoginUser:output_type -> pb.LoginUserResponse
-	3, // 11: pb.ContainerService.ContainerState:output_type -> pb.ServerMessage
-	3, // 12: pb.ContainerService.ContainerInfo:output_type -> pb.ServerMessage
-	3, // 13: pb.ContainerService.ContainerInspect:output_type -> pb.ServerMessage
-	3, // 14: pb.ContainerService.ContainerStats:output_type -> pb.ServerMessage
-	3, // 15: pb.ContainerService.ContainerEvent:output_type -> pb.ServerMessage
-	8, // [8:16] is the sub-list for method output_type
-	0, // [0:8] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	0,  // 0: pb.ContainerService.ConnMessage:input_type -> pb.Hello
+	1,  // 1: pb.ContainerService.DataStream:input_type -> pb.AgentMessage
+	2,  // 2: pb.ContainerService.LoginUser:input_type -> pb.LoginUserRequest
+	1,  // 3: pb.ContainerService.ContainerState:input_type -> pb.AgentMessage
+	1,  // 4: pb.ContainerService.ContainerInfo:input_type -> pb.AgentMessage
+	1,  // 5: pb.ContainerService.ContainerInspect:input_type -> pb.AgentMessage
+	1,  // 6: pb.ContainerService.ContainerStats:input_type -> pb.AgentMessage
+	1,  // 7: pb.ContainerService.ContainerEvent:input_type -> pb.AgentMessage
+	3,  // 8: pb.ContainerService.ContainerBatch:input_type -> pb.AgentMessageBatch
+	4,  // 9: pb.ContainerService.Register:input_type -> pb.RegisterRequest
+	5,  // 10: pb.ContainerService.Heartbeat:input_type -> pb.HeartbeatRequest
+	6,  // 11: pb.ContainerService.Enroll:input_type -> pb.EnrollRequest
+	7,  // 12: pb.ContainerService.RotateKey:input_type -> pb.RotateKeyRequest
+	8,  // 13: pb.ContainerService.ReportCommand:input_type -> pb.CommandResult
+	0,  // 14: pb.ContainerService.ConnMessage:output_type -> pb.Hello
+	9,  // 15: pb.ContainerService.DataStream:output_type -> pb.ServerMessage
+	10, // 16: pb.ContainerService.LoginUser:output_type -> pb.LoginUserResponse
+	9,  // 17: pb.ContainerService.ContainerState:output_type -> pb.ServerMessage
+	9,  // 18: pb.ContainerService.ContainerInfo:output_type -> pb.ServerMessage
+	9,  // 19: pb.ContainerService.ContainerInspect:output_type -> pb.ServerMessage
+	9,  // 20: pb.ContainerService.ContainerStats:output_type -> pb.ServerMessage
+	9,  // 21: pb.ContainerService.ContainerEvent:output_type -> pb.ServerMessage
+	9,  // 22: pb.ContainerService.ContainerBatch:output_type -> pb.ServerMessage
+	11, // 23: pb.ContainerService.Register:output_type -> pb.RegisterResponse
+	12, // 24: pb.ContainerService.Heartbeat:output_type -> pb.HeartbeatResponse
+	13, // 25: pb.ContainerService.Enroll:output_type -> pb.EnrollResponse
+	14, // 26: pb.ContainerService.RotateKey:output_type -> pb.RotateKeyResponse
+	9,  // 27: pb.ContainerService.ReportCommand:output_type -> pb.ServerMessage
+	14, // [14:28] is the sub-list for method output_type
+	0,  // [0:14] is the sub-list for method input_type
+	0,  // [0:0] is the sub-list for extension type_name
+	0,  // [0:0] is the sub-list for extension extendee
+	0,  // [0:0] is the sub-list for field type_name
 }
 
 func init() { file_rpc_service_proto_init() }
@@ -27,6 +27,12 @@ const (
 	ContainerService_ContainerInspect_FullMethodName = "/pb.ContainerService/ContainerInspect"
 	ContainerService_ContainerStats_FullMethodName   = "/pb.ContainerService/ContainerStats"
 	ContainerService_ContainerEvent_FullMethodName   = "/pb.ContainerService/ContainerEvent"
+	ContainerService_ContainerBatch_FullMethodName   = "/pb.ContainerService/ContainerBatch"
+	ContainerService_Register_FullMethodName         = "/pb.ContainerService/Register"
+	ContainerService_Heartbeat_FullMethodName        = "/pb.ContainerService/Heartbeat"
+	ContainerService_Enroll_FullMethodName           = "/pb.ContainerService/Enroll"
+	ContainerService_RotateKey_FullMethodName        = "/pb.ContainerService/RotateKey"
+	ContainerService_ReportCommand_FullMethodName    = "/pb.ContainerService/ReportCommand"
 )
 
 // ContainerServiceClient is the client API for ContainerService service.
@@ -43,6 +49,16 @@ type ContainerServiceClient interface {
 	ContainerInspect(ctx context.Context, in *AgentMessage, opts ...grpc.CallOption) (*ServerMessage, error)
 	ContainerStats(ctx context.Context, in *AgentMessage, opts ...grpc.CallOption) (*ServerMessage, error)
 	ContainerEvent(ctx context.Context, in *AgentMessage, opts ...grpc.CallOption) (*ServerMessage, error)
+	// batch (AgentMessage 묶음 전송)
+	ContainerBatch(ctx context.Context, in *AgentMessageBatch, opts ...grpc.CallOption) (*ServerMessage, error)
+	// 핸드셰이크 / 상태 보고
+	Register(ctx context.Context, in *RegisterRequest, opts ...grpc.CallOption) (*RegisterResponse, error)
+	Heartbeat(ctx context.Context, in *HeartbeatRequest, opts ...grpc.CallOption) (*HeartbeatResponse, error)
+	// 최초 등록 / 자격 증명 교체
+	Enroll(ctx context.Context, in *EnrollRequest, opts ...grpc.CallOption) (*EnrollResponse, error)
+	RotateKey(ctx context.Context, in *RotateKeyRequest, opts ...grpc.CallOption) (*RotateKeyResponse, error)
+	// 원격 명령 실행 결과 보고
+	ReportCommand(ctx context.Context, in *CommandResult, opts ...grpc.CallOption) (*ServerMessage, error)
 }
 
 type containerServiceClient struct {
@@ -139,6 +155,66 @@ func (c *containerServiceClient) ContainerEvent(ctx context.Context, in *AgentMe
 	return out, nil
 }
 
+func (c *containerServiceClient) ContainerBatch(ctx context.Context, in *AgentMessageBatch, opts ...grpc.CallOption) (*ServerMessage, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ServerMessage)
+	err := c.cc.Invoke(ctx, ContainerService_ContainerBatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *containerServiceClient) Register(ctx context.Context, in *RegisterRequest, opts ...grpc.CallOption) (*RegisterResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RegisterResponse)
+	err := c.cc.Invoke(ctx, ContainerService_Register_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *containerServiceClient) Heartbeat(ctx context.Context, in *HeartbeatRequest, opts ...grpc.CallOption) (*HeartbeatResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HeartbeatResponse)
+	err := c.cc.Invoke(ctx, ContainerService_Heartbeat_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *containerServiceClient) Enroll(ctx context.Context, in *EnrollRequest, opts ...grpc.CallOption) (*EnrollResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EnrollResponse)
+	err := c.cc.Invoke(ctx, ContainerService_Enroll_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *containerServiceClient) RotateKey(ctx context.Context, in *RotateKeyRequest, opts ...grpc.CallOption) (*RotateKeyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RotateKeyResponse)
+	err := c.cc.Invoke(ctx, ContainerService_RotateKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *containerServiceClient) ReportCommand(ctx context.Context, in *CommandResult, opts ...grpc.CallOption) (*ServerMessage, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ServerMessage)
+	err := c.cc.Invoke(ctx, ContainerService_ReportCommand_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ContainerServiceServer is the server API for ContainerService service.
 // All implementations must embed UnimplementedContainerServiceServer
 // for forward compatibility.
@@ -153,6 +229,16 @@ type ContainerServiceServer interface {
 	ContainerInspect(context.Context, *AgentMessage) (*ServerMessage, error)
 	ContainerStats(context.Context, *AgentMessage) (*ServerMessage, error)
 	ContainerEvent(context.Context, *AgentMessage) (*ServerMessage, error)
+	// batch (AgentMessage 묶음 전송)
+	ContainerBatch(context.Context, *AgentMessageBatch) (*ServerMessage, error)
+	// 핸드셰이크 / 상태 보고
+	Register(context.Context, *RegisterRequest) (*RegisterResponse, error)
+	Heartbeat(context.Context, *HeartbeatRequest) (*HeartbeatResponse, error)
+	// 최초 등록 / 자격 증명 교체
+	Enroll(context.Context, *EnrollRequest) (*EnrollResponse, error)
+	RotateKey(context.Context, *RotateKeyRequest) (*RotateKeyResponse, error)
+	// 원격 명령 실행 결과 보고
+	ReportCommand(context.Context, *CommandResult) (*ServerMessage, error)
 	mustEmbedUnimplementedContainerServiceServer()
 }
 
@@ -187,6 +273,24 @@ func (UnimplementedContainerServiceServer) ContainerStats(context.Context, *Agen
 func (UnimplementedContainerServiceServer) ContainerEvent(context.Context, *AgentMessage) (*ServerMessage, error) {
 	return nil, status.Error(codes.Unimplemented, "method ContainerEvent not implemented")
 }
+func (UnimplementedContainerServiceServer) ContainerBatch(context.Context, *AgentMessageBatch) (*ServerMessage, error) {
+	return nil, status.Error(codes.Unimplemented, "method ContainerBatch not implemented")
+}
+func (UnimplementedContainerServiceServer) Register(context.Context, *RegisterRequest) (*RegisterResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Register not implemented")
+}
+func (UnimplementedContainerServiceServer) Heartbeat(context.Context, *HeartbeatRequest) (*HeartbeatResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Heartbeat not implemented")
+}
+func (UnimplementedContainerServiceServer) Enroll(context.Context, *EnrollRequest) (*EnrollResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Enroll not implemented")
+}
+func (UnimplementedContainerServiceServer) RotateKey(context.Context, *RotateKeyRequest) (*RotateKeyResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RotateKey not implemented")
+}
+func (UnimplementedContainerServiceServer) ReportCommand(context.Context, *CommandResult) (*ServerMessage, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReportCommand not implemented")
+}
 func (UnimplementedContainerServiceServer) mustEmbedUnimplementedContainerServiceServer() {}
 func (UnimplementedContainerServiceServer) testEmbeddedByValue()                          {}
 
@@ -330,6 +434,114 @@ func _ContainerService_ContainerEvent_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ContainerService_ContainerBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AgentMessageBatch)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContainerServiceServer).ContainerBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ContainerService_ContainerBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContainerServiceServer).ContainerBatch(ctx, req.(*AgentMessageBatch))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ContainerService_Register_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContainerServiceServer).Register(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ContainerService_Register_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContainerServiceServer).Register(ctx, req.(*RegisterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ContainerService_Heartbeat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HeartbeatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContainerServiceServer).Heartbeat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ContainerService_Heartbeat_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContainerServiceServer).Heartbeat(ctx, req.(*HeartbeatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ContainerService_Enroll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnrollRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContainerServiceServer).Enroll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ContainerService_Enroll_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContainerServiceServer).Enroll(ctx, req.(*EnrollRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ContainerService_RotateKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RotateKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContainerServiceServer).RotateKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ContainerService_RotateKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContainerServiceServer).RotateKey(ctx, req.(*RotateKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ContainerService_ReportCommand_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CommandResult)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContainerServiceServer).ReportCommand(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ContainerService_ReportCommand_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContainerServiceServer).ReportCommand(ctx, req.(*CommandResult))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ContainerService_ServiceDesc is the grpc.ServiceDesc for ContainerService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -361,6 +573,30 @@ var ContainerService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ContainerEvent",
 			Handler:    _ContainerService_ContainerEvent_Handler,
 		},
+		{
+			MethodName: "ContainerBatch",
+			Handler:    _ContainerService_ContainerBatch_Handler,
+		},
+		{
+			MethodName: "Register",
+			Handler:    _ContainerService_Register_Handler,
+		},
+		{
+			MethodName: "Heartbeat",
+			Handler:    _ContainerService_Heartbeat_Handler,
+		},
+		{
+			MethodName: "Enroll",
+			Handler:    _ContainerService_Enroll_Handler,
+		},
+		{
+			MethodName: "RotateKey",
+			Handler:    _ContainerService_RotateKey_Handler,
+		},
+		{
+			MethodName: "ReportCommand",
+			Handler:    _ContainerService_ReportCommand_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -28,6 +28,11 @@ const (
 	CommandType_START_CONTAINER   CommandType = 1
 	CommandType_STOP_CONTAINER    CommandType = 2
 	CommandType_RESTART_CONTAINER CommandType = 3
+	CommandType_PAUSE_CONTAINER   CommandType = 4
+	CommandType_UNPAUSE_CONTAINER CommandType = 5
+	CommandType_REMOVE_CONTAINER  CommandType = 6 // args["force"]="true" : 실행 중이어도 삭제
+	CommandType_PULL_IMAGE        CommandType = 7 // args["image"] : 이미지 참조 (ex: nginx:1.27)
+	CommandType_COLLECT_NOW       CommandType = 8 // 즉시 수집, args["types"] : list,inspect,stat (생략 시 전체)
 )
 
 // Enum value maps for CommandType.
@@ -37,12 +42,22 @@ var (
 		1: "START_CONTAINER",
 		2: "STOP_CONTAINER",
 		3: "RESTART_CONTAINER",
+		4: "PAUSE_CONTAINER",
+		5: "UNPAUSE_CONTAINER",
+		6: "REMOVE_CONTAINER",
+		7: "PULL_IMAGE",
+		8: "COLLECT_NOW",
 	}
 	CommandType_value = map[string]int32{
 		"ACK":               0,
 		"START_CONTAINER":   1,
 		"STOP_CONTAINER":    2,
 		"RESTART_CONTAINER": 3,
+		"PAUSE_CONTAINER":   4,
+		"UNPAUSE_CONTAINER": 5,
+		"REMOVE_CONTAINER":  6,
+		"PULL_IMAGE":        7,
+		"COLLECT_NOW":       8,
 	}
 )
 
@@ -78,6 +93,9 @@ type ServerMessage struct {
 	Command         CommandType            `protobuf:"varint,1,opt,name=command,proto3,enum=pb.CommandType" json:"command,omitempty"`
 	TargetContainer string                 `protobuf:"bytes,2,opt,name=target_container,json=targetContainer,proto3" json:"target_container,omitempty"`
 	Host            string                 `protobuf:"bytes,3,opt,name=host,proto3" json:"host,omitempty"`
+	AckSeq          uint64                 `protobuf:"varint,4,opt,name=ack_seq,json=ackSeq,proto3" json:"ack_seq,omitempty"`                                                        // command=ACK : ack_seq 이하의 DataStream 메시지 수신 완료 (누적 ACK)
+	CommandId       string                 `protobuf:"bytes,5,opt,name=command_id,json=commandId,proto3" json:"command_id,omitempty"`                                                // 명령 상관관계 ID (CommandResult 에 그대로 반환)
+	Args            map[string]string      `protobuf:"bytes,6,rep,name=args,proto3" json:"args,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // 명령별 추가 인자
 	unknownFields   protoimpl.UnknownFields
 	sizeCache       protoimpl.SizeCache
 }
@@ -133,20 +151,165 @@ func (x *ServerMessage) GetHost() string {
 	return ""
 }
 
+func (x *ServerMessage) GetAckSeq() uint64 {
+	if x != nil {
+		return x.AckSeq
+	}
+	return 0
+}
+
+func (x *ServerMessage) GetCommandId() string {
+	if x != nil {
+		return x.CommandId
+	}
+	return ""
+}
+
+func (x *ServerMessage) GetArgs() map[string]string {
+	if x != nil {
+		return x.Args
+	}
+	return nil
+}
+
+// CommandResult 명령 실행 결과 (agent -> server)
+type CommandResult struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	CommandId       string                 `protobuf:"bytes,1,opt,name=command_id,json=commandId,proto3" json:"command_id,omitempty"`
+	Command         CommandType            `protobuf:"varint,2,opt,name=command,proto3,enum=pb.CommandType" json:"command,omitempty"`
+	Host            string                 `protobuf:"bytes,3,opt,name=host,proto3" json:"host,omitempty"`
+	TargetContainer string                 `protobuf:"bytes,4,opt,name=target_container,json=targetContainer,proto3" json:"target_container,omitempty"`
+	Success         bool                   `protobuf:"varint,5,opt,name=success,proto3" json:"success,omitempty"`
+	Error           string                 `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	Agentid         int32                  `protobuf:"varint,7,opt,name=agentid,proto3" json:"agentid,omitempty"`
+	Timestamp       int64                  `protobuf:"varint,8,opt,name=timestamp,proto3" json:"timestamp,omitempty"` // unix milli
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CommandResult) Reset() {
+	*x = CommandResult{}
+	mi := &file_server_message_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommandResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommandResult) ProtoMessage() {}
+
+func (x *CommandResult) ProtoReflect() protoreflect.Message {
+	mi := &file_server_message_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommandResult.ProtoReflect.Descriptor instead.
+func (*CommandResult) Descriptor() ([]byte, []int) {
+	return file_server_message_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CommandResult) GetCommandId() string {
+	if x != nil {
+		return x.CommandId
+	}
+	return ""
+}
+
+func (x *CommandResult) GetCommand() CommandType {
+	if x != nil {
+		return x.Command
+	}
+	return CommandType_ACK
+}
+
+func (x *CommandResult) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *CommandResult) GetTargetContainer() string {
+	if x != nil {
+		return x.TargetContainer
+	}
+	return ""
+}
+
+func (x *CommandResult) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *CommandResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *CommandResult) GetAgentid() int32 {
+	if x != nil {
+		return x.Agentid
+	}
+	return 0
+}
+
+func (x *CommandResult) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
 var File_server_message_proto protoreflect.FileDescriptor
 
 const file_server_message_proto_rawDesc = "" +
 	"\n" +
-	"\x14server_message.proto\x12\x02pb\"y\n" +
+	"\x14server_message.proto\x12\x02pb\"\x9b\x02\n" +
 	"\rServerMessage\x12)\n" +
 	"\acommand\x18\x01 \x01(\x0e2\x0f.pb.CommandTypeR\acommand\x12)\n" +
 	"\x10target_container\x18\x02 \x01(\tR\x0ftargetContainer\x12\x12\n" +
-	"\x04host\x18\x03 \x01(\tR\x04host*V\n" +
+	"\x04host\x18\x03 \x01(\tR\x04host\x12\x17\n" +
+	"\aack_seq\x18\x04 \x01(\x04R\x06ackSeq\x12\x1d\n" +
+	"\n" +
+	"command_id\x18\x05 \x01(\tR\tcommandId\x12/\n" +
+	"\x04args\x18\x06 \x03(\v2\x1b.pb.ServerMessage.ArgsEntryR\x04args\x1a7\n" +
+	"\tArgsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x80\x02\n" +
+	"\rCommandResult\x12\x1d\n" +
+	"\n" +
+	"command_id\x18\x01 \x01(\tR\tcommandId\x12)\n" +
+	"\acommand\x18\x02 \x01(\x0e2\x0f.pb.CommandTypeR\acommand\x12\x12\n" +
+	"\x04host\x18\x03 \x01(\tR\x04host\x12)\n" +
+	"\x10target_container\x18\x04 \x01(\tR\x0ftargetContainer\x12\x18\n" +
+	"\asuccess\x18\x05 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x06 \x01(\tR\x05error\x12\x18\n" +
+	"\aagentid\x18\a \x01(\x05R\aagentid\x12\x1c\n" +
+	"\ttimestamp\x18\b \x01(\x03R\ttimestamp*\xb9\x01\n" +
 	"\vCommandType\x12\a\n" +
 	"\x03ACK\x10\x00\x12\x13\n" +
 	"\x0fSTART_CONTAINER\x10\x01\x12\x12\n" +
 	"\x0eSTOP_CONTAINER\x10\x02\x12\x15\n" +
-	"\x11RESTART_CONTAINER\x10\x03B\x13Z\x11docker_service/pbb\x06proto3"
+	"\x11RESTART_CONTAINER\x10\x03\x12\x13\n" +
+	"\x0fPAUSE_CONTAINER\x10\x04\x12\x15\n" +
+	"\x11UNPAUSE_CONTAINER\x10\x05\x12\x14\n" +
+	"\x10REMOVE_CONTAINER\x10\x06\x12\x0e\n" +
+	"\n" +
+	"PULL_IMAGE\x10\a\x12\x0f\n" +
+	"\vCOLLECT_NOW\x10\bB\x13Z\x11docker_service/pbb\x06proto3"
 
 var (
 	file_server_message_proto_rawDescOnce sync.Once
@@ -161,18 +324,22 @@ func file_server_message_proto_rawDescGZIP() []byte {
 }
 
 var file_server_message_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_server_message_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_server_message_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
 var file_server_message_proto_goTypes = []any{
 	(CommandType)(0),      // 0: pb.CommandType
 	(*ServerMessage)(nil), // 1: pb.ServerMessage
+	(*CommandResult)(nil), // 2: pb.CommandResult
+	nil,                   // 3: pb.ServerMessage.ArgsEntry
 }
 var file_server_message_proto_depIdxs = []int32{
 	0, // 0: pb.ServerMessage.command:type_name -> pb.CommandType
-	1, // [1:1] is the sub-list for method output_type
-	1, // [1:1] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	3, // 1: pb.ServerMessage.args:type_name -> pb.ServerMessage.ArgsEntry
+	0, // 2: pb.CommandResult.command:type_name -> pb.CommandType
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
 }
 
 func init() { file_server_message_proto_init() }
@@ -186,7 +353,7 @@ func file_server_message_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_server_message_proto_rawDesc), len(file_server_message_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   1,
+			NumMessages:   3,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,74 @@
+package app
+
+import (
+	"sync"
+
+	"saas-service/internal/container"
+	"saas-service/internal/ingest"
+	"saas-service/internal/logger"
+	"saas-service/internal/server/api"
+	"saas-service/internal/server/gapi"
+)
+
+type Application struct {
+	wg         *sync.WaitGroup
+	Writer     *ingest.Writer
+	GApiServer *gapi.Server
+	ApiServer  *api.Server
+}
+
+func NewApplication(ct *container.Container, ch_terminate chan bool) *Application {
+	var wg *sync.WaitGroup = &sync.WaitGroup{}
+
+	writer := ingest.NewWriter(wg, ct.DbHnd, ct.Config.BatchSize, ct.Config.BatchFlush)
+
+	gapisvr, err := gapi.NewServer(wg, ct, writer)
+	if err != nil {
+		logger.Log.Error("gRPC server initialization fail.. %v", err)
+		return nil
+	}
+
+	var apisvr *api.Server
+	if ct.Config.HTTPServerAddress != "" {
+		apisvr, err = api.NewServer(wg, ct, gapisvr, writer)
+		if err != nil {
+			logger.Log.Error("api server initialization fail.. %v", err)
+			return nil
+		}
+	}
+
+	return &Application{
+		wg:         wg,
+		Writer:     writer,
+		GApiServer: gapisvr,
+		ApiServer:  apisvr,
+	}
+}
+
+func (app Application) Start() {
+	app.wg.Add(1)
+	go app.Writer.Start()
+
+	app.wg.Add(1)
+	logger.Log.Print(3, "Start gRPC server.. #1")
+	go app.GApiServer.StartgPRC()
+
+	if app.ApiServer != nil {
+		app.wg.Add(1)
+		logger.Log.Print(3, "Start api server.. #1")
+		go app.ApiServer.Start()
+	}
+}
+
+func (app Application) Shutdown() {
+	if app.ApiServer != nil {
+		app.ApiServer.Shutdown()
+	}
+	logger.Log.Print(3, "Shutdown gRPC server#1")
+	app.GApiServer.ShutdowngRPC()
+	logger.Log.Print(3, "Shutdown gRPC server#2")
+
+	// gRPC 종료 후 남은 stats/event 저장
+	app.Writer.Shutdown()
+	app.wg.Wait()
+}
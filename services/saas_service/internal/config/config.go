@@ -0,0 +1,54 @@
+package config
+
+import (
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+type Config struct {
+	Environment       string `mapstructure:"ENVIRONMENT"`
+	DBDriver          string `mapstructure:"DB_DRIVER"`
+	DBAddress         string `mapstructure:"DB_ADDRESS"`
+	DBPort            int    `mapstructure:"DB_PORT"`
+	DBUser            string `mapstructure:"DB_USER"`
+	DBPasswd          string `mapstructure:"DB_PASSWD"`
+	DBSName           string `mapstructure:"DB_NAME"`
+	GRPCServerAddress string `mapstructure:"GRPC_SERVER_ADDRESS"`
+	HTTPServerAddress string `mapstructure:"HTTP_SERVER_ADDRESS"`
+	AdminToken        string `mapstructure:"ADMIN_TOKEN"` // 관리 API Bearer token ("" : 인증 없음)
+
+	TokenSecretKey      string        `mapstructure:"TOKEN_SYMMETRIC_KEY"`
+	AccessTokenDuration time.Duration `mapstructure:"ACCESS_TOKEN_DURATION"`
+
+	EnrollSecret   string        `mapstructure:"ENROLL_SECRET"`    // bootstrap token 서명 키 (32자 이상)
+	EnrollTokenTTL time.Duration `mapstructure:"ENROLL_TOKEN_TTL"` // bootstrap token 유효 시간 (기본 1h)
+	AgentKeyCache  time.Duration `mapstructure:"AGENT_KEY_CACHE"`  // agent key 검증 결과 캐시 시간 (기본 30s)
+
+	BatchSize            int           `mapstructure:"BATCH_SIZE"`             // stats/event 한번에 insert 할 최대 행 수 (기본 500)
+	BatchFlush           time.Duration `mapstructure:"BATCH_FLUSH"`            // stats/event 버퍼 flush 주기 (기본 1s)
+	HeartbeatIntervalSec int           `mapstructure:"HEARTBEAT_INTERVAL_SEC"` // Register 응답으로 전달하는 heartbeat 주기 (0 : agent 설정 사용)
+	CollectorSettings    string        `mapstructure:"COLLECTOR_SETTINGS"`     // Register 응답으로 전달하는 수집 설정 (PIPELINE_COLLECTORS 와 같은 JSON, "" : agent 설정 유지)
+
+	MemDbSeedAgents int `mapstructure:"MEMDB_SEED_AGENTS"` // DB_DRIVER=memory 일 때 미리 만들 loadtest agent 수
+
+	DebugLv int `mapstructure:"DEBUG_LV"`
+}
+
+func LoadConfig(path string) (Config, error) {
+	var config Config
+	var err error = nil
+	viper.AddConfigPath(path)
+	viper.SetConfigName("app")
+	viper.SetConfigType("env")
+
+	viper.AutomaticEnv()
+
+	err = viper.ReadInConfig()
+	if err != nil {
+		return config, err
+	}
+
+	err = viper.Unmarshal(&config)
+	return config, nil
+}
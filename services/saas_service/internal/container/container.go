@@ -0,0 +1,72 @@
+package container
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"saas-service/internal/config"
+	"saas-service/internal/db"
+	"saas-service/internal/db/mdb"
+	"saas-service/internal/db/memdb"
+	"saas-service/internal/logger"
+)
+
+type Container struct {
+	Config *config.Config
+	DbHnd  db.DbHandler
+}
+
+var container *Container
+
+func NewContainer() (*Container, error) {
+	container = &Container{}
+	// load config
+	config, err := initConfig()
+	if err != nil {
+		return nil, fmt.Errorf("config loading error..%v \n", err)
+	}
+	container.Config = &config
+
+	// init database
+	dbhnd := initDatabase(config)
+	container.DbHnd = dbhnd
+
+	return container, nil
+}
+
+func initConfig() (config.Config, error) {
+	return config.LoadConfig(".")
+}
+
+func initDatabase(config config.Config) db.DbHandler {
+	if config.DBDriver == "memory" {
+		logger.Log.Print(3, "Db driver memory (agents seed:%d)", config.MemDbSeedAgents)
+		mem := memdb.NewMemDbHandler()
+		mem.SeedLoadtestAgents(config.MemDbSeedAgents)
+		return mem
+	}
+
+	mdb := mdb.NewMdbHandler(config.DBUser, config.DBPasswd, config.DBSName, config.DBAddress, config.DBPort)
+
+	for i := 0; i < 10; i++ {
+		err := mdb.Init()
+		if err != nil {
+			logger.Log.Error("Db Init err.. (%d)%v", i, err)
+		} else {
+			logger.Log.Print(3, "Db init OK!")
+			break
+		}
+		time.Sleep(2 * time.Second)
+	}
+
+	// 이전 방식(평문)으로 저장된 agent key 해시 변환 (인증은 해시 값만 비교)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if n, err := mdb.HashLegacyAgentKeys(ctx); err != nil {
+		logger.Log.Error("Db hash legacy agent keys err.. %v", err)
+	} else if n > 0 {
+		logger.Log.Print(3, "Db hashed %d legacy agent keys", n)
+	}
+	return mdb
+}
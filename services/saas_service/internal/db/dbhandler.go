@@ -0,0 +1,38 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+type DbHandler interface {
+	Init() error
+	Close(*sql.DB)
+	ReadSysdate(ctx context.Context) (string, error)
+
+	// user
+	ReadUser(ctx context.Context, username string) (User, error)
+
+	// agent
+	ReadAgent(ctx context.Context, id int) (Agent, error)
+	ReadAgentByKey(ctx context.Context, hashedKey string) (Agent, error)
+	CreateAgent(ctx context.Context, arg CreateAgentParams) (Agent, error)
+	UpdateAgentKey(ctx context.Context, id int, hashedKey string) error
+	HashLegacyAgentKeys(ctx context.Context) (int, error)
+	UpdateAgentInfo(ctx context.Context, id int, name, address string) error
+
+	// agent host
+	ReadAgentHosts(ctx context.Context, agentId int) ([]AgentHost, error)
+	UpsertAgentHost(ctx context.Context, agentId int, hostname, address string) (int, error)
+
+	// inventory
+	SyncContainerInfo(ctx context.Context, agentId, hostId int, rows []ContainerInfo, full bool) error
+	UpsertContainerInspect(ctx context.Context, rows []ContainerInspect) error
+
+	// 이력 (batch insert)
+	InsertContainerStats(ctx context.Context, rows []ContainerStats) error
+	InsertContainerEvents(ctx context.Context, rows []ContainerEvent) error
+
+	// 묶음 저장 (한 트랜잭션)
+	WriteIngestBatch(ctx context.Context, b IngestBatch) error
+}
@@ -0,0 +1,153 @@
+package mdb
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"saas-service/internal/db"
+)
+
+// CreateAgent agent 등록 (id : MAX(id)+1)
+func (q *MariaDbHandler) CreateAgent(ctx context.Context, arg db.CreateAgentParams) (db.Agent, error) {
+	ado := q.GetDB()
+
+	tx, err := ado.BeginTx(ctx, nil)
+	if err != nil {
+		return db.Agent{}, err
+	}
+	defer tx.Rollback()
+
+	var id int
+	query := `SELECT IFNULL(MAX(id), 0) + 1 FROM agent FOR UPDATE`
+	if err := tx.QueryRowContext(ctx, query).Scan(&id); err != nil {
+		return db.Agent{}, err
+	}
+
+	query = `
+	INSERT INTO agent (id, agent_name, agent_key, agent_address, updated_at)
+	VALUES (?, ?, ?, ?, now())
+	`
+	if _, err := tx.ExecContext(ctx, query, id, arg.AgentName, arg.AgentKey, arg.AgentAddress); err != nil {
+		return db.Agent{}, err
+	}
+	if err := tx.Commit(); err != nil {
+		return db.Agent{}, err
+	}
+
+	return db.Agent{
+		Id:           id,
+		AgentName:    arg.AgentName,
+		AgentKey:     arg.AgentKey,
+		AgentAddress: arg.AgentAddress,
+	}, nil
+}
+
+// InsertContainerStats 리소스 이력 batch insert, 최신 값은 container_stats 에 upsert
+// 같은 수집 시각의 중복(재전송)은 무시한다.
+func (q *MariaDbHandler) InsertContainerStats(ctx context.Context, rows []db.ContainerStats) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	ado := q.GetDB()
+
+	tx, err := ado.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := insertContainerStats(ctx, tx, rows); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func insertContainerStats(ctx context.Context, tx *sql.Tx, rows []db.ContainerStats) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	values := strings.TrimSuffix(strings.Repeat("(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?), ", len(rows)), ", ")
+	args := make([]any, 0, len(rows)*11)
+	for _, r := range rows {
+		args = append(args, r.Id, r.HostId, r.ContainerId, r.CollectedAt, r.ContainerName,
+			r.CpuPercent, r.MemoryUsage, r.MemoryLimit, r.MemoryPercent, r.NetworkRx, r.NetworkTx)
+	}
+
+	query := `
+	INSERT IGNORE INTO container_stats_log (id, host_id, container_id, collected_at, container_name,
+		cpu_percent, memory_usage, memory_limit, memory_percent, network_rx, network_tx)
+	VALUES ` + values
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return err
+	}
+
+	query = `
+	INSERT INTO container_stats (id, host_id, container_id, collected_at, container_name,
+		cpu_percent, memory_usage, memory_limit, memory_percent, network_rx, network_tx)
+	VALUES ` + values + `
+	ON DUPLICATE KEY UPDATE
+		container_name = IF(VALUES(collected_at) >= collected_at, VALUES(container_name), container_name),
+		cpu_percent = IF(VALUES(collected_at) >= collected_at, VALUES(cpu_percent), cpu_percent),
+		memory_usage = IF(VALUES(collected_at) >= collected_at, VALUES(memory_usage), memory_usage),
+		memory_limit = IF(VALUES(collected_at) >= collected_at, VALUES(memory_limit), memory_limit),
+		memory_percent = IF(VALUES(collected_at) >= collected_at, VALUES(memory_percent), memory_percent),
+		network_rx = IF(VALUES(collected_at) >= collected_at, VALUES(network_rx), network_rx),
+		network_tx = IF(VALUES(collected_at) >= collected_at, VALUES(network_tx), network_tx),
+		collected_at = GREATEST(collected_at, VALUES(collected_at))`
+	_, err := tx.ExecContext(ctx, query, args...)
+	return err
+}
+
+// InsertContainerEvents 이벤트 이력 batch insert
+func (q *MariaDbHandler) InsertContainerEvents(ctx context.Context, rows []db.ContainerEvent) error {
+	return insertContainerEvents(ctx, q.GetDB(), rows)
+}
+
+func insertContainerEvents(ctx context.Context, ado execer, rows []db.ContainerEvent) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	values := strings.TrimSuffix(strings.Repeat("(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?), ", len(rows)), ", ")
+	args := make([]any, 0, len(rows)*12)
+	for _, r := range rows {
+		args = append(args, r.Id, r.HostId, r.ContainerId, r.ReceivedAt, r.Seq, r.Hostname,
+			r.Type, r.Action, r.ActorId, r.ActorName, r.EventTimestamp, r.Attrs)
+	}
+
+	query := `
+	INSERT IGNORE INTO container_event_log (id, host_id, container_id, received_at, seq, hostname,
+		type, action, actor_id, actor_name, event_timestamp, attrs)
+	VALUES ` + values
+	_, err := ado.ExecContext(ctx, query, args...)
+	return err
+}
+
+// WriteIngestBatch 수집 데이터 묶음을 한 트랜잭션으로 저장 (일부만 저장되는 경우 없음)
+func (q *MariaDbHandler) WriteIngestBatch(ctx context.Context, b db.IngestBatch) error {
+	ado := q.GetDB()
+
+	tx, err := ado.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, l := range b.Lists {
+		if err := syncContainerInfo(ctx, tx, l.Id, l.HostId, l.Rows, l.Full); err != nil {
+			return err
+		}
+	}
+	if err := upsertContainerInspect(ctx, tx, b.Inspects); err != nil {
+		return err
+	}
+	if err := insertContainerStats(ctx, tx, b.Stats); err != nil {
+		return err
+	}
+	if err := insertContainerEvents(ctx, tx, b.Events); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,151 @@
+package mdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"saas-service/internal/db"
+	"saas-service/internal/logger"
+)
+
+func (q *MariaDbHandler) ReadSysdate(ctx context.Context) (string, error) {
+	ado := q.GetDB()
+
+	query := `
+	select now() as dt from dual
+	`
+
+	strDateTime := ""
+	if err := ado.QueryRowContext(ctx, query).Scan(&strDateTime); err != nil {
+		return "", err
+	}
+	return strDateTime, nil
+}
+
+func (q *MariaDbHandler) ReadAgent(ctx context.Context, id int) (db.Agent, error) {
+	ado := q.GetDB()
+
+	query := `
+	SELECT id
+		 , IFNULL(agent_name, '')
+		 , IFNULL(agent_key, '')
+		 , IFNULL(agent_address, '')
+		 , updated_at
+	FROM agent
+	WHERE id = ?
+	`
+
+	return scanAgent(ado.QueryRowContext(ctx, query, id))
+}
+
+// ReadAgentByKey 해시된 agent key 로 agent 조회 (저장 값 자체로는 인증되지 않도록 해시 값만 비교)
+func (q *MariaDbHandler) ReadAgentByKey(ctx context.Context, hashedKey string) (db.Agent, error) {
+	ado := q.GetDB()
+
+	query := `
+	SELECT id
+		 , IFNULL(agent_name, '')
+		 , IFNULL(agent_key, '')
+		 , IFNULL(agent_address, '')
+		 , updated_at
+	FROM agent
+	WHERE agent_key = ?
+	LIMIT 1
+	`
+
+	return scanAgent(ado.QueryRowContext(ctx, query, hashedKey))
+}
+
+func scanAgent(row *sql.Row) (db.Agent, error) {
+	var a db.Agent
+	err := row.Scan(
+		&a.Id,
+		&a.AgentName,
+		&a.AgentKey,
+		&a.AgentAddress,
+		&a.UpdatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return db.Agent{}, db.ErrNotFound
+	}
+	if err != nil {
+		logger.Log.Error("scanAgent err : %v", err)
+		return db.Agent{}, err
+	}
+	return a, nil
+}
+
+func (q *MariaDbHandler) ReadUser(ctx context.Context, username string) (db.User, error) {
+	ado := q.GetDB()
+	var u db.User
+
+	query := `
+	SELECT username
+		 , IFNULL(hashed_password, '')
+		 , IFNULL(full_name, '')
+		 , IFNULL(email, '')
+		 , agent_id
+		 , created_at
+	FROM users
+	WHERE username = ?
+	`
+
+	err := ado.QueryRowContext(ctx, query, username).Scan(
+		&u.Username,
+		&u.HashedPassword,
+		&u.FullName,
+		&u.Email,
+		&u.AgentId,
+		&u.CreatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return db.User{}, db.ErrNotFound
+	}
+	if err != nil {
+		logger.Log.Error("ReadUser err : %v", err)
+		return db.User{}, err
+	}
+	return u, nil
+}
+
+func (q *MariaDbHandler) ReadAgentHosts(ctx context.Context, agentId int) ([]db.AgentHost, error) {
+	ado := q.GetDB()
+
+	query := `
+	SELECT id
+		 , host_id
+		 , IFNULL(hostname, '')
+		 , IFNULL(host_address, '')
+	FROM agent_host
+	WHERE id = ?
+	ORDER BY host_id
+	`
+
+	rows, err := ado.QueryContext(ctx, query, agentId)
+	if err != nil {
+		logger.Log.Error("ReadAgentHosts #1 err : %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var hosts []db.AgentHost
+	for rows.Next() {
+		var h db.AgentHost
+		if err := rows.Scan(
+			&h.Id,
+			&h.HostId,
+			&h.Hostname,
+			&h.HostAddress,
+		); err != nil {
+			logger.Log.Error("ReadAgentHosts #2 err : %v", err)
+			return nil, err
+		}
+		hosts = append(hosts, h)
+	}
+	if err := rows.Err(); err != nil {
+		logger.Log.Error("ReadAgentHosts #3 err : %v", err)
+		return nil, err
+	}
+	return hosts, nil
+}
@@ -0,0 +1,211 @@
+package mdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"saas-service/internal/db"
+)
+
+// UpdateAgentKey agent key 교체 (이전 key 는 즉시 무효)
+func (q *MariaDbHandler) UpdateAgentKey(ctx context.Context, id int, hashedKey string) error {
+	ado := q.GetDB()
+
+	query := `UPDATE agent SET agent_key = ?, updated_at = now() WHERE id = ?`
+
+	res, err := ado.ExecContext(ctx, query, hashedKey, id)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return db.ErrNotFound
+	}
+	return nil
+}
+
+// HashLegacyAgentKeys 평문으로 저장된 이전 agent key 를 해시 값으로 변환 (시작 시 1회), 변환 건수 반환
+// util.HashAgentKey 와 같은 형식 ("sha256:" + hex)
+func (q *MariaDbHandler) HashLegacyAgentKeys(ctx context.Context) (int, error) {
+	ado := q.GetDB()
+
+	query := `
+	UPDATE agent
+	   SET agent_key = CONCAT('sha256:', SHA2(agent_key, 256))
+	     , updated_at = now()
+	WHERE agent_key IS NOT NULL
+	  AND agent_key <> ''
+	  AND agent_key NOT LIKE 'sha256:%'
+	`
+
+	res, err := ado.ExecContext(ctx, query)
+	if err != nil {
+		return 0, err
+	}
+	n, err := res.RowsAffected()
+	return int(n), err
+}
+
+// UpdateAgentInfo Register 시 agent 이름/주소 갱신 ("" 는 유지)
+func (q *MariaDbHandler) UpdateAgentInfo(ctx context.Context, id int, name, address string) error {
+	ado := q.GetDB()
+
+	query := `
+	UPDATE agent
+	   SET agent_name = IF(? = '', agent_name, ?)
+	     , agent_address = IF(? = '', agent_address, ?)
+	     , updated_at = now()
+	WHERE id = ?
+	`
+
+	_, err := ado.ExecContext(ctx, query, name, name, address, address, id)
+	return err
+}
+
+// UpsertAgentHost hostname 으로 agent_host 조회, 없으면 등록 (host_id : agent 내 MAX+1)
+func (q *MariaDbHandler) UpsertAgentHost(ctx context.Context, agentId int, hostname, address string) (int, error) {
+	ado := q.GetDB()
+
+	tx, err := ado.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	var hostId int
+	query := `SELECT host_id FROM agent_host WHERE id = ? AND hostname = ? FOR UPDATE`
+	err = tx.QueryRowContext(ctx, query, agentId, hostname).Scan(&hostId)
+	switch {
+	case err == nil:
+		if address != "" {
+			query = `UPDATE agent_host SET host_address = ?, updated_at = now() WHERE id = ? AND host_id = ?`
+			if _, err := tx.ExecContext(ctx, query, address, agentId, hostId); err != nil {
+				return 0, err
+			}
+		}
+	case errors.Is(err, sql.ErrNoRows):
+		query = `SELECT IFNULL(MAX(host_id), 0) + 1 FROM agent_host WHERE id = ? FOR UPDATE`
+		if err := tx.QueryRowContext(ctx, query, agentId).Scan(&hostId); err != nil {
+			return 0, err
+		}
+		query = `
+		INSERT INTO agent_host (id, host_id, hostname, host_address, updated_at)
+		VALUES (?, ?, ?, ?, now())
+		`
+		if _, err := tx.ExecContext(ctx, query, agentId, hostId, hostname, address); err != nil {
+			return 0, err
+		}
+	default:
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return hostId, nil
+}
+
+// SyncContainerInfo 컨테이너 목록 upsert
+// full : 호스트 전체 목록이면 목록에 없는 컨테이너 삭제 (scope 수집은 일부만 포함)
+func (q *MariaDbHandler) SyncContainerInfo(ctx context.Context, agentId, hostId int, rows []db.ContainerInfo, full bool) error {
+	ado := q.GetDB()
+
+	tx, err := ado.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := syncContainerInfo(ctx, tx, agentId, hostId, rows, full); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func syncContainerInfo(ctx context.Context, tx *sql.Tx, agentId, hostId int, rows []db.ContainerInfo, full bool) error {
+	if len(rows) > 0 {
+		var sb strings.Builder
+		args := make([]any, 0, len(rows)*7)
+		sb.WriteString(`
+		INSERT INTO container_info (id, host_id, container_id, container_name, image, state, status, changed_at)
+		VALUES `)
+		for i, r := range rows {
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+			sb.WriteString("(?, ?, ?, ?, ?, ?, ?, now())")
+			args = append(args, agentId, hostId, r.ContainerId, r.ContainerName, r.Image, r.State, r.Status)
+		}
+		// 상태가 바뀐 경우에만 changed_at 갱신
+		sb.WriteString(`
+		ON DUPLICATE KEY UPDATE
+			changed_at = IF(state <=> VALUES(state) AND status <=> VALUES(status) AND image <=> VALUES(image), changed_at, now()),
+			container_name = VALUES(container_name),
+			image = VALUES(image),
+			state = VALUES(state),
+			status = VALUES(status)`)
+		if _, err := tx.ExecContext(ctx, sb.String(), args...); err != nil {
+			return err
+		}
+	}
+
+	if full {
+		query := `DELETE FROM container_info WHERE id = ? AND host_id = ?`
+		args := []any{agentId, hostId}
+		if len(rows) > 0 {
+			query += ` AND container_id NOT IN (?` + strings.Repeat(", ?", len(rows)-1) + `)`
+			for _, r := range rows {
+				args = append(args, r.ContainerId)
+			}
+		}
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UpsertContainerInspect 컨테이너 상세 upsert
+func (q *MariaDbHandler) UpsertContainerInspect(ctx context.Context, rows []db.ContainerInspect) error {
+	return upsertContainerInspect(ctx, q.GetDB(), rows)
+}
+
+func upsertContainerInspect(ctx context.Context, ado execer, rows []db.ContainerInspect) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	var sb strings.Builder
+	args := make([]any, 0, len(rows)*11)
+	sb.WriteString(`
+	INSERT INTO container_inspect (id, host_id, container_id, container_name, image, platform, restart_count,
+		state_info, config_info, network_info, mount_info, changed_at)
+	VALUES `)
+	for i, r := range rows {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString("(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, now())")
+		args = append(args, r.Id, r.HostId, r.ContainerId, r.ContainerName, r.Image, r.Platform, r.RestartCount,
+			r.StateInfo, r.ConfigInfo, r.NetworkInfo, r.MountInfo)
+	}
+	sb.WriteString(`
+	ON DUPLICATE KEY UPDATE
+		container_name = VALUES(container_name),
+		image = VALUES(image),
+		platform = VALUES(platform),
+		restart_count = VALUES(restart_count),
+		state_info = VALUES(state_info),
+		config_info = VALUES(config_info),
+		network_info = VALUES(network_info),
+		mount_info = VALUES(mount_info),
+		changed_at = now()`)
+
+	_, err := ado.ExecContext(ctx, sb.String(), args...)
+	return err
+}
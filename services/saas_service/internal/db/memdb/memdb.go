@@ -0,0 +1,314 @@
+package memdb
+
+// 메모리 DbHandler (DB_DRIVER=memory)
+// MySQL 없이 로컬 실행/부하 테스트용, 프로세스 종료 시 데이터는 사라진다.
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"saas-service/internal/db"
+	"saas-service/internal/util"
+)
+
+type hostKey struct {
+	agentId int
+	hostId  int
+}
+
+type containerKey struct {
+	hostKey
+	containerId string
+}
+
+// MemDbHandler 메모리 DbHandler
+type MemDbHandler struct {
+	mu       sync.RWMutex
+	users    map[string]db.User
+	agents   map[int]db.Agent
+	hosts    map[hostKey]db.AgentHost
+	infos    map[containerKey]db.ContainerInfo
+	inspects map[containerKey]db.ContainerInspect
+	stats    map[containerKey]db.ContainerStats // 최신 값 (container_stats)
+
+	statsLogCount int                 // container_stats_log 행 수
+	eventLogCount int                 // container_event_log 행 수
+	eventLog      []db.ContainerEvent // 최근 maxEventLog 개만 보관
+	maxEventLog   int
+}
+
+func NewMemDbHandler() *MemDbHandler {
+	return &MemDbHandler{
+		users:       make(map[string]db.User),
+		agents:      make(map[int]db.Agent),
+		hosts:       make(map[hostKey]db.AgentHost),
+		infos:       make(map[containerKey]db.ContainerInfo),
+		inspects:    make(map[containerKey]db.ContainerInspect),
+		stats:       make(map[containerKey]db.ContainerStats),
+		maxEventLog: 10000,
+	}
+}
+
+func (m *MemDbHandler) Init() error   { return nil }
+func (m *MemDbHandler) Close(*sql.DB) {}
+
+func (m *MemDbHandler) ReadSysdate(ctx context.Context) (string, error) {
+	return time.Now().Format("2006-01-02 15:04:05"), nil
+}
+
+// SeedLoadtestAgents loadtest 용 agent 등록 (ID 2..n+1, key "loadtest-%04d" : db/script/seed_loadtest_agents.sql 과 동일)
+func (m *MemDbHandler) SeedLoadtestAgents(n int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i := 0; i < n; i++ {
+		id := i + 2
+		m.agents[id] = db.Agent{
+			Id:        id,
+			AgentName: fmt.Sprintf("loadtest-%04d", id),
+			AgentKey:  util.HashAgentKey(fmt.Sprintf("loadtest-%04d", id)),
+			UpdatedAt: sql.NullTime{Time: time.Now(), Valid: true},
+		}
+	}
+}
+
+// CreateUser 로컬 실행용 사용자 등록
+func (m *MemDbHandler) CreateUser(u db.User) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.users[u.Username] = u
+}
+
+func (m *MemDbHandler) ReadUser(ctx context.Context, username string) (db.User, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	u, ok := m.users[username]
+	if !ok {
+		return db.User{}, db.ErrNotFound
+	}
+	return u, nil
+}
+
+func (m *MemDbHandler) ReadAgent(ctx context.Context, id int) (db.Agent, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	a, ok := m.agents[id]
+	if !ok {
+		return db.Agent{}, db.ErrNotFound
+	}
+	return a, nil
+}
+
+func (m *MemDbHandler) ReadAgentByKey(ctx context.Context, hashedKey string) (db.Agent, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, a := range m.agents {
+		if a.AgentKey != "" && a.AgentKey == hashedKey {
+			return a, nil
+		}
+	}
+	return db.Agent{}, db.ErrNotFound
+}
+
+func (m *MemDbHandler) CreateAgent(ctx context.Context, arg db.CreateAgentParams) (db.Agent, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	id := 1
+	for aid := range m.agents {
+		if aid >= id {
+			id = aid + 1
+		}
+	}
+	a := db.Agent{
+		Id:           id,
+		AgentName:    arg.AgentName,
+		AgentKey:     arg.AgentKey,
+		AgentAddress: arg.AgentAddress,
+		UpdatedAt:    sql.NullTime{Time: time.Now(), Valid: true},
+	}
+	m.agents[id] = a
+	return a, nil
+}
+
+func (m *MemDbHandler) UpdateAgentKey(ctx context.Context, id int, hashedKey string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	a, ok := m.agents[id]
+	if !ok {
+		return db.ErrNotFound
+	}
+	a.AgentKey = hashedKey
+	a.UpdatedAt = sql.NullTime{Time: time.Now(), Valid: true}
+	m.agents[id] = a
+	return nil
+}
+
+func (m *MemDbHandler) HashLegacyAgentKeys(ctx context.Context) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	n := 0
+	for id, a := range m.agents {
+		if a.AgentKey != "" && !strings.HasPrefix(a.AgentKey, "sha256:") {
+			a.AgentKey = util.HashAgentKey(a.AgentKey)
+			m.agents[id] = a
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (m *MemDbHandler) UpdateAgentInfo(ctx context.Context, id int, name, address string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	a, ok := m.agents[id]
+	if !ok {
+		return nil
+	}
+	if name != "" {
+		a.AgentName = name
+	}
+	if address != "" {
+		a.AgentAddress = address
+	}
+	a.UpdatedAt = sql.NullTime{Time: time.Now(), Valid: true}
+	m.agents[id] = a
+	return nil
+}
+
+func (m *MemDbHandler) ReadAgentHosts(ctx context.Context, agentId int) ([]db.AgentHost, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var hosts []db.AgentHost
+	for k, h := range m.hosts {
+		if k.agentId == agentId {
+			hosts = append(hosts, h)
+		}
+	}
+	sort.Slice(hosts, func(i, j int) bool { return hosts[i].HostId < hosts[j].HostId })
+	return hosts, nil
+}
+
+func (m *MemDbHandler) UpsertAgentHost(ctx context.Context, agentId int, hostname, address string) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	maxId := 0
+	for k, h := range m.hosts {
+		if k.agentId != agentId {
+			continue
+		}
+		if h.Hostname == hostname {
+			if address != "" {
+				h.HostAddress = address
+				m.hosts[k] = h
+			}
+			return h.HostId, nil
+		}
+		if k.hostId > maxId {
+			maxId = k.hostId
+		}
+	}
+	hostId := maxId + 1
+	m.hosts[hostKey{agentId, hostId}] = db.AgentHost{Id: agentId, HostId: hostId, Hostname: hostname, HostAddress: address}
+	return hostId, nil
+}
+
+func (m *MemDbHandler) SyncContainerInfo(ctx context.Context, agentId, hostId int, rows []db.ContainerInfo, full bool) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.syncContainerInfo(agentId, hostId, rows, full)
+	return nil
+}
+
+func (m *MemDbHandler) syncContainerInfo(agentId, hostId int, rows []db.ContainerInfo, full bool) {
+	hk := hostKey{agentId, hostId}
+	present := make(map[string]bool, len(rows))
+	for _, r := range rows {
+		r.Id, r.HostId = agentId, hostId
+		m.infos[containerKey{hk, r.ContainerId}] = r
+		present[r.ContainerId] = true
+	}
+	if full {
+		for k := range m.infos {
+			if k.hostKey == hk && !present[k.containerId] {
+				delete(m.infos, k)
+			}
+		}
+	}
+}
+
+func (m *MemDbHandler) UpsertContainerInspect(ctx context.Context, rows []db.ContainerInspect) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.upsertContainerInspect(rows)
+	return nil
+}
+
+func (m *MemDbHandler) upsertContainerInspect(rows []db.ContainerInspect) {
+	for _, r := range rows {
+		m.inspects[containerKey{hostKey{r.Id, r.HostId}, r.ContainerId}] = r
+	}
+}
+
+func (m *MemDbHandler) InsertContainerStats(ctx context.Context, rows []db.ContainerStats) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.insertContainerStats(rows)
+	return nil
+}
+
+func (m *MemDbHandler) insertContainerStats(rows []db.ContainerStats) {
+	for _, r := range rows {
+		k := containerKey{hostKey{r.Id, r.HostId}, r.ContainerId}
+		if cur, ok := m.stats[k]; !ok || !r.CollectedAt.Before(cur.CollectedAt) {
+			m.stats[k] = r
+		}
+	}
+	m.statsLogCount += len(rows)
+}
+
+func (m *MemDbHandler) InsertContainerEvents(ctx context.Context, rows []db.ContainerEvent) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.insertContainerEvents(rows)
+	return nil
+}
+
+func (m *MemDbHandler) insertContainerEvents(rows []db.ContainerEvent) {
+	m.eventLog = append(m.eventLog, rows...)
+	m.eventLogCount += len(rows)
+	if over := len(m.eventLog) - m.maxEventLog; over > 0 {
+		m.eventLog = append([]db.ContainerEvent(nil), m.eventLog[over:]...)
+	}
+}
+
+// WriteIngestBatch 묶음 저장 (한 번의 잠금 안에서 모두 반영)
+func (m *MemDbHandler) WriteIngestBatch(ctx context.Context, b db.IngestBatch) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, l := range b.Lists {
+		m.syncContainerInfo(l.Id, l.HostId, l.Rows, l.Full)
+	}
+	m.upsertContainerInspect(b.Inspects)
+	m.insertContainerStats(b.Stats)
+	m.insertContainerEvents(b.Events)
+	return nil
+}
+
+// Counts 저장된 행 수 (container_info, container_inspect, container_stats_log, container_event_log)
+func (m *MemDbHandler) Counts() map[string]int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return map[string]int{
+		"agent":               len(m.agents),
+		"agent_host":          len(m.hosts),
+		"container_info":      len(m.infos),
+		"container_inspect":   len(m.inspects),
+		"container_stats":     len(m.stats),
+		"container_stats_log": m.statsLogCount,
+		"container_event_log": m.eventLogCount,
+	}
+}
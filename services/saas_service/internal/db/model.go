@@ -0,0 +1,114 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+)
+
+// ErrNotFound 조회 결과 없음
+var ErrNotFound = errors.New("not found")
+
+type User struct {
+	Username       string        `json:"username"`
+	HashedPassword string        `json:"hashed_password"`
+	FullName       string        `json:"full_name"`
+	Email          string        `json:"email"`
+	AgentId        sql.NullInt32 `json:"agent_id"`
+	CreatedAt      sql.NullTime  `json:"created_at"`
+}
+
+type Agent struct {
+	Id           int          `json:"id"`
+	AgentName    string       `json:"agent_name"`
+	AgentKey     string       `json:"-"` // 해시 값 ("sha256:<hex>")
+	AgentAddress string       `json:"agent_address"`
+	UpdatedAt    sql.NullTime `json:"updated_at"`
+}
+
+type CreateAgentParams struct {
+	AgentName    string `json:"agent_name"`
+	AgentKey     string `json:"-"` // 해시 값
+	AgentAddress string `json:"agent_address"`
+}
+
+// AgentHost agent 가 관리하는 docker 호스트 (agent_host)
+type AgentHost struct {
+	Id          int    `json:"id"` // agent ID
+	HostId      int    `json:"host_id"`
+	Hostname    string `json:"hostname"`
+	HostAddress string `json:"host_address"`
+}
+
+// ContainerInfo 컨테이너 목록 (container_info)
+type ContainerInfo struct {
+	Id            int    `json:"id"`
+	HostId        int    `json:"host_id"`
+	ContainerId   string `json:"container_id"`
+	ContainerName string `json:"container_name"`
+	Image         string `json:"image"`
+	State         string `json:"state"`
+	Status        string `json:"status"`
+}
+
+// ContainerInspect 컨테이너 상세 (container_inspect), *Info 필드는 JSON
+type ContainerInspect struct {
+	Id            int    `json:"id"`
+	HostId        int    `json:"host_id"`
+	ContainerId   string `json:"container_id"`
+	ContainerName string `json:"container_name"`
+	Image         string `json:"image"`
+	Platform      string `json:"platform"`
+	RestartCount  int    `json:"restart_count"`
+	StateInfo     string `json:"state_info"`
+	ConfigInfo    string `json:"config_info"`
+	NetworkInfo   string `json:"network_info"`
+	MountInfo     string `json:"mount_info"`
+}
+
+// ContainerStats 리소스 사용량 (container_stats_log, container_stats)
+type ContainerStats struct {
+	Id            int       `json:"id"`
+	HostId        int       `json:"host_id"`
+	ContainerId   string    `json:"container_id"`
+	CollectedAt   time.Time `json:"collected_at"`
+	ContainerName string    `json:"container_name"`
+	CpuPercent    float64   `json:"cpu_percent"`
+	MemoryUsage   uint64    `json:"memory_usage"`
+	MemoryLimit   uint64    `json:"memory_limit"`
+	MemoryPercent float64   `json:"memory_percent"`
+	NetworkRx     uint64    `json:"network_rx"`
+	NetworkTx     uint64    `json:"network_tx"`
+}
+
+// ContainerEvent docker 이벤트 (container_event_log)
+type ContainerEvent struct {
+	Id             int       `json:"id"`
+	HostId         int       `json:"host_id"`
+	ContainerId    string    `json:"container_id"`
+	ReceivedAt     time.Time `json:"received_at"`
+	Seq            int       `json:"seq"`
+	Hostname       string    `json:"hostname"`
+	Type           string    `json:"type"`
+	Action         string    `json:"action"`
+	ActorId        string    `json:"actor_id"`
+	ActorName      string    `json:"actor_name"`
+	EventTimestamp int64     `json:"event_timestamp"`
+	Attrs          string    `json:"attrs"` // JSON
+}
+
+// ContainerList 호스트 하나의 컨테이너 목록 (Full : 호스트 전체 목록)
+type ContainerList struct {
+	Id     int
+	HostId int
+	Rows   []ContainerInfo
+	Full   bool
+}
+
+// IngestBatch 한 트랜잭션으로 저장할 수집 데이터
+type IngestBatch struct {
+	Lists    []ContainerList
+	Inspects []ContainerInspect
+	Stats    []ContainerStats
+	Events   []ContainerEvent
+}
@@ -0,0 +1,561 @@
+package ingest
+
+// 수집 데이터 저장
+// - unary/batch  : 요청의 메시지 전체를 한 트랜잭션으로 저장 (응답 = 저장 완료)
+// - DataStream   : list/inspect 는 수신 즉시 upsert, stats/event 는 버퍼에 모아 batch insert
+//                  (BATCH_SIZE 도달 또는 BATCH_FLUSH 주기), 저장 완료는 done 콜백으로 알림
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"saas-service/internal/db"
+	"saas-service/internal/logger"
+
+	"docker_service/pb"
+)
+
+const (
+	defaultBatchSize  = 500
+	defaultBatchFlush = time.Second
+	maxBuffered       = 50000 // 버퍼 최대 행 수 (DB 장애 시 초과분은 버림)
+	writeTimeout      = 10 * time.Second
+)
+
+var (
+	// ErrBufferFull 버퍼가 가득 차 메시지를 받을 수 없음 (agent 가 재전송해야 함)
+	ErrBufferFull = errors.New("ingest buffer full")
+	// ErrDropped 저장 실패가 이어져 버퍼에서 버려짐
+	ErrDropped = errors.New("ingest rows dropped")
+)
+
+// commit 메시지 하나의 버퍼 행 저장 추적 (모든 행 저장 또는 첫 실패 시 done 한 번 호출)
+type commit struct {
+	remaining atomic.Int64
+	once      sync.Once
+	done      func(error)
+}
+
+func newCommit(rows int, done func(error)) *commit {
+	c := &commit{done: done}
+	c.remaining.Store(int64(rows))
+	return c
+}
+
+func (c *commit) settle(err error) {
+	if err != nil {
+		c.once.Do(func() { c.done(err) })
+		return
+	}
+	if c.remaining.Add(-1) == 0 {
+		c.once.Do(func() { c.done(nil) })
+	}
+}
+
+func settleAll(commits []*commit, err error) {
+	for _, c := range commits {
+		c.settle(err)
+	}
+}
+
+// Stats 저장 통계
+type Stats struct {
+	Messages      uint64    `json:"messages"`
+	StatsRows     uint64    `json:"stats_rows"`
+	EventRows     uint64    `json:"event_rows"`
+	Flushes       uint64    `json:"flushes"`
+	WriteErrors   uint64    `json:"write_errors"`
+	Dropped       uint64    `json:"dropped"`
+	BufferedStats int       `json:"buffered_stats"`
+	BufferedEvent int       `json:"buffered_events"`
+	LastError     string    `json:"last_error,omitempty"`
+	LastErrorAt   time.Time `json:"last_error_at,omitempty"`
+}
+
+type hostCacheKey struct {
+	agentId  int
+	hostname string
+}
+
+// Writer 수집 데이터 저장기
+type Writer struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     *sync.WaitGroup
+	dbHnd  db.DbHandler
+
+	batchSize  int
+	batchFlush time.Duration
+	flushCh    chan struct{}
+
+	hostMu sync.Mutex
+	hosts  map[hostCacheKey]int // (agent, hostname) -> host_id
+
+	mu          sync.Mutex
+	statsBuf    []db.ContainerStats
+	statsCommit []*commit // statsBuf 와 같은 순서
+	eventBuf    []db.ContainerEvent
+	eventCommit []*commit // eventBuf 와 같은 순서
+	eventSeq    int
+	stats       Stats
+}
+
+// NewWriter Writer 생성 (batchSize/batchFlush <= 0 : 기본값)
+func NewWriter(wg *sync.WaitGroup, dbHnd db.DbHandler, batchSize int, batchFlush time.Duration) *Writer {
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+	if batchFlush <= 0 {
+		batchFlush = defaultBatchFlush
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Writer{
+		ctx:        ctx,
+		cancel:     cancel,
+		wg:         wg,
+		dbHnd:      dbHnd,
+		batchSize:  batchSize,
+		batchFlush: batchFlush,
+		flushCh:    make(chan struct{}, 1),
+		hosts:      make(map[hostCacheKey]int),
+	}
+}
+
+// Start flush 루프 (Shutdown 시 남은 버퍼 저장 후 반환)
+func (w *Writer) Start() {
+	defer w.wg.Done()
+	logger.Log.Print(2, "[ingest] writer start, batch=%d flush=%v", w.batchSize, w.batchFlush)
+
+	ticker := time.NewTicker(w.batchFlush)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.ctx.Done():
+			w.flush()
+			logger.Log.Print(2, "[ingest] writer stopped")
+			return
+		case <-ticker.C:
+			w.flush()
+		case <-w.flushCh:
+			w.flush()
+		}
+	}
+}
+
+func (w *Writer) Shutdown() {
+	w.cancel()
+}
+
+// Status 저장 통계
+func (w *Writer) Status() Stats {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	st := w.stats
+	st.BufferedStats = len(w.statsBuf)
+	st.BufferedEvent = len(w.eventBuf)
+	return st
+}
+
+// HostID agent 의 호스트 ID (없으면 agent_host 에 등록)
+func (w *Writer) HostID(ctx context.Context, agentId int, hostname, address string) (int, error) {
+	key := hostCacheKey{agentId, hostname}
+	w.hostMu.Lock()
+	id, ok := w.hosts[key]
+	w.hostMu.Unlock()
+	if ok && address == "" {
+		return id, nil
+	}
+
+	id, err := w.dbHnd.UpsertAgentHost(ctx, agentId, hostname, address)
+	if err != nil {
+		return 0, err
+	}
+	w.hostMu.Lock()
+	w.hosts[key] = id
+	w.hostMu.Unlock()
+	return id, nil
+}
+
+// Handle 메시지 저장 (agentId : 인증된 agent), nil 이면 저장 완료
+func (w *Writer) Handle(ctx context.Context, agentId int, msg *pb.AgentMessage) error {
+	return w.HandleBatch(ctx, agentId, []*pb.AgentMessage{msg})
+}
+
+// HandleBatch 메시지 묶음을 한 트랜잭션으로 저장 (실패하면 아무것도 저장되지 않아 재전송해도 중복 없음)
+func (w *Writer) HandleBatch(ctx context.Context, agentId int, msgs []*pb.AgentMessage) error {
+	var batch db.IngestBatch
+	for _, msg := range msgs {
+		hostId, err := w.resolveHost(ctx, agentId, msg)
+		if err != nil {
+			return err
+		}
+		switch msg.GetType() {
+		case pb.DataType_CONTAINER_LIST:
+			batch.Lists = append(batch.Lists, listRows(agentId, hostId, msg))
+		case pb.DataType_CONTAINER_INSPECT:
+			batch.Inspects = append(batch.Inspects, inspectRows(agentId, hostId, msg)...)
+		case pb.DataType_CONTAINER_STATS:
+			batch.Stats = append(batch.Stats, statsRows(agentId, hostId, msg)...)
+		case pb.DataType_CONTAINER_EVENT:
+			if row, ok := w.eventRow(agentId, hostId, msg); ok {
+				batch.Events = append(batch.Events, row)
+			}
+		default:
+			return fmt.Errorf("unknown data type: %v", msg.GetType())
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, writeTimeout)
+	defer cancel()
+	if err := w.dbHnd.WriteIngestBatch(ctx, batch); err != nil {
+		w.recordError(err)
+		return fmt.Errorf("ingest batch: %w", err)
+	}
+
+	w.mu.Lock()
+	w.stats.Messages += uint64(len(msgs))
+	w.stats.StatsRows += uint64(len(batch.Stats))
+	w.stats.EventRows += uint64(len(batch.Events))
+	w.stats.Flushes++
+	w.mu.Unlock()
+	return nil
+}
+
+// HandleStream DataStream 메시지 저장
+// 에러를 반환하면 저장되지 않은 것이고, nil 이면 저장이 끝나거나(nil) 실패했을 때(ErrDropped 등) done 을 한 번 호출한다.
+// list/inspect 는 반환 전에, stats/event 는 버퍼가 flush 된 뒤에 호출된다.
+func (w *Writer) HandleStream(ctx context.Context, agentId int, msg *pb.AgentMessage, done func(error)) error {
+	hostId, err := w.resolveHost(ctx, agentId, msg)
+	if err != nil {
+		return err
+	}
+
+	switch msg.GetType() {
+	case pb.DataType_CONTAINER_LIST:
+		if err := w.saveList(ctx, listRows(agentId, hostId, msg)); err != nil {
+			return err
+		}
+	case pb.DataType_CONTAINER_INSPECT:
+		if err := w.saveInspect(ctx, inspectRows(agentId, hostId, msg)); err != nil {
+			return err
+		}
+	case pb.DataType_CONTAINER_STATS:
+		if err := w.bufferStats(statsRows(agentId, hostId, msg), done); err != nil {
+			return err
+		}
+		w.countMessage()
+		return nil
+	case pb.DataType_CONTAINER_EVENT:
+		row, ok := w.eventRow(agentId, hostId, msg)
+		if !ok {
+			break
+		}
+		if err := w.bufferEvent(row, done); err != nil {
+			return err
+		}
+		w.countMessage()
+		return nil
+	default:
+		return fmt.Errorf("unknown data type: %v", msg.GetType())
+	}
+	w.countMessage()
+	done(nil)
+	return nil
+}
+
+func (w *Writer) resolveHost(ctx context.Context, agentId int, msg *pb.AgentMessage) (int, error) {
+	if msg.GetHost() == "" {
+		return 0, fmt.Errorf("host is required")
+	}
+	hostId, err := w.HostID(ctx, agentId, msg.GetHost(), "")
+	if err != nil {
+		w.recordError(err)
+		return 0, fmt.Errorf("agent_host: %w", err)
+	}
+	return hostId, nil
+}
+
+func (w *Writer) countMessage() {
+	w.mu.Lock()
+	w.stats.Messages++
+	w.mu.Unlock()
+}
+
+func (w *Writer) saveList(ctx context.Context, list db.ContainerList) error {
+	ctx, cancel := context.WithTimeout(ctx, writeTimeout)
+	defer cancel()
+	if err := w.dbHnd.SyncContainerInfo(ctx, list.Id, list.HostId, list.Rows, list.Full); err != nil {
+		w.recordError(err)
+		return fmt.Errorf("container_info: %w", err)
+	}
+	return nil
+}
+
+func (w *Writer) saveInspect(ctx context.Context, rows []db.ContainerInspect) error {
+	ctx, cancel := context.WithTimeout(ctx, writeTimeout)
+	defer cancel()
+	if err := w.dbHnd.UpsertContainerInspect(ctx, rows); err != nil {
+		w.recordError(err)
+		return fmt.Errorf("container_inspect: %w", err)
+	}
+	return nil
+}
+
+// bufferStats 버퍼에 추가 (전부 들어갈 자리가 없으면 ErrBufferFull, 일부만 넣지 않음)
+func (w *Writer) bufferStats(rows []db.ContainerStats, done func(error)) error {
+	if len(rows) == 0 {
+		done(nil)
+		return nil
+	}
+	c := newCommit(len(rows), done)
+
+	w.mu.Lock()
+	if len(w.statsBuf)+len(rows) > maxBuffered {
+		w.stats.Dropped += uint64(len(rows))
+		w.mu.Unlock()
+		return ErrBufferFull
+	}
+	w.statsBuf = append(w.statsBuf, rows...)
+	for range rows {
+		w.statsCommit = append(w.statsCommit, c)
+	}
+	full := len(w.statsBuf) >= w.batchSize
+	w.mu.Unlock()
+
+	if full {
+		w.requestFlush()
+	}
+	return nil
+}
+
+func (w *Writer) bufferEvent(row db.ContainerEvent, done func(error)) error {
+	w.mu.Lock()
+	if len(w.eventBuf) >= maxBuffered {
+		w.stats.Dropped++
+		w.mu.Unlock()
+		return ErrBufferFull
+	}
+	w.eventBuf = append(w.eventBuf, row)
+	w.eventCommit = append(w.eventCommit, newCommit(1, done))
+	full := len(w.eventBuf) >= w.batchSize
+	w.mu.Unlock()
+
+	if full {
+		w.requestFlush()
+	}
+	return nil
+}
+
+// listRows scope 가 없으면 호스트 전체 목록 : 목록에 없는 컨테이너는 삭제
+func listRows(agentId, hostId int, msg *pb.AgentMessage) db.ContainerList {
+	containers := msg.GetListData().GetContainers()
+	rows := make([]db.ContainerInfo, 0, len(containers))
+	for _, c := range containers {
+		rows = append(rows, db.ContainerInfo{
+			Id:            agentId,
+			HostId:        hostId,
+			ContainerId:   c.GetId(),
+			ContainerName: c.GetName(),
+			Image:         c.GetImage(),
+			State:         c.GetState(),
+			Status:        c.GetStatus(),
+		})
+	}
+	return db.ContainerList{Id: agentId, HostId: hostId, Rows: rows, Full: msg.GetScope() == ""}
+}
+
+func inspectRows(agentId, hostId int, msg *pb.AgentMessage) []db.ContainerInspect {
+	inspects := msg.GetInspectData().GetInspects()
+	rows := make([]db.ContainerInspect, 0, len(inspects))
+	for _, in := range inspects {
+		rows = append(rows, db.ContainerInspect{
+			Id:            agentId,
+			HostId:        hostId,
+			ContainerId:   in.GetId(),
+			ContainerName: in.GetName(),
+			Image:         in.GetImage(),
+			Platform:      in.GetPlatform(),
+			StateInfo:     toJSON(in.GetState()),
+			ConfigInfo:    toJSON(in.GetConfig()),
+			NetworkInfo:   toJSON(in.GetNetwork()),
+			MountInfo:     toJSON(in.GetMounts()),
+		})
+	}
+	return rows
+}
+
+func statsRows(agentId, hostId int, msg *pb.AgentMessage) []db.ContainerStats {
+	collectedAt := time.UnixMilli(msg.GetTimestamp())
+	if msg.GetTimestamp() == 0 {
+		collectedAt = time.Now()
+	}
+
+	stats := msg.GetStatsData().GetStats()
+	rows := make([]db.ContainerStats, 0, len(stats))
+	for _, s := range stats {
+		rows = append(rows, db.ContainerStats{
+			Id:            agentId,
+			HostId:        hostId,
+			ContainerId:   s.GetId(),
+			CollectedAt:   collectedAt,
+			ContainerName: s.GetName(),
+			CpuPercent:    clampPercent(s.GetCpuPercent()),
+			MemoryUsage:   s.GetMemoryUsage(),
+			MemoryLimit:   s.GetMemoryLimit(),
+			MemoryPercent: clampPercent(s.GetMemoryPercent()),
+			NetworkRx:     s.GetNetworkRx(),
+			NetworkTx:     s.GetNetworkTx(),
+		})
+	}
+	return rows
+}
+
+func (w *Writer) eventRow(agentId, hostId int, msg *pb.AgentMessage) (db.ContainerEvent, bool) {
+	ev := msg.GetEventData()
+	if ev == nil {
+		return db.ContainerEvent{}, false
+	}
+	containerId := msg.GetScope()
+	if containerId == "" {
+		containerId = ev.GetActorId()
+	}
+
+	// received_at 이 같은 이벤트 구분용 순번
+	w.mu.Lock()
+	w.eventSeq = (w.eventSeq + 1) % 1000000000
+	seq := w.eventSeq
+	w.mu.Unlock()
+
+	return db.ContainerEvent{
+		Id:             agentId,
+		HostId:         hostId,
+		ContainerId:    containerId,
+		ReceivedAt:     time.Now(),
+		Seq:            seq,
+		Hostname:       msg.GetHost(),
+		Type:           ev.GetType(),
+		Action:         ev.GetAction(),
+		ActorId:        ev.GetActorId(),
+		ActorName:      ev.GetActorName(),
+		EventTimestamp: ev.GetTimestamp(),
+		Attrs:          toJSON(ev.GetAttrs()),
+	}, true
+}
+
+func (w *Writer) requestFlush() {
+	select {
+	case w.flushCh <- struct{}{}:
+	default:
+	}
+}
+
+// flush 버퍼를 batchSize 단위로 저장 (실패하면 버퍼에 되돌려 다음 주기에 재시도)
+func (w *Writer) flush() {
+	w.mu.Lock()
+	statsRows, statsCommit := w.statsBuf, w.statsCommit
+	eventRows, eventCommit := w.eventBuf, w.eventCommit
+	w.statsBuf, w.statsCommit = nil, nil
+	w.eventBuf, w.eventCommit = nil, nil
+	w.mu.Unlock()
+
+	for len(statsRows) > 0 {
+		n := min(len(statsRows), w.batchSize)
+		if err := w.write(func(ctx context.Context) error { return w.dbHnd.InsertContainerStats(ctx, statsRows[:n]) }); err != nil {
+			w.requeueStats(statsRows, statsCommit)
+			break
+		}
+		w.mu.Lock()
+		w.stats.StatsRows += uint64(n)
+		w.stats.Flushes++
+		w.mu.Unlock()
+		settleAll(statsCommit[:n], nil)
+		statsRows, statsCommit = statsRows[n:], statsCommit[n:]
+	}
+
+	for len(eventRows) > 0 {
+		n := min(len(eventRows), w.batchSize)
+		if err := w.write(func(ctx context.Context) error { return w.dbHnd.InsertContainerEvents(ctx, eventRows[:n]) }); err != nil {
+			w.requeueEvents(eventRows, eventCommit)
+			break
+		}
+		w.mu.Lock()
+		w.stats.EventRows += uint64(n)
+		w.stats.Flushes++
+		w.mu.Unlock()
+		settleAll(eventCommit[:n], nil)
+		eventRows, eventCommit = eventRows[n:], eventCommit[n:]
+	}
+}
+
+func (w *Writer) write(fn func(ctx context.Context) error) error {
+	ctx, cancel := context.WithTimeout(context.Background(), writeTimeout)
+	defer cancel()
+	if err := fn(ctx); err != nil {
+		w.recordError(err)
+		logger.Log.Error("[ingest] batch insert fail: %v", err)
+		return err
+	}
+	return nil
+}
+
+// requeueStats 저장 실패한 행을 버퍼 앞에 되돌림 (maxBuffered 초과분은 버리고 ErrDropped 로 알림)
+func (w *Writer) requeueStats(rows []db.ContainerStats, commits []*commit) {
+	w.mu.Lock()
+	w.statsBuf = append(rows, w.statsBuf...)
+	w.statsCommit = append(commits, w.statsCommit...)
+	var dropped []*commit
+	if over := len(w.statsBuf) - maxBuffered; over > 0 {
+		dropped = w.statsCommit[:over]
+		w.statsBuf, w.statsCommit = w.statsBuf[over:], w.statsCommit[over:]
+		w.stats.Dropped += uint64(over)
+	}
+	w.mu.Unlock()
+	settleAll(dropped, ErrDropped)
+}
+
+func (w *Writer) requeueEvents(rows []db.ContainerEvent, commits []*commit) {
+	w.mu.Lock()
+	w.eventBuf = append(rows, w.eventBuf...)
+	w.eventCommit = append(commits, w.eventCommit...)
+	var dropped []*commit
+	if over := len(w.eventBuf) - maxBuffered; over > 0 {
+		dropped = w.eventCommit[:over]
+		w.eventBuf, w.eventCommit = w.eventBuf[over:], w.eventCommit[over:]
+		w.stats.Dropped += uint64(over)
+	}
+	w.mu.Unlock()
+	settleAll(dropped, ErrDropped)
+}
+
+func (w *Writer) recordError(err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.stats.WriteErrors++
+	w.stats.LastError = err.Error()
+	w.stats.LastErrorAt = time.Now()
+}
+
+func toJSON(v any) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "null"
+	}
+	return string(b)
+}
+
+// clampPercent DECIMAL(5,2) 범위로 제한 (멀티코어 cpu 사용률은 100 을 넘을 수 있음)
+func clampPercent(v float64) float64 {
+	if v < 0 {
+		return 0
+	}
+	if v > 999.99 {
+		return 999.99
+	}
+	return v
+}
@@ -0,0 +1,10 @@
+package logger
+
+import (
+	"github.com/gdygd/goglib"
+)
+
+// //---------------------------------------------------------------------------
+// // Log
+// //---------------------------------------------------------------------------
+var Log *goglib.OLog2 = goglib.InitLogEnv("./log", "saas", 1) // level 1~9, saas-service
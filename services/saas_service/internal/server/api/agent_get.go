@@ -0,0 +1,56 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (server *Server) heartbeat(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, SuccessResponse("ok"))
+}
+
+func (server *Server) agentList(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, SuccessResponse(server.gapiSvr.Agents()))
+}
+
+func (server *Server) agentDetail(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse("invalid agent id"))
+		return
+	}
+	st, ok := server.gapiSvr.Agent(id)
+	if !ok {
+		ctx.JSON(http.StatusNotFound, ErrorResponse("agent not found"))
+		return
+	}
+	ctx.JSON(http.StatusOK, SuccessResponse(st))
+}
+
+func (server *Server) commandStatus(ctx *gin.Context) {
+	cs, ok := server.gapiSvr.Command(ctx.Param("id"))
+	if !ok {
+		ctx.JSON(http.StatusNotFound, ErrorResponse("command not found"))
+		return
+	}
+	ctx.JSON(http.StatusOK, SuccessResponse(cs))
+}
+
+func (server *Server) ingestStatus(ctx *gin.Context) {
+	agents := server.gapiSvr.Agents()
+	resp := IngestStatusResponse{
+		Agents: len(agents),
+		Writer: server.writer.Status(),
+	}
+	for _, a := range agents {
+		if a.StreamActive {
+			resp.Connected++
+		}
+	}
+	if rc, ok := server.ct.DbHnd.(rowCounter); ok {
+		resp.Rows = rc.Counts()
+	}
+	ctx.JSON(http.StatusOK, SuccessResponse(resp))
+}
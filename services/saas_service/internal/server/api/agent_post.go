@@ -0,0 +1,39 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"saas-service/internal/logger"
+	"saas-service/internal/server/gapi"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (server *Server) pushCommand(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse("invalid agent id"))
+		return
+	}
+	var req gapi.CommandRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse(err.Error()))
+		return
+	}
+
+	cs, err := server.gapiSvr.PushCommand(id, req)
+	if err != nil {
+		logger.Log.Error("pushCommand agent=%d cmd=%s err: %v", id, req.Command, err)
+		code := http.StatusBadRequest
+		if errors.Is(err, gapi.ErrAgentNotConnected) {
+			code = http.StatusNotFound
+		} else if errors.Is(err, gapi.ErrSendQueueFull) {
+			code = http.StatusServiceUnavailable
+		}
+		ctx.JSON(code, ErrorResponse(err.Error()))
+		return
+	}
+	ctx.JSON(http.StatusAccepted, SuccessResponse(cs))
+}
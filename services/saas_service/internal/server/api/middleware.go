@@ -0,0 +1,26 @@
+package api
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// adminMiddleware Authorization: Bearer <ADMIN_TOKEN> 확인 (ADMIN_TOKEN 미설정 시 통과)
+func adminMiddleware(adminToken string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if adminToken == "" {
+			ctx.Next()
+			return
+		}
+		auth := ctx.GetHeader("Authorization")
+		got, ok := strings.CutPrefix(auth, "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(got), []byte(adminToken)) != 1 {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, ErrorResponse("invalid admin token"))
+			return
+		}
+		ctx.Next()
+	}
+}
@@ -0,0 +1,33 @@
+package api
+
+// ============================================================================
+// Generic API Response
+// ============================================================================
+
+type APIResponse[T any] struct {
+	Success bool   `json:"success"`
+	Message string `json:"message,omitempty"`
+	Data    T      `json:"data,omitempty"`
+}
+
+func SuccessResponse[T any](data T) APIResponse[T] {
+	return APIResponse[T]{
+		Success: true,
+		Data:    data,
+	}
+}
+
+func ErrorResponse(message string) APIResponse[any] {
+	return APIResponse[any]{
+		Success: false,
+		Message: message,
+	}
+}
+
+// IngestStatusResponse 저장 통계와 연결 agent 수
+type IngestStatusResponse struct {
+	Agents    int            `json:"agents"`
+	Connected int            `json:"connected"` // DataStream 연결 수
+	Writer    any            `json:"writer"`
+	Rows      map[string]int `json:"rows,omitempty"` // DB_DRIVER=memory 일 때 테이블별 행 수
+}
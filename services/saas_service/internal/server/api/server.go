@@ -0,0 +1,96 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"saas-service/internal/config"
+	"saas-service/internal/container"
+	"saas-service/internal/ingest"
+	"saas-service/internal/logger"
+	"saas-service/internal/server/gapi"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	R_TIME_OUT = 5 * time.Second
+	W_TIME_OUT = 5 * time.Second
+)
+
+// rowCounter DB_DRIVER=memory 일 때 테이블별 행 수 조회
+type rowCounter interface {
+	Counts() map[string]int
+}
+
+// Server 관리 HTTP API (연결 agent 조회, 명령 전송, 저장 상태)
+type Server struct {
+	wg     *sync.WaitGroup
+	srv    *http.Server
+	config *config.Config
+	router *gin.Engine
+	ct     *container.Container
+
+	gapiSvr *gapi.Server
+	writer  *ingest.Writer
+}
+
+func NewServer(wg *sync.WaitGroup, ct *container.Container, gapiSvr *gapi.Server, writer *ingest.Writer) (*Server, error) {
+	server := &Server{
+		wg:      wg,
+		config:  ct.Config,
+		ct:      ct,
+		gapiSvr: gapiSvr,
+		writer:  writer,
+	}
+
+	server.setupRouter()
+
+	server.srv = &http.Server{}
+	server.srv.Addr = ct.Config.HTTPServerAddress
+	server.srv.Handler = server.router.Handler()
+	server.srv.ReadTimeout = R_TIME_OUT
+	server.srv.WriteTimeout = W_TIME_OUT
+
+	return server, nil
+}
+
+func (server *Server) setupRouter() {
+	router := gin.Default()
+
+	router.GET("/heartbeat", server.heartbeat)
+
+	admin := router.Group("/", adminMiddleware(server.config.AdminToken))
+	admin.GET("/agents", server.agentList)                 // 연결(등록)된 agent 목록
+	admin.GET("/agents/:id", server.agentDetail)           // agent 상태
+	admin.POST("/agents/:id/commands", server.pushCommand) // agent 로 명령 전송
+	admin.GET("/commands/:id", server.commandStatus)       // 명령 실행 결과
+	admin.GET("/ingest/status", server.ingestStatus)       // 저장 통계
+
+	server.router = router
+}
+
+func (server *Server) Start() error {
+	logger.Log.Print(2, "Gin server start.%s", server.config.HTTPServerAddress)
+	err := server.srv.ListenAndServe()
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		logger.Log.Error("api server start err: %v", err)
+		return err
+	}
+	return nil
+}
+
+func (server *Server) Shutdown() error {
+	defer server.wg.Done()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := server.srv.Shutdown(ctx); err != nil {
+		logger.Log.Error("api server shutdown err: %v", err)
+		return err
+	}
+	logger.Log.Print(2, "api server stopped")
+	return nil
+}
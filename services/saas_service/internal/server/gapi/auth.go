@@ -0,0 +1,137 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"saas-service/internal/db"
+	"saas-service/internal/util"
+
+	"github.com/gdygd/goglib/token"
+)
+
+const (
+	defaultEnrollTokenTTL = time.Hour
+	defaultKeyCacheTTL    = 30 * time.Second
+	enrollSubjectPrefix   = "enroll:" // bootstrap token 의 username 접두어
+)
+
+// enrollTokens bootstrap token 발급/검증
+// token 은 ENROLL_SECRET 으로 서명한 단기 JWT 이며, 같은 token 으로 한 번만 등록할 수 있다.
+// 사용 이력은 메모리에 보관하므로 만료 시간(ENROLL_TOKEN_TTL)을 짧게 유지한다.
+type enrollTokens struct {
+	maker token.Maker
+
+	mu   sync.Mutex
+	used map[string]time.Time // token ID -> 만료 시각
+}
+
+func newEnrollTokens(secret string) (*enrollTokens, error) {
+	maker, err := token.NewJWTMaker(secret)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create enroll token maker (ENROLL_SECRET):%w", err)
+	}
+	return &enrollTokens{maker: maker, used: make(map[string]time.Time)}, nil
+}
+
+func (e *enrollTokens) issue(agentName string, ttl time.Duration) (string, time.Time, error) {
+	tok, payload, err := e.maker.CreateToken(enrollSubjectPrefix+agentName, ttl)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return tok, payload.ExpiredAt, nil
+}
+
+// consume token 검증 후 사용 처리, token 에 지정된 agent 이름 반환
+func (e *enrollTokens) consume(tok string) (string, error) {
+	payload, err := e.maker.VerifyToken(tok)
+	if err != nil {
+		return "", err
+	}
+	if !strings.HasPrefix(payload.Username, enrollSubjectPrefix) {
+		return "", token.ErrInvalidToken
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	now := time.Now()
+	for id, exp := range e.used {
+		if now.After(exp) {
+			delete(e.used, id)
+		}
+	}
+	id := payload.ID.String()
+	if _, ok := e.used[id]; ok {
+		return "", fmt.Errorf("bootstrap token already used")
+	}
+	e.used[id] = payload.ExpiredAt
+
+	return strings.TrimPrefix(payload.Username, enrollSubjectPrefix), nil
+}
+
+// release 등록 실패 시 token 을 다시 사용할 수 있게 되돌림
+func (e *enrollTokens) release(tok string) {
+	payload, err := e.maker.VerifyToken(tok)
+	if err != nil {
+		return
+	}
+	e.mu.Lock()
+	delete(e.used, payload.ID.String())
+	e.mu.Unlock()
+}
+
+// keyCache agent key 검증 결과 캐시 (요청마다 DB 조회 방지)
+type keyCache struct {
+	ttl time.Duration
+
+	mu      sync.Mutex
+	entries map[string]keyCacheEntry // 해시된 key -> agent
+}
+
+type keyCacheEntry struct {
+	agent   db.Agent
+	expires time.Time
+}
+
+func newKeyCache(ttl time.Duration) *keyCache {
+	if ttl <= 0 {
+		ttl = defaultKeyCacheTTL
+	}
+	return &keyCache{ttl: ttl, entries: make(map[string]keyCacheEntry)}
+}
+
+func (k *keyCache) lookup(ctx context.Context, dbHnd db.DbHandler, key string) (*db.Agent, error) {
+	hashed := util.HashAgentKey(key)
+
+	k.mu.Lock()
+	e, ok := k.entries[hashed]
+	k.mu.Unlock()
+	if ok && time.Now().Before(e.expires) {
+		agent := e.agent
+		return &agent, nil
+	}
+
+	agent, err := dbHnd.ReadAgentByKey(ctx, hashed)
+	if err != nil {
+		return nil, err
+	}
+
+	k.mu.Lock()
+	k.entries[hashed] = keyCacheEntry{agent: agent, expires: time.Now().Add(k.ttl)}
+	k.mu.Unlock()
+	return &agent, nil
+}
+
+// invalidate agent 의 캐시 제거 (key 교체 시 이전 key 즉시 무효화)
+func (k *keyCache) invalidate(agentId int) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	for hashed, e := range k.entries {
+		if e.agent.Id == agentId {
+			delete(k.entries, hashed)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package gapi
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"docker_service/pb"
+
+	"github.com/google/uuid"
+)
+
+// maxCommandHistory 보관할 명령 이력 수
+const maxCommandHistory = 1000
+
+// 명령 상태
+const (
+	CommandSent    = "sent"
+	CommandSuccess = "success"
+	CommandFailed  = "failed"
+)
+
+// CommandStatus agent 로 보낸 명령과 실행 결과
+type CommandStatus struct {
+	CommandId       string            `json:"command_id"`
+	AgentId         int               `json:"agentid"`
+	Command         string            `json:"command"`
+	Host            string            `json:"host"`
+	TargetContainer string            `json:"target_container,omitempty"`
+	Args            map[string]string `json:"args,omitempty"`
+	Status          string            `json:"status"`
+	Error           string            `json:"error,omitempty"`
+	SentAt          time.Time         `json:"sent_at"`
+	CompletedAt     time.Time         `json:"completed_at,omitempty"`
+}
+
+// commandTracker 명령 이력 (최근 maxCommandHistory 개)
+type commandTracker struct {
+	mu    sync.Mutex
+	byId  map[string]*CommandStatus
+	order []string
+}
+
+func newCommandTracker() *commandTracker {
+	return &commandTracker{byId: make(map[string]*CommandStatus)}
+}
+
+func (t *commandTracker) add(cs *CommandStatus) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.byId[cs.CommandId] = cs
+	t.order = append(t.order, cs.CommandId)
+	if over := len(t.order) - maxCommandHistory; over > 0 {
+		for _, id := range t.order[:over] {
+			delete(t.byId, id)
+		}
+		t.order = append([]string(nil), t.order[over:]...)
+	}
+}
+
+func (t *commandTracker) get(id string) (CommandStatus, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	cs, ok := t.byId[id]
+	if !ok {
+		return CommandStatus{}, false
+	}
+	return *cs, true
+}
+
+// complete ReportCommand 결과 반영 (다른 agent 의 명령이면 false)
+func (t *commandTracker) complete(agentId int, r *pb.CommandResult) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	cs, ok := t.byId[r.GetCommandId()]
+	if !ok || cs.AgentId != agentId {
+		return false
+	}
+	cs.Status = CommandSuccess
+	if !r.GetSuccess() {
+		cs.Status = CommandFailed
+	}
+	cs.Error = r.GetError()
+	cs.CompletedAt = time.Now()
+	return true
+}
+
+func (t *commandTracker) fail(id string, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if cs, ok := t.byId[id]; ok {
+		cs.Status = CommandFailed
+		cs.Error = err.Error()
+		cs.CompletedAt = time.Now()
+	}
+}
+
+// CommandRequest 명령 요청 (관리 API)
+type CommandRequest struct {
+	Command         string            `json:"command" binding:"required"` // ex: restart_container
+	Host            string            `json:"host"`
+	TargetContainer string            `json:"target_container"`
+	Args            map[string]string `json:"args"`
+}
+
+// PushCommand 연결된 agent 의 DataStream 으로 명령 전송
+func (server *Server) PushCommand(agentId int, req CommandRequest) (CommandStatus, error) {
+	v, ok := pb.CommandType_value[strings.ToUpper(strings.TrimSpace(req.Command))]
+	if !ok || pb.CommandType(v) == pb.CommandType_ACK {
+		return CommandStatus{}, fmt.Errorf("unknown command: %s", req.Command)
+	}
+	cmd := pb.CommandType(v)
+
+	s, ok := server.sessions.lookup(agentId)
+	if !ok {
+		return CommandStatus{}, ErrAgentNotConnected
+	}
+	if !s.allows(cmd) {
+		return CommandStatus{}, fmt.Errorf("command %s is not allowed by agent %d", cmd, agentId)
+	}
+
+	cs := &CommandStatus{
+		CommandId:       uuid.NewString(),
+		AgentId:         agentId,
+		Command:         cmd.String(),
+		Host:            req.Host,
+		TargetContainer: req.TargetContainer,
+		Args:            req.Args,
+		Status:          CommandSent,
+		SentAt:          time.Now(),
+	}
+	msg := &pb.ServerMessage{
+		Command:         cmd,
+		Host:            req.Host,
+		TargetContainer: req.TargetContainer,
+		CommandId:       cs.CommandId,
+		Args:            req.Args,
+	}
+	// 전송 전에 등록 (결과 보고가 먼저 도착할 수 있음)
+	server.commands.add(cs)
+	if err := s.send(msg); err != nil {
+		server.commands.fail(cs.CommandId, err)
+		return CommandStatus{}, err
+	}
+	return *cs, nil
+}
+
+// Command 명령 상태 조회
+func (server *Server) Command(id string) (CommandStatus, bool) {
+	return server.commands.get(id)
+}
@@ -0,0 +1,97 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"saas-service/internal/db"
+	"saas-service/internal/logger"
+
+	"docker_service/pb"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// agent-key 없이 호출 가능한 RPC (bootstrap token 으로 인증)
+var publicMethods = map[string]bool{
+	pb.ContainerService_Enroll_FullMethodName: true,
+}
+
+type agentCtxKey struct{}
+
+// agentFromContext 인증 interceptor 가 저장한 agent
+func agentFromContext(ctx context.Context) *db.Agent {
+	agent, _ := ctx.Value(agentCtxKey{}).(*db.Agent)
+	return agent
+}
+
+func GrpcServerLogger(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	startTime := time.Now()
+	result, err := handler(ctx, req)
+	duration := time.Since(startTime)
+
+	statusCode := codes.Unknown
+	if st, ok := status.FromError(err); ok {
+		statusCode = st.Code()
+	}
+
+	if err != nil {
+		logger.Log.Error("gRPC request Err.. %v", err)
+	}
+
+	logger.Log.Print(2, "protocol : grpc, method : %s, status_code : %d status_test : %s duration : %v, received gRPC request",
+		info.FullMethod, int(statusCode), statusCode.String(), duration)
+
+	return result, err
+}
+
+// authenticate metadata agent-key 를 agent 테이블과 대조
+func (server *Server) authenticate(ctx context.Context) (*db.Agent, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	keys := md.Get("agent-key")
+	if len(keys) == 0 || keys[0] == "" {
+		return nil, status.Error(codes.Unauthenticated, "agent-key is required")
+	}
+
+	agent, err := server.keys.lookup(ctx, server.dbHnd, keys[0])
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, status.Error(codes.Unauthenticated, "invalid agent-key")
+	}
+	if err != nil {
+		logger.Log.Error("authenticate err : %v", err)
+		return nil, status.Error(codes.Unavailable, "agent-key verification failed")
+	}
+	return agent, nil
+}
+
+func (server *Server) agentAuthUnary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if publicMethods[info.FullMethod] {
+		return handler(ctx, req)
+	}
+	agent, err := server.authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return handler(context.WithValue(ctx, agentCtxKey{}, agent), req)
+}
+
+// authStream 인증된 agent 를 context 에 담은 ServerStream
+type authStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authStream) Context() context.Context { return s.ctx }
+
+func (server *Server) agentAuthStream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	agent, err := server.authenticate(ss.Context())
+	if err != nil {
+		return err
+	}
+	ctx := context.WithValue(ss.Context(), agentCtxKey{}, agent)
+	return handler(srv, &authStream{ServerStream: ss, ctx: ctx})
+}
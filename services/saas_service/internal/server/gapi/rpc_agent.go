@@ -0,0 +1,88 @@
+package gapi
+
+import (
+	"context"
+	"time"
+
+	"saas-service/internal/logger"
+
+	"docker_service/pb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// Register 연결(재연결) 시 agent 정보, 호스트 목록 등록 및 서버 설정 전달
+func (server *Server) Register(ctx context.Context, req *pb.RegisterRequest) (*pb.RegisterResponse, error) {
+	agent := agentFromContext(ctx)
+	if agent == nil {
+		return nil, status.Error(codes.Unauthenticated, "agent-key is required")
+	}
+	if err := checkAgent(agent, req.GetAgentid()); err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	address := ""
+	if p, ok := peer.FromContext(ctx); ok {
+		address = p.Addr.String()
+	}
+	if err := server.dbHnd.UpdateAgentInfo(ctx, agent.Id, req.GetAgentName(), address); err != nil {
+		logger.Log.Error("Register agent=%d update agent err : %v", agent.Id, err)
+	}
+	for _, h := range req.GetHosts() {
+		if _, err := server.writer.HostID(ctx, agent.Id, h.GetName(), h.GetAddr()); err != nil {
+			logger.Log.Error("Register agent=%d host=%s err : %v", agent.Id, h.GetName(), err)
+			return nil, status.Error(codes.Unavailable, "cannot register host")
+		}
+	}
+
+	name := req.GetAgentName()
+	if name == "" {
+		name = agent.AgentName
+	}
+	server.sessions.get(agent.Id).register(req, name, address)
+
+	logger.Log.Print(2, "Register agent=%d name=%s version=%s hosts=%d commands=%v",
+		agent.Id, name, req.GetVersion(), len(req.GetHosts()), req.GetCommands())
+
+	return &pb.RegisterResponse{
+		Success:              true,
+		Agentid:              int32(agent.Id),
+		CollectorSettings:    server.config.CollectorSettings,
+		HeartbeatIntervalSec: int32(server.config.HeartbeatIntervalSec),
+	}, nil
+}
+
+// Heartbeat agent 상태(큐 적체량 등) 갱신
+func (server *Server) Heartbeat(ctx context.Context, req *pb.HeartbeatRequest) (*pb.HeartbeatResponse, error) {
+	agent := agentFromContext(ctx)
+	if agent == nil {
+		return nil, status.Error(codes.Unauthenticated, "agent-key is required")
+	}
+	if err := checkAgent(agent, req.GetAgentid()); err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	server.sessions.get(agent.Id).heartbeat(req)
+	if req.GetHealth() != "" && req.GetHealth() != "ok" {
+		logger.Log.Warn("Heartbeat agent=%d health=%s inflight=%d last_error=%s",
+			agent.Id, req.GetHealth(), req.GetInflight(), req.GetLastError())
+	}
+	return &pb.HeartbeatResponse{Success: true, ServerTime: time.Now().UnixMilli()}, nil
+}
+
+// ReportCommand 명령 실행 결과 반영
+func (server *Server) ReportCommand(ctx context.Context, req *pb.CommandResult) (*pb.ServerMessage, error) {
+	agent := agentFromContext(ctx)
+	if agent == nil {
+		return nil, status.Error(codes.Unauthenticated, "agent-key is required")
+	}
+
+	if !server.commands.complete(agent.Id, req) {
+		logger.Log.Warn("ReportCommand agent=%d unknown command id=%s", agent.Id, req.GetCommandId())
+	}
+	logger.Log.Print(2, "ReportCommand agent=%d id=%s cmd=%s host=%s target=%s success=%v err=%s",
+		agent.Id, req.GetCommandId(), req.GetCommand(), req.GetHost(), req.GetTargetContainer(), req.GetSuccess(), req.GetError())
+	return ackMessage, nil
+}
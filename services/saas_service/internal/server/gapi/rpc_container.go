@@ -0,0 +1,64 @@
+package gapi
+
+import (
+	"context"
+
+	"saas-service/internal/logger"
+
+	"docker_service/pb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var ackMessage = &pb.ServerMessage{Command: pb.CommandType_ACK}
+
+// saveMessages unary/batch 로 받은 수집 데이터를 한 트랜잭션으로 저장 (ACK = 저장 완료)
+func (server *Server) saveMessages(ctx context.Context, msgs []*pb.AgentMessage) (*pb.ServerMessage, error) {
+	agent := agentFromContext(ctx)
+	if agent == nil {
+		return nil, status.Error(codes.Unauthenticated, "agent-key is required")
+	}
+	for _, msg := range msgs {
+		if err := checkAgent(agent, msg.GetAgentid()); err != nil {
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		}
+	}
+	if err := server.writer.HandleBatch(ctx, agent.Id, msgs); err != nil {
+		logger.Log.Error("agent=%d messages=%d save fail: %v", agent.Id, len(msgs), err)
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	if s, ok := server.sessions.lookup(agent.Id); ok {
+		s.received(len(msgs))
+	}
+	return ackMessage, nil
+}
+
+func (server *Server) saveMessage(ctx context.Context, req *pb.AgentMessage) (*pb.ServerMessage, error) {
+	return server.saveMessages(ctx, []*pb.AgentMessage{req})
+}
+
+func (server *Server) ContainerState(ctx context.Context, req *pb.AgentMessage) (*pb.ServerMessage, error) {
+	return server.saveMessage(ctx, req)
+}
+
+func (server *Server) ContainerInfo(ctx context.Context, req *pb.AgentMessage) (*pb.ServerMessage, error) {
+	return server.saveMessage(ctx, req)
+}
+
+func (server *Server) ContainerInspect(ctx context.Context, req *pb.AgentMessage) (*pb.ServerMessage, error) {
+	return server.saveMessage(ctx, req)
+}
+
+func (server *Server) ContainerStats(ctx context.Context, req *pb.AgentMessage) (*pb.ServerMessage, error) {
+	return server.saveMessage(ctx, req)
+}
+
+func (server *Server) ContainerEvent(ctx context.Context, req *pb.AgentMessage) (*pb.ServerMessage, error) {
+	return server.saveMessage(ctx, req)
+}
+
+// ContainerBatch 묶음 전송 저장 (전부 저장하거나 아무것도 저장하지 않음 : 실패 시 agent 가 배치 전체를 재전송)
+func (server *Server) ContainerBatch(ctx context.Context, req *pb.AgentMessageBatch) (*pb.ServerMessage, error) {
+	return server.saveMessages(ctx, req.GetMessages())
+}
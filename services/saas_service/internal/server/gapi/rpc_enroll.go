@@ -0,0 +1,82 @@
+package gapi
+
+import (
+	"context"
+
+	"saas-service/internal/db"
+	"saas-service/internal/logger"
+	"saas-service/internal/util"
+
+	"docker_service/pb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// Enroll bootstrap token 확인 후 agent 등록, agent ID 와 agent key 발급
+func (server *Server) Enroll(ctx context.Context, req *pb.EnrollRequest) (*pb.EnrollResponse, error) {
+	name, err := server.enroll.consume(req.GetBootstrapToken())
+	if err != nil {
+		logger.Log.Warn("Enroll rejected : %v", err)
+		return nil, status.Error(codes.Unauthenticated, "invalid bootstrap token")
+	}
+	if name == "" {
+		name = req.GetAgentName()
+	}
+
+	addr := req.GetAgentAddress()
+	if addr == "" {
+		if p, ok := peer.FromContext(ctx); ok {
+			addr = p.Addr.String()
+		}
+	}
+
+	key, err := util.NewAgentKey()
+	if err != nil {
+		server.enroll.release(req.GetBootstrapToken())
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	agent, err := server.dbHnd.CreateAgent(ctx, db.CreateAgentParams{
+		AgentName:    name,
+		AgentKey:     util.HashAgentKey(key),
+		AgentAddress: addr,
+	})
+	if err != nil {
+		server.enroll.release(req.GetBootstrapToken())
+		logger.Log.Error("Enroll CreateAgent err : %v", err)
+		return nil, status.Error(codes.Internal, "cannot create agent")
+	}
+
+	logger.Log.Print(2, "Enroll agent=%d name=%s addr=%s version=%s", agent.Id, name, addr, req.GetVersion())
+	return &pb.EnrollResponse{
+		Success:  true,
+		Agentid:  int32(agent.Id),
+		AgentKey: key,
+	}, nil
+}
+
+// RotateKey 현재 agent key 로 인증된 agent 의 key 교체
+func (server *Server) RotateKey(ctx context.Context, req *pb.RotateKeyRequest) (*pb.RotateKeyResponse, error) {
+	agent := agentFromContext(ctx)
+	if agent == nil {
+		return nil, status.Error(codes.Unauthenticated, "agent-key is required")
+	}
+	if err := checkAgent(agent, req.GetAgentid()); err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	key, err := util.NewAgentKey()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if err := server.dbHnd.UpdateAgentKey(ctx, agent.Id, util.HashAgentKey(key)); err != nil {
+		logger.Log.Error("RotateKey agent=%d err : %v", agent.Id, err)
+		return nil, status.Error(codes.Internal, "cannot update agent key")
+	}
+	server.keys.invalidate(agent.Id)
+
+	logger.Log.Print(2, "RotateKey agent=%d", agent.Id)
+	return &pb.RotateKeyResponse{Success: true, AgentKey: key}, nil
+}
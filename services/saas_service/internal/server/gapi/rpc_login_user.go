@@ -0,0 +1,57 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"saas-service/internal/db"
+	"saas-service/internal/logger"
+	"saas-service/internal/util"
+
+	"docker_service/pb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const defaultAccessTokenDuration = 15 * time.Minute
+
+// LoginUser users 테이블 사용자 확인 후 access token 발급
+func (server *Server) LoginUser(ctx context.Context, req *pb.LoginUserRequest) (*pb.LoginUserResponse, error) {
+	user, err := server.dbHnd.ReadUser(ctx, req.GetUsername())
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, "cannot read user")
+	}
+	if err := util.CheckPassword(req.GetPassword(), user.HashedPassword); err != nil {
+		return nil, status.Error(codes.Unauthenticated, "incorrect password")
+	}
+
+	duration := server.config.AccessTokenDuration
+	if duration <= 0 {
+		duration = defaultAccessTokenDuration
+	}
+	accessToken, payload, err := server.tokenMaker.CreateToken(user.Username, duration)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "cannot create access token")
+	}
+
+	logger.Log.Print(2, "LoginUser user=%s", user.Username)
+	resp := &pb.LoginUserResponse{
+		User: &pb.User{
+			Username: user.Username,
+			FullName: user.FullName,
+			Email:    user.Email,
+		},
+		AccessToken:          accessToken,
+		AccessTokenExpiresAt: timestamppb.New(payload.ExpiredAt),
+	}
+	if user.CreatedAt.Valid {
+		resp.User.CreatedAt = timestamppb.New(user.CreatedAt.Time)
+	}
+	return resp, nil
+}
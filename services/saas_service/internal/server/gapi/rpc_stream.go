@@ -0,0 +1,218 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"saas-service/internal/db"
+	"saas-service/internal/logger"
+
+	"docker_service/pb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ackInterval DataStream 누적 ACK 전송 주기
+const ackInterval = 200 * time.Millisecond
+
+// DataStream agent 수집 데이터 수신, 누적 ACK 와 명령(ServerMessage) 전송
+// 스트림의 첫 seq 부터 연속으로 받은 seq 중 저장이 끝난 seq 까지만 ACK 한다. 이미 받은 seq 는 무시하고,
+// 중간 seq 가 빠지거나 저장에 실패하면 스트림을 끊어 agent 가 재연결 후 ACK 대기 메시지를 순서대로 재전송하게 한다.
+func (server *Server) DataStream(stream pb.ContainerService_DataStreamServer) error {
+	agent := agentFromContext(stream.Context())
+	if agent == nil {
+		return status.Error(codes.Unauthenticated, "agent-key is required")
+	}
+
+	sess, streamId, sendCh := server.sessions.attach(agent.Id)
+	defer sess.detach(streamId)
+	logger.Log.Print(2, "[DataStream] agent=%d connected", agent.Id)
+
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	acks := newDurableAcks()
+	sendErr := make(chan error, 1)
+	go func() {
+		sendErr <- server.streamSender(ctx, stream, sendCh, &acks.ackSeq)
+		cancel()
+	}()
+	recvErr := make(chan error, 1)
+	go func() {
+		recvErr <- server.streamReceiver(ctx, stream, agent, sess, acks)
+	}()
+
+	for {
+		select {
+		case err := <-recvErr:
+			return err
+		case err := <-sendErr:
+			if err != nil {
+				return err
+			}
+			sendErr = nil // ctx 종료 : receiver 도 곧 끝난다
+		case err := <-acks.failed:
+			logger.Log.Error("[DataStream] agent=%d save fail, stream closed: %v", agent.Id, err)
+			return status.Errorf(codes.Unavailable, "save fail: %v", err)
+		}
+	}
+}
+
+// streamReceiver 메시지 수신 및 저장 (저장 완료는 acks 로 비동기 통지)
+func (server *Server) streamReceiver(ctx context.Context, stream pb.ContainerService_DataStreamServer, agent *db.Agent, sess *agentSession, acks *durableAcks) error {
+	var nextSeq uint64 // 다음에 받아야 할 seq (0 : 첫 메시지 전)
+	for {
+		msg, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			logger.Log.Print(2, "[DataStream] agent=%d closed", agent.Id)
+			return nil
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				logger.Log.Print(2, "[DataStream] agent=%d disconnected", agent.Id)
+				return nil
+			}
+			return err
+		}
+
+		if err := checkAgent(agent, msg.GetAgentid()); err != nil {
+			return status.Error(codes.PermissionDenied, err.Error())
+		}
+
+		seq := msg.GetSeq()
+		if seq != 0 {
+			if nextSeq == 0 {
+				nextSeq = seq
+			}
+			if seq < nextSeq {
+				logger.Log.Print(2, "[DataStream] agent=%d duplicate seq=%d (next=%d), skipped", agent.Id, seq, nextSeq)
+				continue
+			}
+			if seq > nextSeq {
+				logger.Log.Warn("[DataStream] agent=%d seq gap: expected %d, got %d", agent.Id, nextSeq, seq)
+				return status.Errorf(codes.Aborted, "seq gap: expected %d, got %d", nextSeq, seq)
+			}
+		}
+
+		// 저장 실패 시 ACK 하지 않고 스트림을 끊는다 (agent 가 이 seq 부터 재전송)
+		if err := server.writer.HandleStream(ctx, agent.Id, msg, acks.track(seq)); err != nil {
+			logger.Log.Error("[DataStream] agent=%d host=%s type=%v seq=%d save fail: %v", agent.Id, msg.GetHost(), msg.GetType(), seq, err)
+			return status.Errorf(codes.Unavailable, "save fail: %v", err)
+		}
+		sess.received(1)
+
+		if seq != 0 {
+			nextSeq = seq + 1
+		}
+	}
+}
+
+// durableAcks 수신 순서대로 저장 완료를 모아 연속으로 저장된 마지막 seq 를 ackSeq 로 노출
+type durableAcks struct {
+	mu      sync.Mutex
+	pending []pendingSeq // 수신 순서, 저장 대기
+	ackSeq  atomic.Uint64
+	failed  chan error
+}
+
+type pendingSeq struct {
+	seq  uint64
+	done bool
+}
+
+func newDurableAcks() *durableAcks {
+	return &durableAcks{failed: make(chan error, 1)}
+}
+
+// track seq 의 저장 완료 콜백 (seq 0 : ACK 대상이 아니므로 실패만 알림)
+func (a *durableAcks) track(seq uint64) func(error) {
+	if seq == 0 {
+		return func(err error) {
+			if err != nil {
+				a.fail(err)
+			}
+		}
+	}
+	a.mu.Lock()
+	a.pending = append(a.pending, pendingSeq{seq: seq})
+	a.mu.Unlock()
+
+	return func(err error) {
+		if err != nil {
+			a.fail(fmt.Errorf("seq %d: %w", seq, err))
+			return
+		}
+		a.mu.Lock()
+		defer a.mu.Unlock()
+		for i := range a.pending {
+			if a.pending[i].seq == seq {
+				a.pending[i].done = true
+				break
+			}
+		}
+		n := 0
+		for n < len(a.pending) && a.pending[n].done {
+			n++
+		}
+		if n > 0 {
+			a.ackSeq.Store(a.pending[n-1].seq)
+			a.pending = a.pending[n:]
+		}
+	}
+}
+
+func (a *durableAcks) fail(err error) {
+	select {
+	case a.failed <- err:
+	default:
+	}
+}
+
+// streamSender 명령 전송 및 주기적 누적 ACK (stream.Send 는 이 goroutine 에서만 호출)
+func (server *Server) streamSender(ctx context.Context, stream pb.ContainerService_DataStreamServer, sendCh <-chan *pb.ServerMessage, ackSeq *atomic.Uint64) error {
+	ticker := time.NewTicker(ackInterval)
+	defer ticker.Stop()
+
+	var acked uint64
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case msg := <-sendCh:
+			if err := stream.Send(msg); err != nil {
+				return err
+			}
+		case <-ticker.C:
+			seq := ackSeq.Load()
+			if seq <= acked {
+				continue
+			}
+			if err := stream.Send(&pb.ServerMessage{Command: pb.CommandType_ACK, AckSeq: seq}); err != nil {
+				return err
+			}
+			acked = seq
+		}
+	}
+}
+
+// ConnMessage 연결 확인용 echo 스트림
+func (server *Server) ConnMessage(stream pb.ContainerService_ConnMessageServer) error {
+	for {
+		msg, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := stream.Send(&pb.Hello{Msg: msg.GetMsg()}); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package gapi
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"saas-service/internal/config"
+	"saas-service/internal/container"
+	"saas-service/internal/db"
+	"saas-service/internal/ingest"
+	"saas-service/internal/logger"
+
+	"docker_service/pb"
+
+	"github.com/gdygd/goglib/token"
+	"google.golang.org/grpc"
+	_ "google.golang.org/grpc/encoding/gzip" // agent GRPC_COMPRESSION=gzip 수신용 (미등록 시 Unimplemented)
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/reflection"
+)
+
+// Server agent 수집 데이터 수신 gRPC 서버 (docker_service ContainerService 구현)
+type Server struct {
+	wg *sync.WaitGroup
+	pb.UnimplementedContainerServiceServer
+	gServer *grpc.Server
+	config  *config.Config
+	dbHnd   db.DbHandler
+
+	enroll *enrollTokens // bootstrap token 발급/검증
+	keys   *keyCache     // agent key 검증 캐시
+
+	tokenMaker token.Maker
+	writer     *ingest.Writer   // 수집 데이터 저장
+	sessions   *sessionRegistry // 연결된 agent
+	commands   *commandTracker  // 전송한 명령 결과
+}
+
+func NewServer(wg *sync.WaitGroup, ct *container.Container, writer *ingest.Writer) (*Server, error) {
+	enroll, err := newEnrollTokens(ct.Config.EnrollSecret)
+	if err != nil {
+		return nil, err
+	}
+	tokenMaker, err := token.NewJWTMaker(ct.Config.TokenSecretKey)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create token maker:%w", err)
+	}
+
+	server := &Server{
+		wg:     wg,
+		config: ct.Config,
+		dbHnd:  ct.DbHnd,
+		enroll: enroll,
+		keys:   newKeyCache(ct.Config.AgentKeyCache),
+
+		tokenMaker: tokenMaker,
+		writer:     writer,
+		sessions:   newSessionRegistry(),
+		commands:   newCommandTracker(),
+	}
+
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			GrpcServerLogger,
+			server.agentAuthUnary,
+		),
+		grpc.ChainStreamInterceptor(
+			server.agentAuthStream,
+		),
+		// agent GRPC_KEEPALIVE (half-open 감지용 ping) 허용, 기본값(5분)보다 짧으면 GOAWAY 로 끊긴다
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             10 * time.Second,
+			PermitWithoutStream: true,
+		}),
+	)
+	pb.RegisterContainerServiceServer(grpcServer, server)
+	reflection.Register(grpcServer)
+
+	server.gServer = grpcServer
+
+	return server, nil
+}
+
+// IssueEnrollToken 신규 agent 용 bootstrap token 발급 (agentName : 등록될 agent 이름, "" : agent 가 보낸 이름 사용)
+func IssueEnrollToken(cfg *config.Config, agentName string) (string, time.Time, error) {
+	enroll, err := newEnrollTokens(cfg.EnrollSecret)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	ttl := cfg.EnrollTokenTTL
+	if ttl <= 0 {
+		ttl = defaultEnrollTokenTTL
+	}
+	return enroll.issue(agentName, ttl)
+}
+
+func (server *Server) StartgPRC() error {
+	logger.Log.Print(2, "gRPC server start.%s", server.config.GRPCServerAddress)
+
+	listener, err := net.Listen("tcp", server.config.GRPCServerAddress)
+	if err != nil {
+		logger.Log.Error("cannot create listener: %v", err)
+		return err
+	}
+
+	err = server.gServer.Serve(listener)
+	if err != nil {
+		if errors.Is(err, grpc.ErrServerStopped) {
+			return nil
+		}
+		logger.Log.Error("gRPC server faield to serve, err:%v", err)
+		return err
+	}
+	return nil
+}
+
+func (server *Server) ShutdowngRPC() error {
+	defer server.wg.Done()
+	done := make(chan struct{})
+	go func() {
+		server.gServer.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		logger.Log.Print(2, "gPRC server stopped gracefully")
+	case <-time.After(5 * time.Second):
+		logger.Log.Print(2, "gPRC server stopping.. timeout.. force stop")
+		server.gServer.Stop()
+	}
+
+	return nil
+}
+
+// checkAgent 인증된 agent 와 요청의 agentid 일치 확인 (0 : 생략)
+func checkAgent(agent *db.Agent, agentId int32) error {
+	if agentId != 0 && int(agentId) != agent.Id {
+		return fmt.Errorf("agentid %d does not match credential", agentId)
+	}
+	return nil
+}
@@ -0,0 +1,198 @@
+package gapi
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"docker_service/pb"
+)
+
+// sendQueueSize DataStream 송신 큐 (ACK, 명령)
+const sendQueueSize = 256
+
+var (
+	ErrAgentNotConnected = errors.New("agent is not connected (no DataStream)")
+	ErrSendQueueFull     = errors.New("agent send queue is full")
+)
+
+// AgentStatus 연결된 agent 상태 (관리 API)
+type AgentStatus struct {
+	AgentId       int       `json:"agentid"`
+	Name          string    `json:"name"`
+	Version       string    `json:"version"`
+	Address       string    `json:"address"`
+	Hosts         []string  `json:"hosts"`
+	DataTypes     []string  `json:"data_types"`
+	Commands      []string  `json:"commands"`
+	StreamActive  bool      `json:"stream_active"`
+	RegisteredAt  time.Time `json:"registered_at,omitempty"`
+	LastHeartbeat time.Time `json:"last_heartbeat,omitempty"`
+	LastReceived  time.Time `json:"last_received,omitempty"`
+	Health        string    `json:"health,omitempty"`
+	Inflight      int32     `json:"inflight"`
+	SendChLen     int32     `json:"send_ch_len"`
+	LastError     string    `json:"last_error,omitempty"`
+	Received      uint64    `json:"received"` // 수신 메시지 수
+}
+
+// agentSession agent 별 Register/Heartbeat 정보와 DataStream 송신 큐
+type agentSession struct {
+	mu       sync.Mutex
+	status   AgentStatus
+	commands map[pb.CommandType]bool // Register 시 보고한 허용 명령 (nil : 미등록)
+	sendCh   chan *pb.ServerMessage  // nil : DataStream 없음
+	streamId uint64
+}
+
+// sessionRegistry agent ID -> session
+type sessionRegistry struct {
+	mu       sync.RWMutex
+	sessions map[int]*agentSession
+	streamId uint64
+}
+
+func newSessionRegistry() *sessionRegistry {
+	return &sessionRegistry{sessions: make(map[int]*agentSession)}
+}
+
+func (r *sessionRegistry) get(agentId int) *agentSession {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s, ok := r.sessions[agentId]
+	if !ok {
+		s = &agentSession{status: AgentStatus{AgentId: agentId}}
+		r.sessions[agentId] = s
+	}
+	return s
+}
+
+func (r *sessionRegistry) lookup(agentId int) (*agentSession, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	s, ok := r.sessions[agentId]
+	return s, ok
+}
+
+// attach 새 DataStream 연결 (이전 스트림의 송신 큐는 교체)
+func (r *sessionRegistry) attach(agentId int) (*agentSession, uint64, chan *pb.ServerMessage) {
+	s := r.get(agentId)
+	r.mu.Lock()
+	r.streamId++
+	id := r.streamId
+	r.mu.Unlock()
+
+	ch := make(chan *pb.ServerMessage, sendQueueSize)
+	s.mu.Lock()
+	s.sendCh = ch
+	s.streamId = id
+	s.status.StreamActive = true
+	s.mu.Unlock()
+	return s, id, ch
+}
+
+// detach DataStream 종료 (그 사이 새 스트림이 붙었으면 유지)
+func (s *agentSession) detach(streamId uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.streamId == streamId {
+		s.sendCh = nil
+		s.status.StreamActive = false
+	}
+}
+
+// send DataStream 으로 ServerMessage 전송 요청
+func (s *agentSession) send(msg *pb.ServerMessage) error {
+	s.mu.Lock()
+	ch := s.sendCh
+	s.mu.Unlock()
+	if ch == nil {
+		return ErrAgentNotConnected
+	}
+	select {
+	case ch <- msg:
+		return nil
+	default:
+		return ErrSendQueueFull
+	}
+}
+
+// allows Register 시 보고한 허용 명령인지 (미등록이면 agent 에서 판단)
+func (s *agentSession) allows(cmd pb.CommandType) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.commands == nil || s.commands[cmd]
+}
+
+func (s *agentSession) received(n int) {
+	s.mu.Lock()
+	s.status.Received += uint64(n)
+	s.status.LastReceived = time.Now()
+	s.mu.Unlock()
+}
+
+func (s *agentSession) register(req *pb.RegisterRequest, name, address string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.status.Name = name
+	s.status.Version = req.GetVersion()
+	s.status.Address = address
+	s.status.RegisteredAt = time.Now()
+	s.status.Hosts = s.status.Hosts[:0]
+	for _, h := range req.GetHosts() {
+		s.status.Hosts = append(s.status.Hosts, h.GetName())
+	}
+	s.status.DataTypes = s.status.DataTypes[:0]
+	for _, t := range req.GetDataTypes() {
+		s.status.DataTypes = append(s.status.DataTypes, t.String())
+	}
+	s.status.Commands = s.status.Commands[:0]
+	s.commands = make(map[pb.CommandType]bool)
+	for _, c := range req.GetCommands() {
+		s.status.Commands = append(s.status.Commands, c.String())
+		s.commands[c] = true
+	}
+}
+
+func (s *agentSession) heartbeat(req *pb.HeartbeatRequest) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.status.LastHeartbeat = time.Now()
+	s.status.Health = req.GetHealth()
+	s.status.Inflight = req.GetInflight()
+	s.status.SendChLen = req.GetSendChLen()
+	s.status.LastError = req.GetLastError()
+}
+
+func (s *agentSession) snapshot() AgentStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	st := s.status
+	st.Hosts = append([]string(nil), s.status.Hosts...)
+	st.DataTypes = append([]string(nil), s.status.DataTypes...)
+	st.Commands = append([]string(nil), s.status.Commands...)
+	return st
+}
+
+// Agents 연결(등록)된 agent 목록
+func (server *Server) Agents() []AgentStatus {
+	server.sessions.mu.RLock()
+	list := make([]AgentStatus, 0, len(server.sessions.sessions))
+	for _, s := range server.sessions.sessions {
+		list = append(list, s.snapshot())
+	}
+	server.sessions.mu.RUnlock()
+
+	sort.Slice(list, func(i, j int) bool { return list[i].AgentId < list[j].AgentId })
+	return list
+}
+
+// Agent agent 상태 조회
+func (server *Server) Agent(agentId int) (AgentStatus, bool) {
+	s, ok := server.sessions.lookup(agentId)
+	if !ok {
+		return AgentStatus{}, false
+	}
+	return s.snapshot(), true
+}
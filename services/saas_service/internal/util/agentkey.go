@@ -0,0 +1,27 @@
+package util
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+)
+
+// agentKeyPrefix 발급 key 식별용 접두어
+const agentKeyPrefix = "ak_"
+
+// NewAgentKey 새 agent key 생성 (256bit random)
+func NewAgentKey() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate agent key: %w", err)
+	}
+	return agentKeyPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// HashAgentKey DB 저장용 agent key 해시 (random key 이므로 sha256 으로 충분)
+func HashAgentKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return "sha256:" + hex.EncodeToString(sum[:])
+}